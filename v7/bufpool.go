@@ -0,0 +1,124 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+// maxPooledBufferSize is the capacity of the largest buffer that is
+// returned to the pool. Larger buffers are left to the garbage collector,
+// so that a few giant request or response bodies don't stay in memory.
+const maxPooledBufferSize = 1 << 20 // 1 MB
+
+var (
+	bufferPool = sync.Pool{
+		New: func() interface{} { return new(bytes.Buffer) },
+	}
+	gzipWriterPool sync.Pool
+)
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer returns the buffer to the pool unless it has grown
+// larger than maxPooledBufferSize.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// getGzipWriter returns a gzip writer from the pool that writes to w.
+func getGzipWriter(w io.Writer) *gzip.Writer {
+	if v := gzipWriterPool.Get(); v != nil {
+		gw := v.(*gzip.Writer)
+		gw.Reset(w)
+		return gw
+	}
+	return gzip.NewWriter(w)
+}
+
+// putGzipWriter returns the gzip writer to the pool.
+func putGzipWriter(w *gzip.Writer) {
+	w.Reset(ioutil.Discard) // don't hold on to the underlying writer
+	gzipWriterPool.Put(w)
+}
+
+// pooledBody is a request body backed by a buffer from the pool.
+// The buffer is returned to the pool when the body is closed, which
+// the HTTP transport does once it is done with the request. Reads
+// after Close return io.EOF.
+type pooledBody struct {
+	mu  sync.Mutex
+	buf *bytes.Buffer
+	r   *bytes.Reader
+}
+
+// newPooledBody creates a new request body from the given pooled buffer.
+func newPooledBody(buf *bytes.Buffer) *pooledBody {
+	return &pooledBody{buf: buf, r: bytes.NewReader(buf.Bytes())}
+}
+
+// Len returns the number of bytes of the body.
+func (b *pooledBody) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.buf == nil {
+		return 0
+	}
+	return b.r.Len()
+}
+
+// Read implements io.Reader.
+func (b *pooledBody) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.buf == nil {
+		return 0, io.EOF
+	}
+	return b.r.Read(p)
+}
+
+// Close returns the buffer to the pool.
+func (b *pooledBody) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.buf != nil {
+		putBuffer(b.buf)
+		b.buf = nil
+		b.r = nil
+	}
+	return nil
+}
+
+// readBody reads r into a slice of exactly the size of its content.
+// It reads into a buffer from the pool first, so that only the final
+// slice is allocated. contentLength is a hint about the size of the
+// body; pass -1 if it is unknown.
+func readBody(r io.Reader, contentLength int64) ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if contentLength > 0 && contentLength < maxPooledBufferSize {
+		buf.Grow(int(contentLength) + bytes.MinRead)
+	}
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	if buf.Len() == 0 {
+		return nil, nil
+	}
+	data := make([]byte, buf.Len())
+	copy(data, buf.Bytes())
+	return data, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestPooledBody(t *testing.T) {
+	buf := getBuffer()
+	buf.WriteString(`{"query":{"match_all":{}}}`)
+	body := newPooledBody(buf)
+
+	if want, have := buf.Len(), body.Len(); want != have {
+		t.Fatalf("expected Len=%d; got: %d", want, have)
+	}
+	p := make([]byte, 9)
+	if _, err := io.ReadFull(body, p); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := `{"query":`, string(p); want != have {
+		t.Fatalf("expected %q; got: %q", want, have)
+	}
+	if err := body.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := body.Close(); err != nil {
+		t.Fatalf("expected Close to be idempotent; got: %v", err)
+	}
+	if n, err := body.Read(p); n != 0 || err != io.EOF {
+		t.Fatalf("expected Read after Close to return (0, io.EOF); got: (%d, %v)", n, err)
+	}
+	if want, have := 0, body.Len(); want != have {
+		t.Fatalf("expected Len=%d after Close; got: %d", want, have)
+	}
+}
+
+func TestPutBufferSizeCap(t *testing.T) {
+	// The pool may drop buffers at any time, so we can only check that
+	// oversized buffers are not reset, i.e. not touched by putBuffer.
+	buf := new(bytes.Buffer)
+	buf.Grow(maxPooledBufferSize + 1)
+	buf.WriteString("giant")
+	putBuffer(buf)
+	if want, have := "giant", buf.String(); want != have {
+		t.Fatalf("expected oversized buffer to be left alone; got: %q", have)
+	}
+}
+
+func TestRequestSetBodyGzipPooled(t *testing.T) {
+	for _, body := range []interface{}{
+		`{"query":{"match_all":{}}}`,
+		map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}},
+	} {
+		// Run a few times to reuse buffers and gzip writers from the pool
+		for i := 0; i < 3; i++ {
+			req, err := NewRequest("POST", "/")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := req.SetBody(body, true); err != nil {
+				t.Fatal(err)
+			}
+			if req.ContentLength <= 0 {
+				t.Fatalf("expected ContentLength to be set; got: %d", req.ContentLength)
+			}
+			zr, err := gzip.NewReader(req.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := ioutil.ReadAll(zr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := req.Body.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if want, have := `{"query":{"match_all":{}}}`, string(data); want != have {
+				t.Fatalf("expected %q; got: %q", want, have)
+			}
+		}
+	}
+}
+
+func TestReadBody(t *testing.T) {
+	tests := []struct {
+		Body          string
+		ContentLength int64
+	}{
+		{"", 0},
+		{"", -1},
+		{`{"n":1}`, 7},
+		{`{"n":1}`, -1},
+		{strings.Repeat("x", 100000), 100000},
+		{strings.Repeat("x", maxPooledBufferSize+1), -1},
+	}
+	for i, tt := range tests {
+		data, err := readBody(strings.NewReader(tt.Body), tt.ContentLength)
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		if tt.Body == "" && data != nil {
+			t.Fatalf("case #%d: expected nil slice; got: %q", i+1, data)
+		}
+		if want, have := tt.Body, string(data); want != have {
+			t.Fatalf("case #%d: expected body of %d bytes; got: %d bytes", i+1, len(want), len(have))
+		}
+		if cap(data) != len(data) {
+			t.Fatalf("case #%d: expected cap=%d; got: %d", i+1, len(data), cap(data))
+		}
+	}
+}
+
+func BenchmarkRequestSetBodyMapGzipClosed(b *testing.B) {
+	body := map[string]interface{}{
+		"query": map[string]interface{}{
+			"match_all": map[string]interface{}{},
+		},
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req, err := NewRequest("GET", "/")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := req.SetBody(body, true); err != nil {
+			b.Fatal(err)
+		}
+		// The HTTP transport closes the body, releasing the buffer
+		req.Body.Close()
+		testReq = req
+	}
+}
+
+func BenchmarkReadBody(b *testing.B) {
+	for _, kb := range []int{1, 64, 512} {
+		data := bytes.Repeat([]byte("x"), kb<<10)
+		b.Run(fmt.Sprintf("ioutil.ReadAll/%dKB", kb), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ioutil.ReadAll(bytes.NewReader(data)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("readBody/%dKB", kb), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := readBody(bytes.NewReader(data), -1); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
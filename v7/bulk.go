@@ -4,12 +4,18 @@
 
 package elastic
 
+//go:generate easyjson -no_std_marshalers bulk.go
+
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/facert/elastic/v7/uritemplates"
 )
@@ -39,6 +45,13 @@ type BulkService struct {
 	waitForActiveShards string
 	pretty              bool
 
+	itemBackoff          Backoff // retry rejected items if not nil
+	retryItemStatusCodes []int
+	stream               bool
+
+	// serialized requests, by position in requests; nil if not serialized yet
+	sources [][]string
+
 	// estimated bulk size in bytes, up to the request index sizeInBytesCursor
 	sizeInBytes       int64
 	sizeInBytesCursor int
@@ -55,6 +68,7 @@ func NewBulkService(client *Client) *BulkService {
 // Reset cleans up the request queue
 func (s *BulkService) Reset() {
 	s.requests = make([]BulkableRequest, 0)
+	s.sources = nil
 	s.sizeInBytes = 0
 	s.sizeInBytesCursor = 0
 }
@@ -129,23 +143,77 @@ func (s *BulkService) Pretty(pretty bool) *BulkService {
 	return s
 }
 
+// Stream enables streaming the body of the bulk request to Elasticsearch
+// while it is being written, instead of building it in memory first.
+// If gzip compression is enabled in the client, the body is compressed
+// on the fly. It is disabled by default.
+//
+// Notice that the body is sent with chunked transfer encoding then, i.e.
+// without a Content-Length header.
+func (s *BulkService) Stream(stream bool) *BulkService {
+	s.stream = stream
+	return s
+}
+
+// RetryRejectedItems enables resubmitting the items of a successful bulk
+// request that failed with a retryable status code, using the given
+// backoff between attempts. Only the rejected items are resubmitted,
+// and Do returns a single response with the items in the order of the
+// requests. By default, 429 and 503 are retried; use RetryItemStatusCodes
+// to change that. Pass nil to disable it, which is the default.
+//
+// Notice that Do does not return an error if items still fail after
+// the backoff is exhausted; check the response for failed items. If the
+// context is canceled while waiting to retry, Do returns the response
+// so far together with the error of the context.
+func (s *BulkService) RetryRejectedItems(backoff Backoff) *BulkService {
+	s.itemBackoff = backoff
+	return s
+}
+
+// RetryItemStatusCodes sets the status codes of bulk response items that
+// are retried if RetryRejectedItems is enabled. Defaults to 429 and 503.
+func (s *BulkService) RetryItemStatusCodes(statusCodes ...int) *BulkService {
+	s.retryItemStatusCodes = statusCodes
+	return s
+}
+
 // Add adds bulkable requests, i.e. BulkIndexRequest, BulkUpdateRequest,
 // and/or BulkDeleteRequest.
 func (s *BulkService) Add(requests ...BulkableRequest) *BulkService {
 	for _, r := range requests {
 		s.requests = append(s.requests, r)
+		s.sources = append(s.sources, nil)
 	}
 	return s
 }
 
+// source returns the serialized i-th request. Requests are only
+// serialized once.
+func (s *BulkService) source(i int) ([]string, error) {
+	if s.sources[i] == nil {
+		var encoder Encoder
+		if s.client != nil {
+			encoder = s.client.encoder
+		}
+		lines, err := encodeBulkableRequest(s.requests[i], encoder)
+		if err != nil {
+			return nil, err
+		}
+		s.sources[i] = lines
+	}
+	return s.sources[i], nil
+}
+
 // EstimatedSizeInBytes returns the estimated size of all bulkable
 // requests added via Add.
 func (s *BulkService) EstimatedSizeInBytes() int64 {
 	if s.sizeInBytesCursor == len(s.requests) {
 		return s.sizeInBytes
 	}
-	for _, r := range s.requests[s.sizeInBytesCursor:] {
-		s.sizeInBytes += s.estimateSizeInBytes(r)
+	for i := s.sizeInBytesCursor; i < len(s.requests); i++ {
+		lines, _ := s.source(i)
+		s.sizeInBytes += estimateLinesSize(lines)
 		s.sizeInBytesCursor++
 	}
 	return s.sizeInBytes
@@ -155,7 +223,38 @@ func (s *BulkService) EstimatedSizeInBytes() int64 {
 // bulkable request, i.e. BulkIndexRequest, BulkUpdateRequest, and
 // BulkDeleteRequest.
 func (s *BulkService) estimateSizeInBytes(r BulkableRequest) int64 {
-	lines, _ := r.Source()
+	var encoder Encoder
+	if s.client != nil {
+		encoder = s.client.encoder
+	}
+	return estimateBulkableRequestSize(r, encoder)
+}
+
+// estimateBulkableRequestSize returns the estimated size of the
+// given bulkable request in bytes.
+func estimateBulkableRequestSize(r BulkableRequest, encoder Encoder) int64 {
+	lines, _ := encodeBulkableRequest(r, encoder)
+	return estimateLinesSize(lines)
+}
+
+// bulkableRequestEncoder is implemented by the bulkable requests that
+// can be encoded with a custom Encoder.
+type bulkableRequestEncoder interface {
+	encodeSource(encoder Encoder) ([]string, error)
+}
+
+// encodeBulkableRequest returns the on-wire representation of the request,
+// encoded with the given encoder if the request supports it.
+func encodeBulkableRequest(r BulkableRequest, encoder Encoder) ([]string, error) {
+	if e, ok := r.(bulkableRequestEncoder); ok && encoder != nil {
+		return e.encodeSource(encoder)
+	}
+	return r.Source()
+}
+
+// estimateLinesSize returns the size of the given lines of a bulk
+// request body in bytes.
+func estimateLinesSize(lines []string) int64 {
 	size := 0
 	for _, line := range lines {
 		// +1 for the \n
@@ -164,6 +263,21 @@ func (s *BulkService) estimateSizeInBytes(r BulkableRequest) int64 {
 	return int64(size)
 }
 
+// replace replaces the i-th request, keeping the estimated size
+// in bytes up to date.
+func (s *BulkService) replace(i int, r BulkableRequest) {
+	if i < s.sizeInBytesCursor {
+		old, _ := s.source(i)
+		s.sizeInBytes -= estimateLinesSize(old)
+	}
+	s.requests[i] = r
+	s.sources[i] = nil
+	if i < s.sizeInBytesCursor {
+		lines, _ := s.source(i)
+		s.sizeInBytes += estimateLinesSize(lines)
+	}
+}
+
 // NumberOfActions returns the number of bulkable requests that need to
 // be sent to Elasticsearch on the next batch.
 func (s *BulkService) NumberOfActions() int {
@@ -174,8 +288,8 @@ func (s *BulkService) bodyAsString() (string, error) {
 	// Pre-allocate to reduce allocs
 	buf := bytes.NewBuffer(make([]byte, 0, s.EstimatedSizeInBytes()))
 
-	for _, req := range s.requests {
-		source, err := req.Source()
+	for i := range s.requests {
+		source, err := s.source(i)
 		if err != nil {
 			return "", err
 		}
@@ -192,15 +306,114 @@ func (s *BulkService) bodyAsString() (string, error) {
 // you can reuse the BulkService for the next batch as the list of bulk
 // requests is cleared on success.
 func (s *BulkService) Do(ctx context.Context) (*BulkResponse, error) {
+	if s.itemBackoff != nil {
+		return s.doWithItemRetries(ctx)
+	}
+	return s.do(ctx)
+}
+
+// doWithItemRetries commits the batched requests and then resubmits
+// the rejected items until they succeed or the backoff stops.
+func (s *BulkService) doWithItemRetries(ctx context.Context) (*BulkResponse, error) {
+	requests := s.requests
+	ret, err := s.do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(ret.Items) != len(requests) {
+		// Cannot match items to requests
+		return ret, nil
+	}
+
+	statusCodes := s.retryItemStatusCodes
+	if statusCodes == nil {
+		statusCodes = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}
+	}
+	retryable := func(item map[string]*BulkResponseItem) bool {
+		for _, result := range item {
+			for _, code := range statusCodes {
+				if result.Status == code {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	// Use a copy of the service for the retries, so that s can be reused
+	retrySvc := *s
+	retrySvc.itemBackoff = nil
+	for retry := 1; ; retry++ {
+		var positions []int
+		for i, item := range ret.Items {
+			if retryable(item) {
+				positions = append(positions, i)
+			}
+		}
+		if len(positions) == 0 {
+			break
+		}
+		wait, ok := s.itemBackoff.Next(retry)
+		if !ok {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			ret.Errors = len(ret.Failed()) > 0
+			return ret, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		retrySvc.Reset()
+		for _, i := range positions {
+			retrySvc.Add(requests[i])
+		}
+		res, err := retrySvc.do(ctx)
+		if err != nil {
+			// Failed as a whole: Try again after the next backoff
+			s.client.errorf("elastic: bulk retry of %d rejected items failed: %v", len(positions), err)
+			continue
+		}
+		ret.Took += res.Took
+		if len(res.Items) != len(positions) {
+			break
+		}
+		for j, i := range positions {
+			ret.Items[i] = res.Items[j]
+		}
+	}
+
+	ret.Errors = len(ret.Failed()) > 0
+	return ret, nil
+}
+
+// do sends the batched requests to Elasticsearch once.
+func (s *BulkService) do(ctx context.Context) (*BulkResponse, error) {
 	// No actions?
 	if s.NumberOfActions() == 0 {
 		return nil, errors.New("elastic: No bulk actions to commit")
 	}
 
 	// Get body
-	body, err := s.bodyAsString()
-	if err != nil {
-		return nil, err
+	var body interface{}
+	if s.stream {
+		// Serialize all requests up front, so that errors are not
+		// mistaken for connection errors while streaming
+		lines := make([][]string, len(s.requests))
+		for i := range s.requests {
+			source, err := s.source(i)
+			if err != nil {
+				return nil, err
+			}
+			lines[i] = source
+		}
+		body = bulkBody(lines)
+	} else {
+		var err error
+		body, err = s.bodyAsString()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Build url
@@ -271,6 +484,25 @@ func (s *BulkService) Do(ctx context.Context) (*BulkResponse, error) {
 	return ret, nil
 }
 
+// bulkBody is the body of a bulk request that is streamed to Elasticsearch.
+type bulkBody [][]string
+
+// WriteBody writes all lines of the bulk request to w.
+func (b bulkBody) WriteBody(w io.Writer) error {
+	bw := bufio.NewWriterSize(w, 32<<10)
+	for _, lines := range b {
+		for _, line := range lines {
+			if _, err := bw.WriteString(line); err != nil {
+				return err
+			}
+			if err := bw.WriteByte('\n'); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
 // BulkResponse is a response to a bulk execution.
 //
 // Example:
@@ -312,6 +544,7 @@ func (s *BulkService) Do(ctx context.Context) (*BulkResponse, error) {
 //     }
 //   }]
 // }
+//easyjson:json
 type BulkResponse struct {
 	Took   int                            `json:"took,omitempty"`
 	Errors bool                           `json:"errors,omitempty"`
@@ -319,6 +552,7 @@ type BulkResponse struct {
 }
 
 // BulkResponseItem is the result of a single bulk request.
+//easyjson:json
 type BulkResponseItem struct {
 	Index         string        `json:"_index,omitempty"`
 	Type          string        `json:"_type,omitempty"`
@@ -7,7 +7,6 @@ package elastic
 //go:generate easyjson bulk_delete_request.go
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -149,6 +148,12 @@ func (r *BulkDeleteRequest) String() string {
 // See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/docs-bulk.html
 // for details.
 func (r *BulkDeleteRequest) Source() ([]string, error) {
+	return r.encodeSource(&DefaultEncoder{})
+}
+
+// encodeSource returns the on-wire representation of the request,
+// encoding it with the given encoder unless easyjson is enabled.
+func (r *BulkDeleteRequest) encodeSource(encoder Encoder) ([]string, error) {
 	if r.source != nil {
 		return r.source, nil
 	}
@@ -172,8 +177,8 @@ func (r *BulkDeleteRequest) Source() ([]string, error) {
 		// easyjson
 		body, err = command.MarshalJSON()
 	} else {
-		// encoding/json
-		body, err = json.Marshal(command)
+		// encoding/json or custom encoder
+		body, err = encoder.Encode(command)
 	}
 	if err != nil {
 		return nil, err
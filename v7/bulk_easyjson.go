@@ -0,0 +1,900 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package elastic
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson96d41fe8DecodeGithubComFacertElasticV7(in *jlexer.Lexer, out *BulkResponseItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "_index":
+			out.Index = string(in.String())
+		case "_type":
+			out.Type = string(in.String())
+		case "_id":
+			out.Id = string(in.String())
+		case "_version":
+			out.Version = int64(in.Int64())
+		case "result":
+			out.Result = string(in.String())
+		case "_shards":
+			if in.IsNull() {
+				in.Skip()
+				out.Shards = nil
+			} else {
+				if out.Shards == nil {
+					out.Shards = new(ShardsInfo)
+				}
+				easyjson96d41fe8DecodeGithubComFacertElasticV71(in, out.Shards)
+			}
+		case "_seq_no":
+			out.SeqNo = int64(in.Int64())
+		case "_primary_term":
+			out.PrimaryTerm = int64(in.Int64())
+		case "status":
+			out.Status = int(in.Int())
+		case "forced_refresh":
+			out.ForcedRefresh = bool(in.Bool())
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+				out.Error = nil
+			} else {
+				if out.Error == nil {
+					out.Error = new(ErrorDetails)
+				}
+				easyjson96d41fe8DecodeGithubComFacertElasticV72(in, out.Error)
+			}
+		case "get":
+			if in.IsNull() {
+				in.Skip()
+				out.GetResult = nil
+			} else {
+				if out.GetResult == nil {
+					out.GetResult = new(GetResult)
+				}
+				(*out.GetResult).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96d41fe8EncodeGithubComFacertElasticV7(out *jwriter.Writer, in BulkResponseItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Index != "" {
+		const prefix string = ",\"_index\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Index))
+	}
+	if in.Type != "" {
+		const prefix string = ",\"_type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Type))
+	}
+	if in.Id != "" {
+		const prefix string = ",\"_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Id))
+	}
+	if in.Version != 0 {
+		const prefix string = ",\"_version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
+	if in.Result != "" {
+		const prefix string = ",\"result\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Result))
+	}
+	if in.Shards != nil {
+		const prefix string = ",\"_shards\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson96d41fe8EncodeGithubComFacertElasticV71(out, *in.Shards)
+	}
+	if in.SeqNo != 0 {
+		const prefix string = ",\"_seq_no\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.SeqNo))
+	}
+	if in.PrimaryTerm != 0 {
+		const prefix string = ",\"_primary_term\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.PrimaryTerm))
+	}
+	if in.Status != 0 {
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Status))
+	}
+	if in.ForcedRefresh {
+		const prefix string = ",\"forced_refresh\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.ForcedRefresh))
+	}
+	if in.Error != nil {
+		const prefix string = ",\"error\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson96d41fe8EncodeGithubComFacertElasticV72(out, *in.Error)
+	}
+	if in.GetResult != nil {
+		const prefix string = ",\"get\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.GetResult).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BulkResponseItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson96d41fe8EncodeGithubComFacertElasticV7(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BulkResponseItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson96d41fe8DecodeGithubComFacertElasticV7(l, v)
+}
+func easyjson96d41fe8DecodeGithubComFacertElasticV72(in *jlexer.Lexer, out *ErrorDetails) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "resource.type":
+			out.ResourceType = string(in.String())
+		case "resource.id":
+			out.ResourceId = string(in.String())
+		case "index":
+			out.Index = string(in.String())
+		case "phase":
+			out.Phase = string(in.String())
+		case "grouped":
+			out.Grouped = bool(in.Bool())
+		case "caused_by":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.CausedBy = make(map[string]interface{})
+				} else {
+					out.CausedBy = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v1 interface{}
+					if m, ok := v1.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v1.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v1 = in.Interface()
+					}
+					(out.CausedBy)[key] = v1
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "root_cause":
+			if in.IsNull() {
+				in.Skip()
+				out.RootCause = nil
+			} else {
+				in.Delim('[')
+				if out.RootCause == nil {
+					if !in.IsDelim(']') {
+						out.RootCause = make([]*ErrorDetails, 0, 8)
+					} else {
+						out.RootCause = []*ErrorDetails{}
+					}
+				} else {
+					out.RootCause = (out.RootCause)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 *ErrorDetails
+					if in.IsNull() {
+						in.Skip()
+						v2 = nil
+					} else {
+						if v2 == nil {
+							v2 = new(ErrorDetails)
+						}
+						easyjson96d41fe8DecodeGithubComFacertElasticV72(in, v2)
+					}
+					out.RootCause = append(out.RootCause, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "failed_shards":
+			if in.IsNull() {
+				in.Skip()
+				out.FailedShards = nil
+			} else {
+				in.Delim('[')
+				if out.FailedShards == nil {
+					if !in.IsDelim(']') {
+						out.FailedShards = make([]map[string]interface{}, 0, 8)
+					} else {
+						out.FailedShards = []map[string]interface{}{}
+					}
+				} else {
+					out.FailedShards = (out.FailedShards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 map[string]interface{}
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v3 = make(map[string]interface{})
+						} else {
+							v3 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v4 interface{}
+							if m, ok := v4.(easyjson.Unmarshaler); ok {
+								m.UnmarshalEasyJSON(in)
+							} else if m, ok := v4.(json.Unmarshaler); ok {
+								_ = m.UnmarshalJSON(in.Raw())
+							} else {
+								v4 = in.Interface()
+							}
+							(v3)[key] = v4
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					out.FailedShards = append(out.FailedShards, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96d41fe8EncodeGithubComFacertElasticV72(out *jwriter.Writer, in ErrorDetails) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	if in.ResourceType != "" {
+		const prefix string = ",\"resource.type\":"
+		out.RawString(prefix)
+		out.String(string(in.ResourceType))
+	}
+	if in.ResourceId != "" {
+		const prefix string = ",\"resource.id\":"
+		out.RawString(prefix)
+		out.String(string(in.ResourceId))
+	}
+	if in.Index != "" {
+		const prefix string = ",\"index\":"
+		out.RawString(prefix)
+		out.String(string(in.Index))
+	}
+	if in.Phase != "" {
+		const prefix string = ",\"phase\":"
+		out.RawString(prefix)
+		out.String(string(in.Phase))
+	}
+	if in.Grouped {
+		const prefix string = ",\"grouped\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Grouped))
+	}
+	if len(in.CausedBy) != 0 {
+		const prefix string = ",\"caused_by\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v5First := true
+			for v5Name, v5Value := range in.CausedBy {
+				if v5First {
+					v5First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v5Name))
+				out.RawByte(':')
+				if m, ok := v5Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v5Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v5Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.RootCause) != 0 {
+		const prefix string = ",\"root_cause\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v6, v7 := range in.RootCause {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				if v7 == nil {
+					out.RawString("null")
+				} else {
+					easyjson96d41fe8EncodeGithubComFacertElasticV72(out, *v7)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.FailedShards) != 0 {
+		const prefix string = ",\"failed_shards\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.FailedShards {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				if v9 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+					out.RawString(`null`)
+				} else {
+					out.RawByte('{')
+					v10First := true
+					for v10Name, v10Value := range v9 {
+						if v10First {
+							v10First = false
+						} else {
+							out.RawByte(',')
+						}
+						out.String(string(v10Name))
+						out.RawByte(':')
+						if m, ok := v10Value.(easyjson.Marshaler); ok {
+							m.MarshalEasyJSON(out)
+						} else if m, ok := v10Value.(json.Marshaler); ok {
+							out.Raw(m.MarshalJSON())
+						} else {
+							out.Raw(json.Marshal(v10Value))
+						}
+					}
+					out.RawByte('}')
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson96d41fe8DecodeGithubComFacertElasticV71(in *jlexer.Lexer, out *ShardsInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "total":
+			out.Total = int(in.Int())
+		case "successful":
+			out.Successful = int(in.Int())
+		case "failed":
+			out.Failed = int(in.Int())
+		case "failures":
+			if in.IsNull() {
+				in.Skip()
+				out.Failures = nil
+			} else {
+				in.Delim('[')
+				if out.Failures == nil {
+					if !in.IsDelim(']') {
+						out.Failures = make([]*ShardFailure, 0, 8)
+					} else {
+						out.Failures = []*ShardFailure{}
+					}
+				} else {
+					out.Failures = (out.Failures)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 *ShardFailure
+					if in.IsNull() {
+						in.Skip()
+						v11 = nil
+					} else {
+						if v11 == nil {
+							v11 = new(ShardFailure)
+						}
+						easyjson96d41fe8DecodeGithubComFacertElasticV73(in, v11)
+					}
+					out.Failures = append(out.Failures, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96d41fe8EncodeGithubComFacertElasticV71(out *jwriter.Writer, in ShardsInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Total))
+	}
+	{
+		const prefix string = ",\"successful\":"
+		out.RawString(prefix)
+		out.Int(int(in.Successful))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int(int(in.Failed))
+	}
+	if len(in.Failures) != 0 {
+		const prefix string = ",\"failures\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v12, v13 := range in.Failures {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				if v13 == nil {
+					out.RawString("null")
+				} else {
+					easyjson96d41fe8EncodeGithubComFacertElasticV73(out, *v13)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson96d41fe8DecodeGithubComFacertElasticV73(in *jlexer.Lexer, out *ShardFailure) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "_index":
+			out.Index = string(in.String())
+		case "_shard":
+			out.Shard = int(in.Int())
+		case "_node":
+			out.Node = string(in.String())
+		case "reason":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Reason = make(map[string]interface{})
+				} else {
+					out.Reason = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v14 interface{}
+					if m, ok := v14.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v14.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v14 = in.Interface()
+					}
+					(out.Reason)[key] = v14
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "status":
+			out.Status = string(in.String())
+		case "primary":
+			out.Primary = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96d41fe8EncodeGithubComFacertElasticV73(out *jwriter.Writer, in ShardFailure) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Index != "" {
+		const prefix string = ",\"_index\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Index))
+	}
+	if in.Shard != 0 {
+		const prefix string = ",\"_shard\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Shard))
+	}
+	if in.Node != "" {
+		const prefix string = ",\"_node\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Node))
+	}
+	if len(in.Reason) != 0 {
+		const prefix string = ",\"reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v15First := true
+			for v15Name, v15Value := range in.Reason {
+				if v15First {
+					v15First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v15Name))
+				out.RawByte(':')
+				if m, ok := v15Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v15Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v15Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.Status != "" {
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Status))
+	}
+	if in.Primary {
+		const prefix string = ",\"primary\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Primary))
+	}
+	out.RawByte('}')
+}
+func easyjson96d41fe8DecodeGithubComFacertElasticV74(in *jlexer.Lexer, out *BulkResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "took":
+			out.Took = int(in.Int())
+		case "errors":
+			out.Errors = bool(in.Bool())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]map[string]*BulkResponseItem, 0, 8)
+					} else {
+						out.Items = []map[string]*BulkResponseItem{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 map[string]*BulkResponseItem
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v16 = make(map[string]*BulkResponseItem)
+						} else {
+							v16 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v17 *BulkResponseItem
+							if in.IsNull() {
+								in.Skip()
+								v17 = nil
+							} else {
+								if v17 == nil {
+									v17 = new(BulkResponseItem)
+								}
+								(*v17).UnmarshalEasyJSON(in)
+							}
+							(v16)[key] = v17
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					out.Items = append(out.Items, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96d41fe8EncodeGithubComFacertElasticV74(out *jwriter.Writer, in BulkResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Took != 0 {
+		const prefix string = ",\"took\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int(int(in.Took))
+	}
+	if in.Errors {
+		const prefix string = ",\"errors\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Errors))
+	}
+	if len(in.Items) != 0 {
+		const prefix string = ",\"items\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v18, v19 := range in.Items {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				if v19 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+					out.RawString(`null`)
+				} else {
+					out.RawByte('{')
+					v20First := true
+					for v20Name, v20Value := range v19 {
+						if v20First {
+							v20First = false
+						} else {
+							out.RawByte(',')
+						}
+						out.String(string(v20Name))
+						out.RawByte(':')
+						if v20Value == nil {
+							out.RawString("null")
+						} else {
+							(*v20Value).MarshalEasyJSON(out)
+						}
+					}
+					out.RawByte('}')
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BulkResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson96d41fe8EncodeGithubComFacertElasticV74(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BulkResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson96d41fe8DecodeGithubComFacertElasticV74(l, v)
+}
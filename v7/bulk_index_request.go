@@ -191,6 +191,12 @@ func (r *BulkIndexRequest) String() string {
 // See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/docs-bulk.html
 // for details.
 func (r *BulkIndexRequest) Source() ([]string, error) {
+	return r.encodeSource(&DefaultEncoder{})
+}
+
+// encodeSource returns the on-wire representation of the request,
+// encoding it with the given encoder unless easyjson is enabled.
+func (r *BulkIndexRequest) encodeSource(encoder Encoder) ([]string, error) {
 	// { "index" : { "_index" : "test", "_type" : "type1", "_id" : "1" } }
 	// { "field1" : "value1" }
 
@@ -224,8 +230,8 @@ func (r *BulkIndexRequest) Source() ([]string, error) {
 		// easyjson
 		body, err = command.MarshalJSON()
 	} else {
-		// encoding/json
-		body, err = json.Marshal(command)
+		// encoding/json or custom encoder
+		body, err = encoder.Encode(command)
 	}
 	if err != nil {
 		return nil, err
@@ -237,7 +243,7 @@ func (r *BulkIndexRequest) Source() ([]string, error) {
 	if r.doc != nil {
 		switch t := r.doc.(type) {
 		default:
-			body, err := json.Marshal(r.doc)
+			body, err := encoder.Encode(r.doc)
 			if err != nil {
 				return nil, err
 			}
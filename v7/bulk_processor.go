@@ -52,6 +52,12 @@ type BulkProcessorService struct {
 	wantStats            bool          // indicates whether to gather statistics
 	backoff              Backoff       // a custom Backoff to use for errors
 	retryItemStatusCodes []int         // array of status codes for bulk response line items that may be retried
+	deadLetter           BulkDeadLetterSink
+	bufferSize           int                    // # of bytes to buffer, or 0 to disable
+	adaptive             *BulkProcessorAdaptive // adaptive mode, or nil to disable
+	ordered              bool                   // commit requests with the same ordering key in order
+	orderingKey          BulkOrderingKeyFunc    // ordering key, or nil for BulkOrderingKeyByDocument
+	mergeUpdates         bool                   // merge consecutive updates of the same document
 }
 
 // NewBulkProcessorService creates a new BulkProcessorService.
@@ -148,6 +154,83 @@ func (s *BulkProcessorService) RetryItemStatusCodes(retryItemStatusCodes ...int)
 	return s
 }
 
+// DeadLetter specifies a sink that receives every bulk request the
+// processor gave up on, together with its last response item and
+// the number of retries. By default, such requests are only reported
+// via the After callback.
+//
+// Notice that with a dead letter sink, requests that still fail with
+// one of the RetryItemStatusCodes after the backoff is exhausted are
+// sent to the sink instead of being committed again with the next batch.
+func (s *BulkProcessorService) DeadLetter(sink BulkDeadLetterSink) *BulkProcessorService {
+	s.deadLetter = sink
+	return s
+}
+
+// BufferSize bounds the (estimated) number of bytes of requests that
+// have been added to the processor but are not committed yet. If the
+// buffer is full, Add blocks until enough requests have been committed,
+// and TryAdd returns ErrBulkProcessorBufferFull. Requests are queued in
+// the buffer, so Add doesn't block while all workers are committing.
+//
+// The buffer is disabled by default, i.e. Add blocks until a worker
+// picks up the request.
+func (s *BulkProcessorService) BufferSize(bufferSize int) *BulkProcessorService {
+	s.bufferSize = bufferSize
+	return s
+}
+
+// Adaptive enables the adaptive mode, in which the processor adjusts
+// the number of actions per bulk request and the number of concurrent
+// commits from the observed latency and 429 rejections. See
+// BulkProcessorAdaptive for details. Pass nil to disable it, which is
+// the default.
+func (s *BulkProcessorService) Adaptive(adaptive *BulkProcessorAdaptive) *BulkProcessorService {
+	s.adaptive = adaptive
+	return s
+}
+
+// Ordered enables the ordering mode, in which all requests for the same
+// document are committed by the same worker, in the order they have been
+// added. Requests are assigned to workers by a hash of their index and id,
+// or of the key returned by the function passed to OrderingKey. It is
+// disabled by default, i.e. with more than one worker, two operations
+// on the same document may be committed out of order.
+//
+// Notice that a request retried because of one of the
+// RetryItemStatusCodes may still be applied after a subsequent request
+// for the same document in the same bulk request. Elasticsearch usually
+// rejects all items of a shard at once, however.
+func (s *BulkProcessorService) Ordered(ordered bool) *BulkProcessorService {
+	s.ordered = ordered
+	return s
+}
+
+// OrderingKey specifies the function that returns the ordering key of
+// a request in ordering mode. It enables the ordering mode if fn is not
+// nil. Use it e.g. to preserve the order of operations per customer
+// instead of per document. Defaults to BulkOrderingKeyByDocument.
+func (s *BulkProcessorService) OrderingKey(fn BulkOrderingKeyFunc) *BulkProcessorService {
+	s.orderingKey = fn
+	if fn != nil {
+		s.ordered = true
+	}
+	return s
+}
+
+// MergeUpdates enables merging consecutive partial document updates of
+// the same document within a bulk request into a single update. Updates
+// with a script, an upsert document, a version, or sequence number and
+// primary term are never merged, and neither are updates separated by
+// another operation on the same document. It is disabled by default.
+//
+// Merging is most effective in ordering mode, where all updates of a
+// document end up in the same worker.
+func (s *BulkProcessorService) MergeUpdates(mergeUpdates bool) *BulkProcessorService {
+	s.mergeUpdates = mergeUpdates
+	return s
+}
+
 // Do creates a new BulkProcessor and starts it.
 // Consider the BulkProcessor as a running instance that accepts bulk requests
 // and commits them to Elasticsearch, spreading the work across one or more
@@ -170,6 +253,14 @@ func (s *BulkProcessorService) Do(ctx context.Context) (*BulkProcessor, error) {
 		retryItemStatusCodes[code] = struct{}{}
 	}
 
+	var orderingKey BulkOrderingKeyFunc
+	if s.ordered {
+		orderingKey = s.orderingKey
+		if orderingKey == nil {
+			orderingKey = BulkOrderingKeyByDocument
+		}
+	}
+
 	p := newBulkProcessor(
 		s.c,
 		s.beforeFn,
@@ -181,7 +272,12 @@ func (s *BulkProcessorService) Do(ctx context.Context) (*BulkProcessor, error) {
 		s.flushInterval,
 		s.wantStats,
 		s.backoff,
-		retryItemStatusCodes)
+		retryItemStatusCodes,
+		s.deadLetter,
+		s.bufferSize,
+		s.adaptive,
+		orderingKey,
+		s.mergeUpdates)
 
 	err := p.Start(ctx)
 	if err != nil {
@@ -204,6 +300,12 @@ type BulkProcessorStats struct {
 	Succeeded int64 // # of requests that ES reported as successful
 	Failed    int64 // # of requests that ES reported as failed
 
+	DeadLettered int64 // # of requests sent to the dead letter sink
+
+	BufferedBytes int64 // # of bytes in the buffer (see BufferSize)
+	BulkActions   int   // current # of actions per bulk request in adaptive mode
+	Concurrency   int   // current # of concurrent commits in adaptive mode
+
 	Workers []*BulkProcessorWorkerStats // stats for each worker
 }
 
@@ -234,6 +336,10 @@ func (st *BulkProcessorStats) dup() *BulkProcessorStats {
 	dst.Deleted = st.Deleted
 	dst.Succeeded = st.Succeeded
 	dst.Failed = st.Failed
+	dst.DeadLettered = st.DeadLettered
+	dst.BufferedBytes = st.BufferedBytes
+	dst.BulkActions = st.BulkActions
+	dst.Concurrency = st.Concurrency
 	for _, src := range st.Workers {
 		dst.Workers = append(dst.Workers, src.dup())
 	}
@@ -263,7 +369,8 @@ type BulkProcessor struct {
 	bulkSize             int
 	numWorkers           int
 	executionId          int64
-	requestsC            chan BulkableRequest
+	roundRobin           uint64 // assigns requests without ordering key to workers
+	requestsC            chan bulkQueuedRequest // shared by all workers unless ordered
 	workerWg             sync.WaitGroup
 	workers              []*bulkWorker
 	flushInterval        time.Duration
@@ -271,6 +378,13 @@ type BulkProcessor struct {
 	wantStats            bool
 	retryItemStatusCodes map[int]struct{}
 	backoff              Backoff
+	deadLetter           BulkDeadLetterSink
+	bufferSize           int
+	adaptiveOpts         *BulkProcessorAdaptive
+	buffer               *bulkBuffer             // nil if disabled
+	adaptive             *bulkAdaptiveController // nil if disabled
+	orderingKey          BulkOrderingKeyFunc     // nil if not ordered
+	mergeUpdates         bool
 
 	startedMu sync.Mutex // guards the following block
 	started   bool
@@ -292,7 +406,12 @@ func newBulkProcessor(
 	flushInterval time.Duration,
 	wantStats bool,
 	backoff Backoff,
-	retryItemStatusCodes map[int]struct{}) *BulkProcessor {
+	retryItemStatusCodes map[int]struct{},
+	deadLetter BulkDeadLetterSink,
+	bufferSize int,
+	adaptive *BulkProcessorAdaptive,
+	orderingKey BulkOrderingKeyFunc,
+	mergeUpdates bool) *BulkProcessor {
 	return &BulkProcessor{
 		c:                    client,
 		beforeFn:             beforeFn,
@@ -305,6 +424,11 @@ func newBulkProcessor(
 		wantStats:            wantStats,
 		retryItemStatusCodes: retryItemStatusCodes,
 		backoff:              backoff,
+		deadLetter:           deadLetter,
+		bufferSize:           bufferSize,
+		adaptiveOpts:         adaptive,
+		orderingKey:          orderingKey,
+		mergeUpdates:         mergeUpdates,
 	}
 }
 
@@ -323,10 +447,15 @@ func (p *BulkProcessor) Start(ctx context.Context) error {
 		p.numWorkers = 1
 	}
 
-	p.requestsC = make(chan BulkableRequest)
+	p.requestsC = make(chan bulkQueuedRequest)
 	p.executionId = 0
 	p.stats = newBulkProcessorStats(p.numWorkers)
 	p.stopReconnC = make(chan struct{})
+	p.buffer = nil
+	p.adaptive = nil
+	if p.adaptiveOpts != nil {
+		p.adaptive = newBulkAdaptiveController(*p.adaptiveOpts, p.bulkActions, p.numWorkers)
+	}
 
 	// Create and start up workers.
 	p.workers = make([]*bulkWorker, p.numWorkers)
@@ -336,6 +465,12 @@ func (p *BulkProcessor) Start(ctx context.Context) error {
 		go p.workers[i].work(ctx)
 	}
 
+	// Start the dispatcher for the buffer (if enabled)
+	if p.bufferSize > 0 {
+		p.buffer = newBulkBuffer(p, int64(p.bufferSize))
+		go p.buffer.dispatch()
+	}
+
 	// Start the ticker for flush (if enabled)
 	if int64(p.flushInterval) > 0 {
 		p.flusherStopC = make(chan struct{})
@@ -379,8 +514,13 @@ func (p *BulkProcessor) Close() error {
 		p.flusherStopC = nil
 	}
 
-	// Stop all workers.
-	close(p.requestsC)
+	// Stop all workers. If there is a buffer, its dispatcher closes
+	// the requests channel after handing over all queued requests.
+	if p.buffer != nil {
+		p.buffer.close()
+	} else {
+		p.closeRequests()
+	}
 	p.workerWg.Wait()
 
 	p.started = false
@@ -393,15 +533,119 @@ func (p *BulkProcessor) Close() error {
 // the service that created this processor.
 func (p *BulkProcessor) Stats() BulkProcessorStats {
 	p.statsMu.Lock()
-	defer p.statsMu.Unlock()
-	return *p.stats.dup()
+	stats := p.stats.dup()
+	p.statsMu.Unlock()
+	if p.buffer != nil {
+		stats.BufferedBytes = p.buffer.bytes()
+	}
+	if p.adaptive != nil {
+		stats.BulkActions = p.adaptive.bulkActions()
+		stats.Concurrency = p.adaptive.workers()
+	}
+	return *stats
 }
 
 // Add adds a single request to commit by the BulkProcessorService.
 //
+// If a buffer is enabled (see BufferSize), a request added while or after
+// the processor is closed cannot be committed. It is logged as an error
+// and passed to the DeadLetter sink, if any. Use TryAdd or AddWithResult
+// to handle the error instead.
+//
 // The caller is responsible for setting the index and type on the request.
 func (p *BulkProcessor) Add(request BulkableRequest) {
-	p.requestsC <- request
+	if err := p.add(bulkQueuedRequest{request: request}, true); err != nil {
+		p.c.errorf("elastic: bulk processor %q dropped request: %v", p.name, err)
+		p.sendDeadLetter(request, nil, err, 0)
+	}
+}
+
+// TryAdd adds a single request to commit by the BulkProcessorService
+// if it can do so without blocking. It returns ErrBulkProcessorBufferFull
+// if the buffer is full (see BufferSize) or, without a buffer, if no
+// worker is ready to pick up the request. Use it to shed load when the
+// cluster cannot keep up.
+//
+// The caller is responsible for setting the index and type on the request.
+func (p *BulkProcessor) TryAdd(request BulkableRequest) error {
+	return p.add(bulkQueuedRequest{request: request}, false)
+}
+
+// AddWithResult adds a single request to commit by the BulkProcessorService,
+// just like Add. It returns a BulkFuture that resolves when this request
+// has been committed successfully, failed permanently, or was dropped
+// because it could not be committed when closing the processor. Requests
+// that fail with one of the RetryItemStatusCodes are retried and only
+// resolve when they finally succeed or fail.
+//
+// The caller is responsible for setting the index and type on the request.
+func (p *BulkProcessor) AddWithResult(request BulkableRequest) *BulkFuture {
+	future := newBulkFuture(request)
+	if err := p.add(bulkQueuedRequest{request: request, future: future}, true); err != nil {
+		future.resolve(nil, err, 0)
+	}
+	return future
+}
+
+// sendDeadLetter sends a request the processor gave up on to the dead
+// letter sink, if any.
+func (p *BulkProcessor) sendDeadLetter(req BulkableRequest, item *BulkResponseItem, err error, retries int) {
+	if p.deadLetter == nil {
+		return
+	}
+	letter := &BulkDeadLetter{
+		Request: req,
+		Item:    item,
+		Err:     err,
+		Retries: retries,
+	}
+	if err := p.deadLetter.Send(letter); err != nil {
+		p.c.errorf("elastic: bulk processor %q was unable to send dead letter: %v", p.name, err)
+	}
+	p.statsMu.Lock()
+	if p.wantStats {
+		p.stats.DeadLettered++
+	}
+	p.statsMu.Unlock()
+}
+
+// add passes the request on to the buffer, if enabled, or to the workers.
+func (p *BulkProcessor) add(req bulkQueuedRequest, block bool) error {
+	if p.buffer != nil {
+		req.size = estimateBulkableRequestSize(req.request, p.c.encoder)
+		return p.buffer.put(req, block)
+	}
+	return p.send(req, block)
+}
+
+// send hands the request to a worker: In ordering mode, to the worker
+// determined by its ordering key, otherwise to any worker.
+func (p *BulkProcessor) send(req bulkQueuedRequest, block bool) error {
+	requestsC := p.requestsC
+	if p.orderingKey != nil {
+		requestsC = p.worker(req.request).requestsC
+	}
+	if block {
+		requestsC <- req
+		return nil
+	}
+	select {
+	case requestsC <- req:
+		return nil
+	default:
+		return ErrBulkProcessorBufferFull
+	}
+}
+
+// closeRequests closes the requests channels, which stops the workers.
+func (p *BulkProcessor) closeRequests() {
+	if p.orderingKey != nil {
+		for _, w := range p.workers {
+			close(w.requestsC)
+		}
+		return
+	}
+	close(p.requestsC)
 }
 
 // Flush manually asks all workers to commit their outstanding requests.
@@ -411,11 +655,21 @@ func (p *BulkProcessor) Flush() error {
 	p.stats.Flushed++
 	p.statsMu.Unlock()
 
+	// Hand all buffered requests to the workers first
+	if p.buffer != nil {
+		p.buffer.drain()
+	}
+	p.flushWorkers()
+	return nil
+}
+
+// flushWorkers asks all workers to commit their outstanding requests
+// and waits for completion.
+func (p *BulkProcessor) flushWorkers() {
 	for _, w := range p.workers {
 		w.flushC <- struct{}{}
 		<-w.flushAckC // wait for completion
 	}
-	return nil
 }
 
 // flusher is a single goroutine that periodically asks all workers to
@@ -439,24 +693,50 @@ func (p *BulkProcessor) flusher(interval time.Duration) {
 
 // -- Bulk Worker --
 
+// bulkQueuedRequest is a request added to the bulk processor.
+type bulkQueuedRequest struct {
+	request BulkableRequest
+	future  *BulkFuture // nil if added via Add
+	size    int64       // estimated size in bytes if buffered, 0 otherwise
+}
+
+// bulkPendingRequest keeps track of a request in the bulk service
+// of a worker.
+type bulkPendingRequest struct {
+	futures []*BulkFuture     // futures of the requests added via AddWithResult (more than one if merged)
+	size    int64             // estimated size in bytes if buffered, 0 otherwise
+	retries int               // # of retries so far
+	item    *BulkResponseItem // response item of the last attempt
+}
+
 // bulkWorker encapsulates a single worker, running in a goroutine,
 // receiving bulk requests and eventually committing them to Elasticsearch.
 // It is strongly bound to a BulkProcessor.
 type bulkWorker struct {
 	p           *BulkProcessor
 	i           int
+	requestsC   chan bulkQueuedRequest // p.requestsC unless ordered
 	bulkActions int
 	bulkSize    int
 	service     *BulkService
 	flushC      chan struct{}
 	flushAckC   chan struct{}
+	closing     bool                 // true while committing the final requests on Close
+	pending     []bulkPendingRequest // state of the requests in service, by position
+
+	lastByDocument map[string]int // position of the last request per document in service, for merging updates
 }
 
 // newBulkWorker creates a new bulkWorker instance.
 func newBulkWorker(p *BulkProcessor, i int) *bulkWorker {
+	requestsC := p.requestsC
+	if p.orderingKey != nil {
+		requestsC = make(chan bulkQueuedRequest)
+	}
 	return &bulkWorker{
 		p:           p,
 		i:           i,
+		requestsC:   requestsC,
 		bulkActions: p.bulkActions,
 		bulkSize:    p.bulkSize,
 		service:     NewBulkService(p.c),
@@ -478,18 +758,31 @@ func (w *bulkWorker) work(ctx context.Context) {
 	for !stop {
 		var err error
 		select {
-		case req, open := <-w.p.requestsC:
+		case req, open := <-w.requestsC:
 			if open {
 				// Received a new request
-				if _, err = req.Source(); err == nil {
-					w.service.Add(req)
+				if _, err = encodeBulkableRequest(req.request, w.p.c.encoder); err == nil {
+					if !w.p.mergeUpdates || !w.merge(req) {
+						pending := bulkPendingRequest{size: req.size}
+						if req.future != nil {
+							pending.futures = append(pending.futures, req.future)
+						}
+						w.service.Add(req.request)
+						w.pending = append(w.pending, pending)
+					}
 					if w.commitRequired() {
 						err = w.commit(ctx)
 					}
+				} else {
+					if req.future != nil {
+						req.future.resolve(nil, err, 0)
+					}
+					w.release(req.size)
 				}
 			} else {
 				// Channel closed: Stop.
 				stop = true
+				w.closing = true
 				if w.service.NumberOfActions() > 0 {
 					err = w.commit(ctx)
 				}
@@ -532,31 +825,56 @@ func (w *bulkWorker) commit(ctx context.Context) error {
 		var err error
 		// Save requests because they will be reset in service.Do
 		reqs := w.service.requests
+		pending := w.pending
+		if w.p.adaptive != nil {
+			w.p.adaptive.acquire()
+		}
+		start := time.Now()
 		res, err = w.service.Do(ctx)
+		if w.p.adaptive != nil {
+			w.p.adaptive.observe(len(reqs), time.Since(start), res, err)
+			w.p.adaptive.release()
+		}
 		if err == nil {
 			// Overall bulk request was OK.  But each bulk response item also has a status
-			if w.p.retryItemStatusCodes != nil && len(w.p.retryItemStatusCodes) > 0 {
-				// Check res.Items since some might be soft failures
-				if res.Items != nil && res.Errors {
-					// res.Items will be 1 to 1 with reqs in same order
-					for i, item := range res.Items {
-						for _, result := range item {
-							if _, found := w.p.retryItemStatusCodes[result.Status]; found {
-								w.service.Add(reqs[i])
-								if err == nil {
-									err = ErrBulkItemRetry
-								}
-							}
+			w.pending = nil
+			// res.Items will be 1 to 1 with reqs in same order
+			for i, item := range res.Items {
+				if i >= len(reqs) {
+					break
+				}
+				for _, result := range item {
+					switch {
+					case result.Status >= 200 && result.Status <= 299:
+						for _, f := range pending[i].futures {
+							f.resolve(result, nil, pending[i].retries)
+						}
+						w.release(pending[i].size)
+					case w.isRetryItemStatusCode(result.Status):
+						// Soft failure: Retry
+						w.service.Add(reqs[i])
+						pending[i].item = result
+						w.pending = append(w.pending, pending[i])
+						if err == nil {
+							err = ErrBulkItemRetry
 						}
+					default:
+						w.giveUp(reqs[i], pending[i], result, nil)
 					}
 				}
 			}
+			for i := len(res.Items); i < len(reqs); i++ {
+				w.giveUp(reqs[i], pending[i], nil, ErrBulkMissingResponseItem)
+			}
 		}
 		return err
 	}
 	// notifyFunc will be called if retry fails
 	notifyFunc := func(err error) {
 		w.p.c.errorf("elastic: bulk processor %q failed but may retry: %v", w.p.name, err)
+		for i := range w.pending {
+			w.pending[i].retries++
+		}
 	}
 
 	id := atomic.AddInt64(&w.p.executionId, 1)
@@ -578,9 +896,24 @@ func (w *bulkWorker) commit(ctx context.Context) error {
 
 	// Commit bulk requests
 	err := RetryNotify(commitFunc, w.p.backoff, notifyFunc)
+	w.lastByDocument = nil // positions of requests left in service have changed
 	w.updateStats(res)
 	if err != nil {
 		w.p.c.errorf("elastic: bulk processor %q failed: %v", w.p.name, err)
+		// Give up on the requests left in the service: Either all of them
+		// if we cannot commit them before closing, or those still failing
+		// with a retryable status code if there is a dead letter sink.
+		if w.closing || (err == ErrBulkItemRetry && w.p.deadLetter != nil) {
+			for i, req := range w.service.requests {
+				if err == ErrBulkItemRetry {
+					w.giveUp(req, w.pending[i], w.pending[i].item, nil)
+				} else {
+					w.giveUp(req, w.pending[i], nil, err)
+				}
+			}
+			w.service.Reset()
+			w.pending = nil
+		}
 	}
 
 	// Invoke after callback
@@ -591,6 +924,37 @@ func (w *bulkWorker) commit(ctx context.Context) error {
 	return err
 }
 
+// isRetryItemStatusCode returns true if a bulk response item with the
+// given status code should be retried.
+func (w *bulkWorker) isRetryItemStatusCode(status int) bool {
+	_, found := w.p.retryItemStatusCodes[status]
+	return found
+}
+
+// giveUp resolves the future of a request that failed permanently and
+// sends it to the dead letter sink, if any.
+func (w *bulkWorker) giveUp(req BulkableRequest, pending bulkPendingRequest, item *BulkResponseItem, err error) {
+	for _, f := range pending.futures {
+		f.resolve(item, err, pending.retries)
+	}
+	w.deadLetter(req, item, err, pending.retries)
+	w.release(pending.size)
+}
+
+// release frees the bytes of a request the worker is done with in the
+// buffer, if enabled.
+func (w *bulkWorker) release(size int64) {
+	if w.p.buffer != nil {
+		w.p.buffer.release(size)
+	}
+}
+
+// deadLetter sends a request the worker gave up on to the dead letter
+// sink, if any.
+func (w *bulkWorker) deadLetter(req BulkableRequest, item *BulkResponseItem, err error, retries int) {
+	w.p.sendDeadLetter(req, item, err, retries)
+}
+
 func (w *bulkWorker) waitForActiveConnection(ready chan<- struct{}) {
 	defer close(ready)
 
@@ -646,7 +1010,11 @@ func (w *bulkWorker) updateStats(res *BulkResponse) {
 // or the estimated size in bytes is larger than specified in the
 // BulkProcessorService.
 func (w *bulkWorker) commitRequired() bool {
-	if w.bulkActions >= 0 && w.service.NumberOfActions() >= w.bulkActions {
+	bulkActions := w.bulkActions
+	if w.p.adaptive != nil {
+		bulkActions = w.p.adaptive.bulkActions()
+	}
+	if bulkActions >= 0 && w.service.NumberOfActions() >= bulkActions {
 		return true
 	}
 	if w.bulkSize >= 0 && w.service.EstimatedSizeInBytes() >= int64(w.bulkSize) {
@@ -0,0 +1,174 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BulkProcessorAdaptive configures the adaptive mode of BulkProcessor.
+// In adaptive mode, the processor adjusts the number of actions per bulk
+// request and the number of bulk requests committed concurrently from
+// the latency of its bulk requests and the rate of requests rejected by
+// Elasticsearch with 429 Too Many Requests.
+//
+// After each bulk request, the processor
+//
+//   * halves the number of actions and concurrent commits if any item
+//     (or the request as a whole) was rejected with 429,
+//   * reduces the number of actions by a quarter if the latency exceeded
+//     TargetLatency,
+//   * otherwise, if the batch was full, increases the number of actions by
+//     10%, or the number of concurrent commits by one if the number of
+//     actions is already at its maximum.
+//
+// BulkActions is the initial number of actions, and Workers is the
+// maximum number of concurrent commits. BulkSize, if enabled, still
+// bounds the size of each bulk request.
+type BulkProcessorAdaptive struct {
+	MinBulkActions int           // minimum # of actions per bulk request, defaults to 100
+	MaxBulkActions int           // maximum # of actions per bulk request, defaults to 10000
+	MinWorkers     int           // minimum # of concurrent commits, defaults to 1
+	TargetLatency  time.Duration // latency of a bulk request to aim for, defaults to 1s
+}
+
+// bulkAdaptiveController implements the adaptive mode of a bulk
+// processor. It is shared by all workers.
+type bulkAdaptiveController struct {
+	minActions    int
+	maxActions    int
+	minWorkers    int
+	maxWorkers    int
+	targetLatency time.Duration
+
+	mu          sync.Mutex
+	cond        *sync.Cond
+	actions     int // current # of actions per bulk request
+	concurrency int // current # of concurrent commits
+	running     int // # of commits in progress
+}
+
+// newBulkAdaptiveController creates a new bulkAdaptiveController.
+func newBulkAdaptiveController(opts BulkProcessorAdaptive, bulkActions, numWorkers int) *bulkAdaptiveController {
+	c := &bulkAdaptiveController{
+		minActions:    opts.MinBulkActions,
+		maxActions:    opts.MaxBulkActions,
+		minWorkers:    opts.MinWorkers,
+		maxWorkers:    numWorkers,
+		targetLatency: opts.TargetLatency,
+	}
+	if c.minActions <= 0 {
+		c.minActions = 100
+	}
+	if c.maxActions <= 0 {
+		c.maxActions = 10000
+	}
+	if c.maxActions < c.minActions {
+		c.maxActions = c.minActions
+	}
+	if c.maxWorkers < 1 {
+		c.maxWorkers = 1
+	}
+	if c.minWorkers <= 0 {
+		c.minWorkers = 1
+	}
+	if c.minWorkers > c.maxWorkers {
+		c.minWorkers = c.maxWorkers
+	}
+	if c.targetLatency <= 0 {
+		c.targetLatency = 1 * time.Second
+	}
+	c.actions = clampInt(bulkActions, c.minActions, c.maxActions)
+	c.concurrency = c.maxWorkers
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// bulkActions returns the current number of actions per bulk request.
+func (c *bulkAdaptiveController) bulkActions() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.actions
+}
+
+// workers returns the current number of concurrent commits.
+func (c *bulkAdaptiveController) workers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.concurrency
+}
+
+// acquire waits until the worker is allowed to commit.
+func (c *bulkAdaptiveController) acquire() {
+	c.mu.Lock()
+	for c.running >= c.concurrency {
+		c.cond.Wait()
+	}
+	c.running++
+	c.mu.Unlock()
+}
+
+// release signals that a commit has finished.
+func (c *bulkAdaptiveController) release() {
+	c.mu.Lock()
+	c.running--
+	c.cond.Signal()
+	c.mu.Unlock()
+}
+
+// observe adjusts the number of actions and concurrent commits from
+// the outcome of a bulk request with the given number of actions.
+func (c *bulkAdaptiveController) observe(actions int, latency time.Duration, res *BulkResponse, err error) {
+	rejected := IsStatusCode(err, http.StatusTooManyRequests)
+	if err == nil && res != nil && res.Errors {
+		for _, item := range res.Items {
+			for _, result := range item {
+				if result.Status == http.StatusTooManyRequests {
+					rejected = true
+				}
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case rejected:
+		c.actions = clampInt(c.actions/2, c.minActions, c.maxActions)
+		c.concurrency = clampInt(c.concurrency/2, c.minWorkers, c.maxWorkers)
+	case err != nil:
+		// Failed for some other reason: No evidence either way
+	case latency > c.targetLatency:
+		c.actions = clampInt(c.actions*3/4, c.minActions, c.maxActions)
+	case actions >= c.actions:
+		// Only grow if the batch was full, i.e. it was limited by
+		// the number of actions
+		if c.actions < c.maxActions {
+			c.actions = clampInt(c.actions+maxInt(1, c.actions/10), c.minActions, c.maxActions)
+		} else if c.concurrency < c.maxWorkers {
+			c.concurrency++
+			c.cond.Signal()
+		}
+	}
+}
+
+func clampInt(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,125 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBulkAdaptiveControllerDefaults(t *testing.T) {
+	c := newBulkAdaptiveController(BulkProcessorAdaptive{}, 1000, 4)
+	if want, have := 100, c.minActions; want != have {
+		t.Errorf("expected min actions %d; got: %d", want, have)
+	}
+	if want, have := 10000, c.maxActions; want != have {
+		t.Errorf("expected max actions %d; got: %d", want, have)
+	}
+	if want, have := 1000, c.bulkActions(); want != have {
+		t.Errorf("expected %d actions; got: %d", want, have)
+	}
+	if want, have := 4, c.workers(); want != have {
+		t.Errorf("expected %d workers; got: %d", want, have)
+	}
+	if want, have := 1*time.Second, c.targetLatency; want != have {
+		t.Errorf("expected target latency %v; got: %v", want, have)
+	}
+
+	// BulkActions is clamped
+	c = newBulkAdaptiveController(BulkProcessorAdaptive{MinBulkActions: 10, MaxBulkActions: 50}, -1, 1)
+	if want, have := 10, c.bulkActions(); want != have {
+		t.Errorf("expected %d actions; got: %d", want, have)
+	}
+}
+
+func TestBulkAdaptiveControllerObserve(t *testing.T) {
+	c := newBulkAdaptiveController(BulkProcessorAdaptive{
+		MinBulkActions: 100,
+		MaxBulkActions: 1000,
+		MinWorkers:     1,
+		TargetLatency:  100 * time.Millisecond,
+	}, 1000, 4)
+
+	ok := &BulkResponse{}
+	rejected := &BulkResponse{
+		Errors: true,
+		Items: []map[string]*BulkResponseItem{
+			{"index": {Status: http.StatusCreated}},
+			{"index": {Status: http.StatusTooManyRequests}},
+		},
+	}
+
+	// 429 halves actions and concurrency
+	c.observe(1000, 10*time.Millisecond, rejected, nil)
+	if want, have := 500, c.bulkActions(); want != have {
+		t.Errorf("expected %d actions; got: %d", want, have)
+	}
+	if want, have := 2, c.workers(); want != have {
+		t.Errorf("expected %d workers; got: %d", want, have)
+	}
+	c.observe(500, 10*time.Millisecond, nil, &Error{Status: http.StatusTooManyRequests})
+	if want, have := 250, c.bulkActions(); want != have {
+		t.Errorf("expected %d actions; got: %d", want, have)
+	}
+	if want, have := 1, c.workers(); want != have {
+		t.Errorf("expected %d workers; got: %d", want, have)
+	}
+
+	// High latency shrinks actions
+	c.observe(250, 200*time.Millisecond, ok, nil)
+	if want, have := 187, c.bulkActions(); want != have {
+		t.Errorf("expected %d actions; got: %d", want, have)
+	}
+
+	// Batches that were not full don't grow actions
+	c.observe(10, 10*time.Millisecond, ok, nil)
+	if want, have := 187, c.bulkActions(); want != have {
+		t.Errorf("expected %d actions; got: %d", want, have)
+	}
+
+	// Full batches with low latency grow actions up to the maximum,
+	// then concurrency
+	c.observe(187, 10*time.Millisecond, ok, nil)
+	if want, have := 205, c.bulkActions(); want != have {
+		t.Errorf("expected %d actions; got: %d", want, have)
+	}
+	for i := 0; i < 100; i++ {
+		c.observe(c.bulkActions(), 10*time.Millisecond, ok, nil)
+	}
+	if want, have := 1000, c.bulkActions(); want != have {
+		t.Errorf("expected %d actions; got: %d", want, have)
+	}
+	if want, have := 4, c.workers(); want != have {
+		t.Errorf("expected %d workers; got: %d", want, have)
+	}
+}
+
+func TestBulkAdaptiveControllerAcquire(t *testing.T) {
+	c := newBulkAdaptiveController(BulkProcessorAdaptive{}, 1000, 2)
+	c.observe(1000, 0, nil, &Error{Status: http.StatusTooManyRequests})
+	if want, have := 1, c.workers(); want != have {
+		t.Fatalf("expected %d workers; got: %d", want, have)
+	}
+
+	c.acquire()
+	acquiredC := make(chan struct{})
+	go func() {
+		c.acquire()
+		close(acquiredC)
+	}()
+	select {
+	case <-acquiredC:
+		t.Fatal("expected acquire to block")
+	case <-time.After(50 * time.Millisecond):
+	}
+	c.release()
+	select {
+	case <-acquiredC:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected acquire to return after release")
+	}
+	c.release()
+}
@@ -0,0 +1,148 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrBulkProcessorBufferFull is returned by BulkProcessor.TryAdd if
+	// the request cannot be added without blocking.
+	ErrBulkProcessorBufferFull = errors.New("elastic: bulk processor buffer is full")
+
+	// ErrBulkProcessorClosed is returned if a request is added to a
+	// bulk processor that is closed.
+	ErrBulkProcessorClosed = errors.New("elastic: bulk processor is closed")
+)
+
+// bulkBuffer is a byte-bounded queue of requests in front of the workers
+// of a bulk processor. The bytes of a request are reserved when it is
+// added and released when a worker is done with it, i.e. the buffer
+// bounds all requests that are queued or waiting in a worker to be
+// committed.
+//
+// A dispatcher goroutine hands queued requests to the workers. If
+// producers are waiting for room, it asks the workers to commit what
+// they have, so that the buffer is drained even if neither BulkActions
+// nor BulkSize trigger a commit.
+type bulkBuffer struct {
+	p        *BulkProcessor
+	capacity int64
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	used     int64               // # of bytes reserved
+	queue    []bulkQueuedRequest // requests not handed to a worker yet
+	inflight bool                // true while the dispatcher hands a request to a worker
+	waiting  int                 // # of producers waiting for room
+	flushed  bool                // true if workers have been flushed since the last release
+	closed   bool
+}
+
+// newBulkBuffer creates a new bulkBuffer for the given processor.
+func newBulkBuffer(p *BulkProcessor, capacity int64) *bulkBuffer {
+	b := &bulkBuffer{p: p, capacity: capacity}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// put reserves room for the request and enqueues it. If block is true,
+// it waits until there is enough room, otherwise it returns
+// ErrBulkProcessorBufferFull. A request that is larger than the
+// capacity is accepted when the buffer is empty.
+func (b *bulkBuffer) put(req bulkQueuedRequest, block bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for !b.closed && b.used > 0 && b.used+req.size > b.capacity {
+		if !block {
+			return ErrBulkProcessorBufferFull
+		}
+		b.waiting++
+		b.cond.Broadcast() // wake up dispatcher
+		b.cond.Wait()
+		b.waiting--
+	}
+	if b.closed {
+		return ErrBulkProcessorClosed
+	}
+	b.used += req.size
+	b.queue = append(b.queue, req)
+	b.cond.Broadcast()
+	return nil
+}
+
+// release frees the given number of bytes.
+func (b *bulkBuffer) release(size int64) {
+	if size == 0 {
+		return
+	}
+	b.mu.Lock()
+	b.used -= size
+	b.flushed = false
+	b.cond.Broadcast()
+	b.mu.Unlock()
+}
+
+// bytes returns the number of bytes reserved.
+func (b *bulkBuffer) bytes() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used
+}
+
+// drain waits until all queued requests have been handed to a worker.
+func (b *bulkBuffer) drain() {
+	b.mu.Lock()
+	for len(b.queue) > 0 || b.inflight {
+		b.cond.Wait()
+	}
+	b.mu.Unlock()
+}
+
+// close stops accepting requests. The dispatcher hands the remaining
+// requests to the workers and then closes the requests channel.
+func (b *bulkBuffer) close() {
+	b.mu.Lock()
+	b.closed = true
+	b.cond.Broadcast()
+	b.mu.Unlock()
+}
+
+// dispatch hands queued requests to the workers. It runs as a goroutine
+// while the bulk processor is started, and closes the requests channels
+// when the buffer has been closed and all queued requests are handed over.
+func (b *bulkBuffer) dispatch() {
+	b.mu.Lock()
+	for {
+		for len(b.queue) == 0 && !b.closed && (b.waiting == 0 || b.flushed) {
+			b.cond.Wait()
+		}
+		switch {
+		case len(b.queue) > 0:
+			req := b.queue[0]
+			b.queue[0] = bulkQueuedRequest{}
+			b.queue = b.queue[1:]
+			b.inflight = true
+			b.mu.Unlock()
+			b.p.send(req, true)
+			b.mu.Lock()
+			b.inflight = false
+			b.cond.Broadcast()
+		case b.closed:
+			b.mu.Unlock()
+			b.p.closeRequests()
+			return
+		default:
+			// Producers are waiting for room that is held by the workers
+			b.flushed = true
+			b.mu.Unlock()
+			b.p.flushWorkers()
+			b.mu.Lock()
+		}
+	}
+}
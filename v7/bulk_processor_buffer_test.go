@@ -0,0 +1,128 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBulkProcessorBufferSize(t *testing.T) {
+	var committed int64
+	ts := newBulkTestServer(t, func(id string) int {
+		atomic.AddInt64(&committed, 1)
+		return http.StatusCreated
+	})
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRequest := func(id string) BulkableRequest {
+		return NewBulkIndexRequest().Index(testIndexName).Id(id).Doc(tweet{User: "olivere"})
+	}
+	size := estimateBulkableRequestSize(newRequest("1"), client.encoder)
+
+	// Never commit unless asked to, and buffer two requests
+	p, err := client.BulkProcessor().
+		BulkActions(-1).
+		BulkSize(-1).
+		BufferSize(int(2 * size)).
+		Stats(true).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.Add(newRequest("1"))
+	if err := p.TryAdd(newRequest("2")); err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	if err := p.TryAdd(newRequest("3")); err != ErrBulkProcessorBufferFull {
+		t.Fatalf("expected %v; got: %v", ErrBulkProcessorBufferFull, err)
+	}
+	if want, have := 2*size, p.Stats().BufferedBytes; want != have {
+		t.Errorf("expected %d buffered bytes; got: %d", want, have)
+	}
+	if want, have := int64(0), atomic.LoadInt64(&committed); want != have {
+		t.Errorf("expected %d committed requests; got: %d", want, have)
+	}
+
+	// Add blocks until the buffered requests have been committed
+	doneC := make(chan struct{})
+	go func() {
+		p.Add(newRequest("3"))
+		close(doneC)
+	}()
+	select {
+	case <-doneC:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Add to return after buffered requests have been committed")
+	}
+	if want, have := int64(2), atomic.LoadInt64(&committed); want != have {
+		t.Errorf("expected %d committed requests; got: %d", want, have)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(3), atomic.LoadInt64(&committed); want != have {
+		t.Errorf("expected %d committed requests; got: %d", want, have)
+	}
+	if want, have := int64(0), p.Stats().BufferedBytes; want != have {
+		t.Errorf("expected %d buffered bytes; got: %d", want, have)
+	}
+	if err := p.TryAdd(newRequest("4")); err != ErrBulkProcessorClosed {
+		t.Fatalf("expected %v; got: %v", ErrBulkProcessorClosed, err)
+	}
+}
+
+func TestBulkProcessorBufferAddAfterClose(t *testing.T) {
+	ts := newBulkTestServer(t, func(id string) int {
+		return http.StatusCreated
+	})
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadLetters := make(BulkDeadLetterChan, 1)
+	p, err := client.BulkProcessor().
+		BufferSize(1 << 20).
+		DeadLetter(deadLetters).
+		Stats(true).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Add must not drop the request silently
+	req := NewBulkIndexRequest().Index(testIndexName).Id("1").Doc(tweet{User: "olivere"})
+	p.Add(req)
+	select {
+	case letter := <-deadLetters:
+		if letter.Request != req {
+			t.Errorf("expected dead letter for request %v; got: %v", req, letter.Request)
+		}
+		if want, have := ErrBulkProcessorClosed, letter.Err; want != have {
+			t.Errorf("expected error %v; got: %v", want, have)
+		}
+	default:
+		t.Fatal("expected request added after Close to be dead lettered")
+	}
+	if want, have := int64(1), p.Stats().DeadLettered; want != have {
+		t.Errorf("expected %d dead lettered requests; got: %d", want, have)
+	}
+}
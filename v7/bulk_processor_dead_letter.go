@@ -0,0 +1,207 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// BulkDeadLetter is a bulk request that BulkProcessor gave up on.
+type BulkDeadLetter struct {
+	// Request is the bulk request that failed.
+	Request BulkableRequest
+	// Item is the response item of the last attempt. It is nil if the
+	// request failed as a whole, e.g. because Elasticsearch wasn't
+	// reachable. Use Err in that case.
+	Item *BulkResponseItem
+	// Err is the error of the last attempt if the request failed as a
+	// whole, or nil otherwise.
+	Err error
+	// Retries is the number of times the request has been retried.
+	Retries int
+}
+
+// BulkDeadLetterSink receives the bulk requests that BulkProcessor gave
+// up on. These are requests that failed with a status code that is not
+// retried (see BulkProcessorService.RetryItemStatusCodes), requests
+// still failing after the backoff is exhausted, and requests that cannot
+// be committed when the processor is closed.
+//
+// Send is called concurrently from all workers of the bulk processor.
+type BulkDeadLetterSink interface {
+	Send(letter *BulkDeadLetter) error
+}
+
+// -- Channel --
+
+// BulkDeadLetterChan is a BulkDeadLetterSink that sends dead letters
+// on a channel. Notice that Send blocks the bulk processor worker if
+// the channel is unbuffered or full, so make sure to consume it.
+type BulkDeadLetterChan chan *BulkDeadLetter
+
+// Send sends the dead letter on the channel.
+func (c BulkDeadLetterChan) Send(letter *BulkDeadLetter) error {
+	c <- letter
+	return nil
+}
+
+// -- File --
+
+// bulkDeadLetterLine is the on-disk representation of a dead letter.
+type bulkDeadLetterLine struct {
+	Retries int               `json:"retries"`
+	Item    *BulkResponseItem `json:"item,omitempty"`
+	Error   string            `json:"error,omitempty"`
+	Request []json.RawMessage `json:"request"`
+}
+
+// BulkDeadLetterWriter is a BulkDeadLetterSink that writes dead letters
+// as newline-delimited JSON, one dead letter per line. Use
+// BulkDeadLetterReader to read them back, e.g. to replay them.
+// It is safe for concurrent use.
+type BulkDeadLetterWriter struct {
+	mu  sync.Mutex
+	w   *bufio.Writer
+	c   io.Closer
+	enc *json.Encoder
+}
+
+// NewBulkDeadLetterWriter returns a BulkDeadLetterWriter that writes to w.
+func NewBulkDeadLetterWriter(w io.Writer) *BulkDeadLetterWriter {
+	bw := bufio.NewWriter(w)
+	dlw := &BulkDeadLetterWriter{w: bw, enc: json.NewEncoder(bw)}
+	if c, ok := w.(io.Closer); ok {
+		dlw.c = c
+	}
+	return dlw
+}
+
+// NewBulkDeadLetterFile creates (or truncates) the file with the given
+// name and returns a BulkDeadLetterWriter for it. The caller must call
+// Close after the bulk processor has been closed.
+func NewBulkDeadLetterFile(filename string) (*BulkDeadLetterWriter, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewBulkDeadLetterWriter(f), nil
+}
+
+// Send writes the dead letter. The line is buffered; use Flush or
+// Close to write it to the underlying writer.
+func (w *BulkDeadLetterWriter) Send(letter *BulkDeadLetter) error {
+	lines, err := letter.Request.Source()
+	if err != nil {
+		return err
+	}
+	line := bulkDeadLetterLine{
+		Retries: letter.Retries,
+		Item:    letter.Item,
+		Request: make([]json.RawMessage, len(lines)),
+	}
+	if letter.Err != nil {
+		line.Error = letter.Err.Error()
+	}
+	for i, l := range lines {
+		line.Request[i] = json.RawMessage(l)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enc.Encode(line)
+}
+
+// Flush writes buffered dead letters to the underlying writer.
+func (w *BulkDeadLetterWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Flush()
+}
+
+// Close flushes buffered dead letters and closes the underlying
+// writer if it implements io.Closer.
+func (w *BulkDeadLetterWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.w.Flush()
+	if w.c != nil {
+		if cerr := w.c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// BulkDeadLetterReader reads dead letters written by BulkDeadLetterWriter.
+type BulkDeadLetterReader struct {
+	dec *json.Decoder
+}
+
+// NewBulkDeadLetterReader returns a BulkDeadLetterReader that reads from r.
+func NewBulkDeadLetterReader(r io.Reader) *BulkDeadLetterReader {
+	return &BulkDeadLetterReader{dec: json.NewDecoder(r)}
+}
+
+// Next returns the next dead letter. It returns io.EOF if there are
+// no more dead letters. The Request of the dead letter returns the
+// bulk lines as written, and Err only retains the error message.
+func (r *BulkDeadLetterReader) Next() (*BulkDeadLetter, error) {
+	var line bulkDeadLetterLine
+	if err := r.dec.Decode(&line); err != nil {
+		return nil, err
+	}
+	letter := &BulkDeadLetter{
+		Retries: line.Retries,
+		Item:    line.Item,
+	}
+	if line.Error != "" {
+		letter.Err = bulkDeadLetterError(line.Error)
+	}
+	req := make(bulkRawRequest, len(line.Request))
+	for i, l := range line.Request {
+		req[i] = string(l)
+	}
+	letter.Request = req
+	return letter, nil
+}
+
+// Replay adds all remaining dead letters to the bulk processor and
+// returns the number of requests added.
+func (r *BulkDeadLetterReader) Replay(p *BulkProcessor) (int, error) {
+	var n int
+	for {
+		letter, err := r.Next()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		p.Add(letter.Request)
+		n++
+	}
+}
+
+// bulkDeadLetterError is the error of a dead letter read from a file.
+type bulkDeadLetterError string
+
+func (e bulkDeadLetterError) Error() string {
+	return string(e)
+}
+
+// bulkRawRequest is a bulkable request made of pre-serialized lines.
+type bulkRawRequest []string
+
+func (r bulkRawRequest) String() string {
+	return strings.Join(r, "\n")
+}
+
+func (r bulkRawRequest) Source() ([]string, error) {
+	return r, nil
+}
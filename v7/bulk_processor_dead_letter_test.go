@@ -0,0 +1,265 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// newBulkTestServer returns a server that responds to bulk requests
+// with the status returned by statusFn for each document id.
+func newBulkTestServer(t *testing.T, statusFn func(id string) int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		type item map[string]*BulkResponseItem
+		res := struct {
+			Errors bool   `json:"errors"`
+			Items  []item `json:"items"`
+		}{}
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var action map[string]*BulkResponseItem
+			if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+				t.Errorf("invalid action line %q: %v", scanner.Text(), err)
+				return
+			}
+			for op, meta := range action {
+				meta.Status = statusFn(meta.Id)
+				if meta.Status >= 300 {
+					res.Errors = true
+					meta.Error = &ErrorDetails{Type: "test_exception", Reason: http.StatusText(meta.Status)}
+				}
+				res.Items = append(res.Items, item{op: meta})
+				if op != "delete" {
+					scanner.Scan() // skip source
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(res)
+	}))
+}
+
+func TestBulkProcessorDeadLetter(t *testing.T) {
+	ts := newBulkTestServer(t, func(id string) int {
+		switch id {
+		case "bad":
+			return http.StatusBadRequest
+		case "busy":
+			return http.StatusTooManyRequests
+		default:
+			return http.StatusCreated
+		}
+	})
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	letters := make(BulkDeadLetterChan, 10)
+	p, err := client.BulkProcessor().
+		Backoff(NewSimpleBackoff(1, 1, 1)). // retry twice
+		DeadLetter(letters).
+		Stats(true).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1", "bad", "busy", "2"} {
+		p.Add(NewBulkIndexRequest().Index(testIndexName).Id(id).Doc(tweet{User: "olivere"}))
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	close(letters)
+
+	byId := make(map[string]*BulkDeadLetter)
+	for letter := range letters {
+		if letter.Item == nil {
+			t.Fatalf("expected dead letter with response item; got: %+v", letter)
+		}
+		byId[letter.Item.Id] = letter
+	}
+	if want, have := 2, len(byId); want != have {
+		t.Fatalf("expected %d dead letters; got: %d", want, have)
+	}
+	if letter := byId["bad"]; letter == nil {
+		t.Error("expected dead letter for id bad")
+	} else {
+		if want, have := http.StatusBadRequest, letter.Item.Status; want != have {
+			t.Errorf("expected status %d; got: %d", want, have)
+		}
+		if want, have := 0, letter.Retries; want != have {
+			t.Errorf("expected %d retries; got: %d", want, have)
+		}
+		lines, err := letter.Request.Source()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, have := `{"index":{"_index":"elastic-test","_id":"bad"}}`, lines[0]; want != have {
+			t.Errorf("expected request %s; got: %s", want, have)
+		}
+	}
+	if letter := byId["busy"]; letter == nil {
+		t.Error("expected dead letter for id busy")
+	} else {
+		if want, have := http.StatusTooManyRequests, letter.Item.Status; want != have {
+			t.Errorf("expected status %d; got: %d", want, have)
+		}
+		if want, have := 2, letter.Retries; want != have {
+			t.Errorf("expected %d retries; got: %d", want, have)
+		}
+	}
+	if want, have := int64(2), p.Stats().DeadLettered; want != have {
+		t.Errorf("expected %d dead lettered requests; got: %d", want, have)
+	}
+}
+
+func TestBulkProcessorDeadLetterOnClose(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"type":"test_exception","reason":"unavailable"},"status":503}`, http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	letters := make(BulkDeadLetterChan, 10)
+	p, err := client.BulkProcessor().
+		Backoff(NewSimpleBackoff(1, 1)). // retry once
+		DeadLetter(letters).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Add(NewBulkIndexRequest().Index(testIndexName).Id("1").Doc(tweet{User: "olivere"}))
+	p.Add(NewBulkDeleteRequest().Index(testIndexName).Id("2"))
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	close(letters)
+
+	var n int
+	for letter := range letters {
+		n++
+		if letter.Item != nil {
+			t.Errorf("expected no response item; got: %+v", letter.Item)
+		}
+		if letter.Err == nil {
+			t.Error("expected error")
+		}
+		if want, have := 1, letter.Retries; want != have {
+			t.Errorf("expected %d retries; got: %d", want, have)
+		}
+	}
+	if want, have := 2, n; want != have {
+		t.Fatalf("expected %d dead letters; got: %d", want, have)
+	}
+}
+
+func TestBulkDeadLetterWriterAndReader(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewBulkDeadLetterWriter(&buf)
+	letters := []*BulkDeadLetter{
+		{
+			Request: NewBulkIndexRequest().Index(testIndexName).Id("1").Doc(tweet{User: "olivere"}),
+			Item:    &BulkResponseItem{Index: testIndexName, Id: "1", Status: 400, Error: &ErrorDetails{Type: "mapper_parsing_exception"}},
+		},
+		{
+			Request: NewBulkDeleteRequest().Index(testIndexName).Id("2"),
+			Err:     io.ErrUnexpectedEOF,
+			Retries: 3,
+		},
+	}
+	for _, letter := range letters {
+		if err := w.Send(letter); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewBulkDeadLetterReader(bytes.NewReader(buf.Bytes()))
+	for i, want := range letters {
+		got, err := r.Next()
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		if want, have := want.Request.String(), got.Request.String(); want != have {
+			t.Errorf("case #%d: expected request\n%s\ngot:\n%s", i+1, want, have)
+		}
+		if want, have := want.Retries, got.Retries; want != have {
+			t.Errorf("case #%d: expected %d retries; got: %d", i+1, want, have)
+		}
+		if want.Item != nil {
+			if got.Item == nil || got.Item.Status != want.Item.Status || got.Item.Error.Type != want.Item.Error.Type {
+				t.Errorf("case #%d: expected item %+v; got: %+v", i+1, want.Item, got.Item)
+			}
+		}
+		if want.Err != nil {
+			if got.Err == nil || got.Err.Error() != want.Err.Error() {
+				t.Errorf("case #%d: expected error %v; got: %v", i+1, want.Err, got.Err)
+			}
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Fatalf("expected io.EOF; got: %v", err)
+	}
+
+	// Replay into a bulk processor
+	var mu sync.Mutex
+	var ids []string
+	ts := newBulkTestServer(t, func(id string) int {
+		mu.Lock()
+		ids = append(ids, id)
+		mu.Unlock()
+		return http.StatusOK
+	})
+	defer ts.Close()
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var committed int64
+	p, err := client.BulkProcessor().
+		After(func(executionId int64, requests []BulkableRequest, response *BulkResponse, err error) {
+			if err == nil {
+				atomic.AddInt64(&committed, int64(len(requests)))
+			}
+		}).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := NewBulkDeadLetterReader(bytes.NewReader(buf.Bytes())).Replay(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, n; want != have {
+		t.Errorf("expected %d replayed requests; got: %d", want, have)
+	}
+	if want, have := int64(2), committed; want != have {
+		t.Errorf("expected %d committed requests; got: %d", want, have)
+	}
+	if want, have := 2, len(ids); want != have {
+		t.Errorf("expected %d requests on the server; got: %d", want, have)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrBulkMissingResponseItem is the error of a BulkResult if
+	// Elasticsearch returned no response item for the request.
+	ErrBulkMissingResponseItem = errors.New("elastic: missing bulk response item")
+)
+
+// BulkResult is the outcome of a single request added to BulkProcessor
+// via AddWithResult.
+type BulkResult struct {
+	// Request is the request added via AddWithResult.
+	Request BulkableRequest
+	// Item is the response item of the last attempt. It is nil if the
+	// request failed as a whole or could not be serialized.
+	Item *BulkResponseItem
+	// Err is nil if the request has been committed successfully. If the
+	// request failed permanently, Err is an *Error with the status and
+	// error details of the response item. If the request failed as a
+	// whole and was dropped when closing the processor, Err is the
+	// error of the last attempt.
+	Err error
+	// Retries is the number of times the request has been retried.
+	Retries int
+}
+
+// BulkFuture is returned by BulkProcessor.AddWithResult. It resolves
+// when the request has been committed successfully, failed permanently,
+// or was dropped when closing the processor.
+type BulkFuture struct {
+	done   chan struct{}
+	result BulkResult
+}
+
+func newBulkFuture(request BulkableRequest) *BulkFuture {
+	return &BulkFuture{
+		done:   make(chan struct{}),
+		result: BulkResult{Request: request},
+	}
+}
+
+// Done returns a channel that is closed when the result is available.
+func (f *BulkFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Result returns the result of the request. It blocks until the result
+// is available.
+func (f *BulkFuture) Result() *BulkResult {
+	<-f.done
+	return &f.result
+}
+
+// Wait waits until the result is available or the context is done.
+// It returns the result along with its error, or the context error.
+func (f *BulkFuture) Wait(ctx context.Context) (*BulkResult, error) {
+	select {
+	case <-f.done:
+		return &f.result, f.result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// resolve sets the result and wakes up all waiters. It must only be
+// called once.
+func (f *BulkFuture) resolve(item *BulkResponseItem, err error, retries int) {
+	if err == nil && item != nil && !(item.Status >= 200 && item.Status <= 299) {
+		err = &Error{Status: item.Status, Details: item.Error}
+	}
+	f.result.Item = item
+	f.result.Err = err
+	f.result.Retries = retries
+	close(f.done)
+}
@@ -0,0 +1,130 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBulkProcessorAddWithResult(t *testing.T) {
+	var busy int64
+	ts := newBulkTestServer(t, func(id string) int {
+		switch id {
+		case "bad":
+			return http.StatusBadRequest
+		case "busy":
+			// Reject the first attempt only
+			if atomic.AddInt64(&busy, 1) == 1 {
+				return http.StatusTooManyRequests
+			}
+			return http.StatusCreated
+		default:
+			return http.StatusCreated
+		}
+	})
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := client.BulkProcessor().
+		Backoff(NewSimpleBackoff(1, 1)).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	futures := make(map[string]*BulkFuture)
+	for _, id := range []string{"1", "bad", "busy"} {
+		futures[id] = p.AddWithResult(NewBulkIndexRequest().Index(testIndexName).Id(id).Doc(tweet{User: "olivere"}))
+	}
+	p.Add(NewBulkIndexRequest().Index(testIndexName).Id("2").Doc(tweet{User: "olivere"}))
+
+	// Not committed yet
+	select {
+	case <-futures["1"].Done():
+		t.Fatal("expected future to be unresolved before commit")
+	default:
+	}
+	if err := p.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := futures["1"].Wait(ctx)
+	if err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	if res.Item == nil || res.Item.Id != "1" || res.Item.Status != http.StatusCreated {
+		t.Errorf("expected response item for id 1 with status 201; got: %+v", res.Item)
+	}
+
+	res, err = futures["bad"].Wait(ctx)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if e, ok := err.(*Error); !ok || e.Status != http.StatusBadRequest {
+		t.Errorf("expected *Error with status 400; got: %#v", err)
+	}
+	if res.Item == nil || res.Item.Status != http.StatusBadRequest {
+		t.Errorf("expected response item with status 400; got: %+v", res.Item)
+	}
+
+	res, err = futures["busy"].Wait(ctx)
+	if err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	if want, have := 1, res.Retries; want != have {
+		t.Errorf("expected %d retries; got: %d", want, have)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBulkProcessorAddWithResultDroppedOnClose(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"type":"test_exception","reason":"unavailable"},"status":503}`, http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := client.BulkProcessor().
+		Backoff(StopBackoff{}).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	future := p.AddWithResult(NewBulkIndexRequest().Index(testIndexName).Id("1").Doc(tweet{User: "olivere"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := future.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected %v; got: %v", context.DeadlineExceeded, err)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	res := future.Result()
+	if res.Err == nil {
+		t.Fatal("expected error")
+	}
+	if res.Item != nil {
+		t.Errorf("expected no response item; got: %+v", res.Item)
+	}
+}
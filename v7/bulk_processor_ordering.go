@@ -0,0 +1,204 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"hash/fnv"
+	"sync/atomic"
+)
+
+// BulkOrderingKeyFunc returns the key of a bulk request that determines
+// the worker of an ordered BulkProcessor. Requests with the same key are
+// committed in the order they have been added. An empty key means that
+// the request can be committed by any worker.
+type BulkOrderingKeyFunc func(request BulkableRequest) string
+
+// BulkOrderingKeyByDocument is the default BulkOrderingKeyFunc. It returns
+// the index and id of the document, i.e. it preserves the order of the
+// operations per document. Requests without an id have no ordering key.
+func BulkOrderingKeyByDocument(request BulkableRequest) string {
+	index, id := bulkableRequestIndexAndId(request)
+	if id == "" {
+		return ""
+	}
+	return index + "/" + id
+}
+
+// bulkableRequestIndexAndId returns the index and id of the document
+// a bulkable request refers to. It falls back to parsing the action line
+// for requests other than BulkIndexRequest, BulkUpdateRequest and
+// BulkDeleteRequest.
+func bulkableRequestIndexAndId(request BulkableRequest) (index, id string) {
+	switch r := request.(type) {
+	case *BulkIndexRequest:
+		return r.index, r.id
+	case *BulkUpdateRequest:
+		return r.index, r.id
+	case *BulkDeleteRequest:
+		return r.index, r.id
+	}
+	lines, err := request.Source()
+	if err != nil || len(lines) == 0 {
+		return "", ""
+	}
+	var action map[string]struct {
+		Index string `json:"_index"`
+		Id    string `json:"_id"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &action); err != nil {
+		return "", ""
+	}
+	for _, meta := range action {
+		return meta.Index, meta.Id
+	}
+	return "", ""
+}
+
+// worker returns the worker that the request must be committed by
+// in an ordered bulk processor.
+func (p *BulkProcessor) worker(request BulkableRequest) *bulkWorker {
+	key := p.orderingKey(request)
+	if key == "" {
+		n := atomic.AddUint64(&p.roundRobin, 1)
+		return p.workers[n%uint64(len(p.workers))]
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return p.workers[h.Sum32()%uint32(len(p.workers))]
+}
+
+// merge merges the request into the previous request of the worker for
+// the same document if both are partial document updates that can be
+// combined. It returns false if the request has not been merged, in
+// which case the caller must add it.
+func (w *bulkWorker) merge(req bulkQueuedRequest) bool {
+	index, id := bulkableRequestIndexAndId(req.request)
+	if id == "" {
+		return false
+	}
+	key := index + "/" + id
+	if w.lastByDocument == nil {
+		w.lastByDocument = make(map[string]int)
+	}
+	if pos, found := w.lastByDocument[key]; found {
+		prev, ok1 := w.service.requests[pos].(*BulkUpdateRequest)
+		next, ok2 := req.request.(*BulkUpdateRequest)
+		if ok1 && ok2 {
+			if merged, ok := mergeBulkUpdateRequests(prev, next); ok {
+				w.service.replace(pos, merged)
+				if req.future != nil {
+					w.pending[pos].futures = append(w.pending[pos].futures, req.future)
+				}
+				w.pending[pos].size += req.size
+				return true
+			}
+		}
+	}
+	w.lastByDocument[key] = w.service.NumberOfActions()
+	return false
+}
+
+// mergeBulkUpdateRequests combines two partial document updates of the
+// same document into one. It returns false if they cannot be combined,
+// e.g. because one of them uses a script, an upsert document, or
+// optimistic concurrency control.
+func mergeBulkUpdateRequests(a, b *BulkUpdateRequest) (*BulkUpdateRequest, bool) {
+	if !mergeableBulkUpdateRequest(a) || !mergeableBulkUpdateRequest(b) {
+		return nil, false
+	}
+	if a.index != b.index || a.typ != b.typ || a.id != b.id ||
+		a.routing != b.routing || a.parent != b.parent ||
+		!equalBoolPtr(a.docAsUpsert, b.docAsUpsert) ||
+		!equalBoolPtr(a.detectNoop, b.detectNoop) ||
+		!equalBoolPtr(a.returnSource, b.returnSource) {
+		return nil, false
+	}
+	docA, ok := bulkUpdateDocAsMap(a.doc)
+	if !ok {
+		return nil, false
+	}
+	docB, ok := bulkUpdateDocAsMap(b.doc)
+	if !ok {
+		return nil, false
+	}
+	merged := *a
+	merged.doc = mergeDocs(docA, docB)
+	merged.source = nil
+	if b.retryOnConflict != nil && (a.retryOnConflict == nil || *b.retryOnConflict > *a.retryOnConflict) {
+		merged.retryOnConflict = b.retryOnConflict
+	}
+	return &merged, true
+}
+
+// mergeableBulkUpdateRequest returns true if the request is a plain
+// partial document update.
+func mergeableBulkUpdateRequest(r *BulkUpdateRequest) bool {
+	return r.doc != nil &&
+		r.script == nil &&
+		r.scriptedUpsert == nil &&
+		r.upsert == nil &&
+		r.version == 0 &&
+		r.versionType == "" &&
+		r.ifSeqNo == nil &&
+		r.ifPrimaryTerm == nil
+}
+
+// bulkUpdateDocAsMap returns the partial document of an update request
+// as a map. It returns false if the document is not a JSON object.
+func bulkUpdateDocAsMap(doc interface{}) (map[string]interface{}, bool) {
+	var data []byte
+	switch t := doc.(type) {
+	case map[string]interface{}:
+		return t, true
+	case string:
+		data = []byte(t)
+	case *string:
+		if t == nil {
+			return nil, false
+		}
+		data = []byte(*t)
+	default:
+		var err error
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, false
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil || m == nil {
+		return nil, false
+	}
+	return m, true
+}
+
+// mergeDocs merges src into a copy of dst the way Elasticsearch applies
+// a partial document: objects are merged recursively, and all other
+// values are replaced.
+func mergeDocs(dst, src map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range src {
+		if srcObj, ok := v.(map[string]interface{}); ok {
+			if dstObj, ok := out[k].(map[string]interface{}); ok {
+				out[k] = mergeDocs(dstObj, srcObj)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func equalBoolPtr(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,233 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// bulkRecorder is a test server that records the lines of all bulk requests.
+type bulkRecorder struct {
+	mu       sync.Mutex
+	requests [][]string
+}
+
+func (rec *bulkRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var lines []string
+	var items []map[string]*BulkResponseItem
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		var action map[string]*BulkResponseItem
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for op, meta := range action {
+			meta.Status = http.StatusOK
+			items = append(items, map[string]*BulkResponseItem{op: meta})
+			if op != "delete" && scanner.Scan() {
+				lines = append(lines, scanner.Text())
+			}
+		}
+	}
+	// Commit in random order across workers
+	time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+	rec.mu.Lock()
+	rec.requests = append(rec.requests, lines)
+	rec.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&BulkResponse{Items: items})
+}
+
+func TestBulkProcessorOrdered(t *testing.T) {
+	rec := &bulkRecorder{}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := client.BulkProcessor().
+		Workers(4).
+		BulkActions(3).
+		Ordered(true).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const numDocs, numVersions = 10, 20
+	for version := 0; version < numVersions; version++ {
+		for i := 0; i < numDocs; i++ {
+			p.Add(NewBulkIndexRequest().Index(testIndexName).Id(fmt.Sprint(i)).Doc(map[string]interface{}{"version": version}))
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Per document, versions must arrive in order
+	last := make(map[string]int)
+	var n int
+	for _, lines := range rec.requests {
+		for i := 0; i < len(lines); i += 2 {
+			var action map[string]*BulkResponseItem
+			if err := json.Unmarshal([]byte(lines[i]), &action); err != nil {
+				t.Fatal(err)
+			}
+			var doc struct {
+				Version int `json:"version"`
+			}
+			if err := json.Unmarshal([]byte(lines[i+1]), &doc); err != nil {
+				t.Fatal(err)
+			}
+			id := action["index"].Id
+			if prev, found := last[id]; found && doc.Version != prev+1 {
+				t.Fatalf("expected version %d of document %s; got: %d", prev+1, id, doc.Version)
+			}
+			last[id] = doc.Version
+			n++
+		}
+	}
+	if want, have := numDocs*numVersions, n; want != have {
+		t.Fatalf("expected %d requests; got: %d", want, have)
+	}
+}
+
+func TestBulkProcessorMergeUpdates(t *testing.T) {
+	rec := &bulkRecorder{}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := client.BulkProcessor().
+		BulkActions(-1).
+		BulkSize(-1).
+		MergeUpdates(true).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var futures []*BulkFuture
+	futures = append(futures, p.AddWithResult(NewBulkUpdateRequest().Index(testIndexName).Id("1").Doc(map[string]interface{}{"a": 1})))
+	futures = append(futures, p.AddWithResult(NewBulkUpdateRequest().Index(testIndexName).Id("2").Doc(map[string]interface{}{"a": 1})))
+	futures = append(futures, p.AddWithResult(NewBulkUpdateRequest().Index(testIndexName).Id("1").Doc(`{"b":{"c":1}}`)))
+	futures = append(futures, p.AddWithResult(NewBulkUpdateRequest().Index(testIndexName).Id("1").Doc(struct {
+		B map[string]int `json:"b"`
+	}{B: map[string]int{"d": 2}})))
+	p.Add(NewBulkDeleteRequest().Index(testIndexName).Id("2"))
+	p.Add(NewBulkUpdateRequest().Index(testIndexName).Id("2").Doc(map[string]interface{}{"a": 2}))
+	p.Add(NewBulkUpdateRequest().Index(testIndexName).Id("1").Script(NewScript("ctx._source.n++")))
+	p.Add(NewBulkUpdateRequest().Index(testIndexName).Id("1").Doc(map[string]interface{}{"e": 1}))
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 1, len(rec.requests); want != have {
+		t.Fatalf("expected %d bulk requests; got: %d", want, have)
+	}
+	want := []string{
+		`{"update":{"_index":"elastic-test","_id":"1"}}`,
+		`{"doc":{"a":1,"b":{"c":1,"d":2}}}`,
+		`{"update":{"_index":"elastic-test","_id":"2"}}`,
+		`{"doc":{"a":1}}`,
+		`{"delete":{"_index":"elastic-test","_id":"2"}}`,
+		`{"update":{"_index":"elastic-test","_id":"2"}}`,
+		`{"doc":{"a":2}}`,
+		`{"update":{"_index":"elastic-test","_id":"1"}}`,
+		`{"script":{"source":"ctx._source.n++"}}`,
+		`{"update":{"_index":"elastic-test","_id":"1"}}`,
+		`{"doc":{"e":1}}`,
+	}
+	have := rec.requests[0]
+	if len(want) != len(have) {
+		t.Fatalf("expected %d lines; got: %d\n%v", len(want), len(have), have)
+	}
+	for i := range want {
+		if want[i] != have[i] {
+			t.Errorf("line %d: expected %s; got: %s", i+1, want[i], have[i])
+		}
+	}
+	for i, f := range futures {
+		res := f.Result()
+		if res.Err != nil {
+			t.Errorf("future %d: expected no error; got: %v", i, res.Err)
+		}
+	}
+}
+
+func TestBulkOrderingKeyByDocument(t *testing.T) {
+	tests := []struct {
+		Request  BulkableRequest
+		Expected string
+	}{
+		{NewBulkIndexRequest().Index("a").Id("1"), "a/1"},
+		{NewBulkIndexRequest().Index("a"), ""},
+		{NewBulkUpdateRequest().Index("a").Id("2"), "a/2"},
+		{NewBulkDeleteRequest().Index("b").Id("3"), "b/3"},
+		{bulkRawRequest{`{"create":{"_index":"c","_id":"4"}}`, `{}`}, "c/4"},
+		{bulkRawRequest{`{"index":{"_index":"c"}}`, `{}`}, ""},
+	}
+	for i, tt := range tests {
+		if want, have := tt.Expected, BulkOrderingKeyByDocument(tt.Request); want != have {
+			t.Errorf("case #%d: expected %q; got: %q", i+1, want, have)
+		}
+	}
+}
+
+func TestMergeBulkUpdateRequests(t *testing.T) {
+	newUpdate := func() *BulkUpdateRequest {
+		return NewBulkUpdateRequest().Index("a").Id("1")
+	}
+	tests := []struct {
+		A, B     *BulkUpdateRequest
+		Expected string // empty if not mergeable
+	}{
+		{newUpdate().Doc(`{"a":1,"o":{"x":1}}`), newUpdate().Doc(`{"b":2,"o":{"y":2}}`), `{"doc":{"a":1,"b":2,"o":{"x":1,"y":2}}}`},
+		{newUpdate().Doc(`{"o":{"x":1}}`), newUpdate().Doc(`{"o":2}`), `{"doc":{"o":2}}`},
+		{newUpdate().Doc(`{"a":1}`).DocAsUpsert(true), newUpdate().Doc(`{"b":2}`).DocAsUpsert(true), `{"doc":{"a":1,"b":2},"doc_as_upsert":true}`},
+		{newUpdate().Doc(`{"a":1}`).DocAsUpsert(true), newUpdate().Doc(`{"b":2}`), ""},
+		{newUpdate().Doc(`{"a":1}`), newUpdate().Doc(`{"b":2}`).Upsert(map[string]interface{}{"b": 0}), ""},
+		{newUpdate().Doc(`{"a":1}`), newUpdate().Script(NewScript("ctx._source.n++")), ""},
+		{newUpdate().Doc(`{"a":1}`), newUpdate().Doc(`{"b":2}`).IfSeqNo(1).IfPrimaryTerm(1), ""},
+		{newUpdate().Doc(`{"a":1}`), newUpdate().Doc(`{"b":2}`).Routing("r"), ""},
+		{newUpdate().Doc(`{"a":1}`), newUpdate().Doc(`[1,2]`), ""},
+	}
+	for i, tt := range tests {
+		merged, ok := mergeBulkUpdateRequests(tt.A, tt.B)
+		if tt.Expected == "" {
+			if ok {
+				t.Errorf("case #%d: expected requests not to be merged; got: %s", i+1, merged)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("case #%d: expected requests to be merged", i+1)
+			continue
+		}
+		lines, err := merged.Source()
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		if want, have := tt.Expected, lines[1]; want != have {
+			t.Errorf("case #%d: expected %s; got: %s", i+1, want, have)
+		}
+	}
+}
@@ -5,13 +5,18 @@
 package elastic
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestBulk(t *testing.T) {
@@ -524,6 +529,210 @@ func TestBulkContentType(t *testing.T) {
 	}
 }
 
+func TestBulkRetryRejectedItems(t *testing.T) {
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	var batches []int
+	var batchSize int
+	ts := newBulkTestServer(t, func(id string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[id]++
+		batchSize++
+		switch id {
+		case "bad":
+			return http.StatusBadRequest
+		case "busy":
+			// Reject the first two attempts
+			if attempts[id] <= 2 {
+				return http.StatusTooManyRequests
+			}
+		case "unavailable":
+			if attempts[id] <= 1 {
+				return http.StatusServiceUnavailable
+			}
+		case "overloaded":
+			return http.StatusTooManyRequests
+		}
+		return http.StatusCreated
+	})
+	defer ts.Close()
+	ts.Config.Handler = countBatches(ts.Config.Handler, &mu, &batches, &batchSize)
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{"1", "busy", "bad", "unavailable", "2", "overloaded"}
+	svc := client.Bulk().RetryRejectedItems(NewSimpleBackoff(0, 1, 1, 1))
+	for _, id := range ids {
+		svc.Add(NewBulkIndexRequest().Index(testIndexName).Id(id).Doc(tweet{User: "olivere"}))
+	}
+	res, err := svc.Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 0, svc.NumberOfActions(); want != have {
+		t.Errorf("expected %d actions after Do; got: %d", want, have)
+	}
+	if want, have := len(ids), len(res.Items); want != have {
+		t.Fatalf("expected %d items; got: %d", want, have)
+	}
+	wantStatus := []int{201, 201, 400, 201, 201, 429}
+	for i, item := range res.Items {
+		result := item["index"]
+		if want, have := ids[i], result.Id; want != have {
+			t.Errorf("item %d: expected id %q; got: %q", i, want, have)
+		}
+		if want, have := wantStatus[i], result.Status; want != have {
+			t.Errorf("item %d: expected status %d; got: %d", i, want, have)
+		}
+	}
+	if !res.Errors {
+		t.Error("expected errors")
+	}
+	// All items, then busy+unavailable+overloaded, busy+overloaded, and overloaded
+	if want, have := fmt.Sprint([]int{6, 3, 2, 1}), fmt.Sprint(batches); want != have {
+		t.Errorf("expected batches of %s; got: %s", want, have)
+	}
+}
+
+func TestBulkRetryRejectedItemsCanceled(t *testing.T) {
+	ts := newBulkTestServer(t, func(id string) int {
+		if id == "busy" {
+			return http.StatusTooManyRequests
+		}
+		return http.StatusCreated
+	})
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	svc := client.Bulk().RetryRejectedItems(NewConstantBackoff(time.Minute))
+	for _, id := range []string{"1", "busy"} {
+		svc.Add(NewBulkIndexRequest().Index(testIndexName).Id(id).Doc(tweet{User: "olivere"}))
+	}
+	res, err := svc.Do(ctx)
+	if want, have := context.DeadlineExceeded, err; want != have {
+		t.Fatalf("expected error %v; got: %v", want, have)
+	}
+	if res == nil {
+		t.Fatal("expected response")
+	}
+	if !res.Errors {
+		t.Error("expected errors")
+	}
+	if want, have := 1, len(res.Failed()); want != have {
+		t.Errorf("expected %d failed items; got: %d", want, have)
+	}
+}
+
+func TestBulkStream(t *testing.T) {
+	for _, gzipEnabled := range []bool{false, true} {
+		var header http.Header
+		var transferEncoding []string
+		var body []byte
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			transferEncoding = r.TransferEncoding
+			var in io.Reader = r.Body
+			if r.Header.Get("Content-Encoding") == "gzip" {
+				gr, err := gzip.NewReader(r.Body)
+				if err != nil {
+					t.Error(err)
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				in = gr
+			}
+			data, err := ioutil.ReadAll(in)
+			if err != nil {
+				t.Error(err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			body = data
+			fmt.Fprintln(w, `{"items":[{"index":{"_id":"1","status":201}},{"delete":{"_id":"2","status":200}}]}`)
+		}))
+
+		client, err := NewSimpleClient(SetURL(ts.URL), SetGzip(gzipEnabled))
+		if err != nil {
+			t.Fatal(err)
+		}
+		svc := client.Bulk().Stream(true).
+			Add(NewBulkIndexRequest().Index(testIndexName).Id("1").Doc(tweet{User: "olivere", Message: "Welcome to Golang and Elasticsearch."})).
+			Add(NewBulkDeleteRequest().Index(testIndexName).Id("2"))
+		want, err := svc.bodyAsString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, have := int64(len(want)), svc.EstimatedSizeInBytes(); want != have {
+			t.Errorf("gzip=%v: expected estimated size of %d bytes; got: %d", gzipEnabled, want, have)
+		}
+		res, err := svc.Do(context.Background())
+		ts.Close()
+		if err != nil {
+			t.Fatalf("gzip=%v: %v", gzipEnabled, err)
+		}
+		if want, have := 2, len(res.Items); want != have {
+			t.Errorf("gzip=%v: expected %d items; got: %d", gzipEnabled, want, have)
+		}
+		if want, have := want, string(body); want != have {
+			t.Errorf("gzip=%v: expected body\n%s\ngot:\n%s", gzipEnabled, want, have)
+		}
+		if want, have := "application/x-ndjson", header.Get("Content-Type"); want != have {
+			t.Errorf("gzip=%v: expected Content-Type %q; got: %q", gzipEnabled, want, have)
+		}
+		if want, have := fmt.Sprint([]string{"chunked"}), fmt.Sprint(transferEncoding); want != have {
+			t.Errorf("gzip=%v: expected Transfer-Encoding %s; got: %s", gzipEnabled, want, have)
+		}
+		if gzipEnabled {
+			if want, have := "gzip", header.Get("Content-Encoding"); want != have {
+				t.Errorf("gzip=%v: expected Content-Encoding %q; got: %q", gzipEnabled, want, have)
+			}
+		}
+	}
+}
+
+func TestBulkStreamSourceError(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Bulk().Stream(true).
+		Add(NewBulkIndexRequest().Index(testIndexName).Id("1").Doc(map[string]interface{}{"invalid": make(chan int)})).
+		Do(context.Background())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want, have := 0, requests; want != have {
+		t.Errorf("expected %d requests; got: %d", want, have)
+	}
+}
+
+// countBatches wraps a bulk test server handler to record the number of
+// items of each bulk request.
+func countBatches(h http.Handler, mu *sync.Mutex, batches *[]int, batchSize *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r)
+		mu.Lock()
+		*batches = append(*batches, *batchSize)
+		*batchSize = 0
+		mu.Unlock()
+	})
+}
+
 // -- Benchmarks --
 
 var benchmarkBulkEstimatedSizeInBytes int64
@@ -598,3 +807,29 @@ func benchmarkBulkAllocs(b *testing.B, size, num int) {
 	}
 	b.ReportAllocs()
 }
+
+func BenchmarkBulkDoWith10000Requests(b *testing.B) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(ioutil.Discard, r.Body)
+		fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		b.Fatal(err)
+	}
+	requests := make([]BulkableRequest, 10000)
+	for i := range requests {
+		requests[i] = NewBulkIndexRequest().Index(testIndexName).Id(fmt.Sprint(i)).Doc(tweet{User: "olivere", Message: "Welcome to Golang and Elasticsearch."})
+	}
+	for _, stream := range []bool{false, true} {
+		b.Run(fmt.Sprintf("stream=%v", stream), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if _, err := client.Bulk().Stream(stream).Add(requests...).Do(context.Background()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
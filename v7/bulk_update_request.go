@@ -239,6 +239,12 @@ func (r *BulkUpdateRequest) String() string {
 // See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/docs-bulk.html
 // for details.
 func (r *BulkUpdateRequest) Source() ([]string, error) {
+	return r.encodeSource(&DefaultEncoder{})
+}
+
+// encodeSource returns the on-wire representation of the request,
+// encoding it with the given encoder unless easyjson is enabled.
+func (r *BulkUpdateRequest) encodeSource(encoder Encoder) ([]string, error) {
 	// { "update" : { "_index" : "test", "_type" : "type1", "_id" : "1", ... } }
 	// { "doc" : { "field1" : "value1", ... } }
 	// or
@@ -274,8 +280,8 @@ func (r *BulkUpdateRequest) Source() ([]string, error) {
 		// easyjson
 		body, err = command.MarshalJSON()
 	} else {
-		// encoding/json
-		body, err = json.Marshal(command)
+		// encoding/json or custom encoder
+		body, err = encoder.Encode(command)
 	}
 	if err != nil {
 		return nil, err
@@ -320,8 +326,8 @@ func (r *BulkUpdateRequest) Source() ([]string, error) {
 		// easyjson
 		body, err = data.MarshalJSON()
 	} else {
-		// encoding/json
-		body, err = json.Marshal(data)
+		// encoding/json or custom encoder
+		body, err = encoder.Encode(data)
 	}
 	if err != nil {
 		return nil, err
@@ -4,6 +4,8 @@
 
 package elastic
 
+//go:generate easyjson -no_std_marshalers cat_aliases.go
+
 import (
 	"context"
 	"fmt"
@@ -150,11 +152,13 @@ func (s *CatAliasesService) Do(ctx context.Context) (CatAliasesResponse, error)
 // -- Result of a get request.
 
 // CatAliasesResponse is the outcome of CatAliasesService.Do.
+//easyjson:json
 type CatAliasesResponse []CatAliasesResponseRow
 
 // CatAliasesResponseRow is a single row in a CatAliasesResponse.
 // Notice that not all of these fields might be filled; that depends
 // on the number of columns chose in the request (see CatAliasesService.Columns).
+//easyjson:json
 type CatAliasesResponseRow struct {
 	// Alias name.
 	Alias string `json:"alias"`
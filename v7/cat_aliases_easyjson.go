@@ -0,0 +1,151 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package elastic
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF8626c0fDecodeGithubComFacertElasticV7(in *jlexer.Lexer, out *CatAliasesResponseRow) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "alias":
+			out.Alias = string(in.String())
+		case "index":
+			out.Index = string(in.String())
+		case "filter":
+			out.Filter = string(in.String())
+		case "routing.index":
+			out.RoutingIndex = string(in.String())
+		case "routing.search":
+			out.RoutingSearch = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF8626c0fEncodeGithubComFacertElasticV7(out *jwriter.Writer, in CatAliasesResponseRow) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"alias\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Alias))
+	}
+	{
+		const prefix string = ",\"index\":"
+		out.RawString(prefix)
+		out.String(string(in.Index))
+	}
+	{
+		const prefix string = ",\"filter\":"
+		out.RawString(prefix)
+		out.String(string(in.Filter))
+	}
+	{
+		const prefix string = ",\"routing.index\":"
+		out.RawString(prefix)
+		out.String(string(in.RoutingIndex))
+	}
+	{
+		const prefix string = ",\"routing.search\":"
+		out.RawString(prefix)
+		out.String(string(in.RoutingSearch))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatAliasesResponseRow) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF8626c0fEncodeGithubComFacertElasticV7(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatAliasesResponseRow) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF8626c0fDecodeGithubComFacertElasticV7(l, v)
+}
+func easyjsonF8626c0fDecodeGithubComFacertElasticV71(in *jlexer.Lexer, out *CatAliasesResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(CatAliasesResponse, 0, 1)
+			} else {
+				*out = CatAliasesResponse{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 CatAliasesResponseRow
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF8626c0fEncodeGithubComFacertElasticV71(out *jwriter.Writer, in CatAliasesResponse) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatAliasesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF8626c0fEncodeGithubComFacertElasticV71(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatAliasesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF8626c0fDecodeGithubComFacertElasticV71(l, v)
+}
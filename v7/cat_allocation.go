@@ -4,6 +4,8 @@
 
 package elastic
 
+//go:generate easyjson -no_std_marshalers cat_allocation.go
+
 import (
 	"context"
 	"fmt"
@@ -161,11 +163,13 @@ func (s *CatAllocationService) Do(ctx context.Context) (CatAllocationResponse, e
 // -- Result of a get request.
 
 // CatAllocationResponse is the outcome of CatAllocationService.Do.
+//easyjson:json
 type CatAllocationResponse []CatAllocationResponseRow
 
 // CatAllocationResponseRow is a single row in a CatAllocationResponse.
 // Notice that not all of these fields might be filled; that depends
 // on the number of columns chose in the request (see CatAllocationService.Columns).
+//easyjson:json
 type CatAllocationResponseRow struct {
 	// Shards represents the number of shards on a node.
 	Shards int `json:"shards,string"`
@@ -0,0 +1,179 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package elastic
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson47f93461DecodeGithubComFacertElasticV7(in *jlexer.Lexer, out *CatAllocationResponseRow) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "shards":
+			out.Shards = int(in.IntStr())
+		case "disk.indices":
+			out.DiskIndices = string(in.String())
+		case "disk.used":
+			out.DiskUsed = string(in.String())
+		case "disk.avail":
+			out.DiskAvail = string(in.String())
+		case "disk.total":
+			out.DiskTotal = string(in.String())
+		case "disk.percent":
+			out.DiskPercent = int(in.IntStr())
+		case "host":
+			out.Host = string(in.String())
+		case "ip":
+			out.IP = string(in.String())
+		case "node":
+			out.Node = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson47f93461EncodeGithubComFacertElasticV7(out *jwriter.Writer, in CatAllocationResponseRow) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"shards\":"
+		out.RawString(prefix[1:])
+		out.IntStr(int(in.Shards))
+	}
+	{
+		const prefix string = ",\"disk.indices\":"
+		out.RawString(prefix)
+		out.String(string(in.DiskIndices))
+	}
+	{
+		const prefix string = ",\"disk.used\":"
+		out.RawString(prefix)
+		out.String(string(in.DiskUsed))
+	}
+	{
+		const prefix string = ",\"disk.avail\":"
+		out.RawString(prefix)
+		out.String(string(in.DiskAvail))
+	}
+	{
+		const prefix string = ",\"disk.total\":"
+		out.RawString(prefix)
+		out.String(string(in.DiskTotal))
+	}
+	{
+		const prefix string = ",\"disk.percent\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.DiskPercent))
+	}
+	{
+		const prefix string = ",\"host\":"
+		out.RawString(prefix)
+		out.String(string(in.Host))
+	}
+	{
+		const prefix string = ",\"ip\":"
+		out.RawString(prefix)
+		out.String(string(in.IP))
+	}
+	{
+		const prefix string = ",\"node\":"
+		out.RawString(prefix)
+		out.String(string(in.Node))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatAllocationResponseRow) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson47f93461EncodeGithubComFacertElasticV7(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatAllocationResponseRow) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson47f93461DecodeGithubComFacertElasticV7(l, v)
+}
+func easyjson47f93461DecodeGithubComFacertElasticV71(in *jlexer.Lexer, out *CatAllocationResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(CatAllocationResponse, 0, 1)
+			} else {
+				*out = CatAllocationResponse{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 CatAllocationResponseRow
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson47f93461EncodeGithubComFacertElasticV71(out *jwriter.Writer, in CatAllocationResponse) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatAllocationResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson47f93461EncodeGithubComFacertElasticV71(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatAllocationResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson47f93461DecodeGithubComFacertElasticV71(l, v)
+}
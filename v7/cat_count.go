@@ -4,6 +4,8 @@
 
 package elastic
 
+//go:generate easyjson -no_std_marshalers cat_count.go
+
 import (
 	"context"
 	"fmt"
@@ -151,12 +153,14 @@ func (s *CatCountService) Do(ctx context.Context) (CatCountResponse, error) {
 // -- Result of a get request.
 
 // CatCountResponse is the outcome of CatCountService.Do.
+//easyjson:json
 type CatCountResponse []CatCountResponseRow
 
 // CatCountResponseRow specifies the data returned for one index
 // of a CatCountResponse. Notice that not all of these fields might
 // be filled; that depends on the number of columns chose in the
 // request (see CatCountService.Columns).
+//easyjson:json
 type CatCountResponseRow struct {
 	Epoch     int64  `json:"epoch,string"` // e.g. 1527077996
 	Timestamp string `json:"timestamp"`    // e.g. "12:19:56"
@@ -0,0 +1,137 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package elastic
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson92900f22DecodeGithubComFacertElasticV7(in *jlexer.Lexer, out *CatCountResponseRow) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "epoch":
+			out.Epoch = int64(in.Int64Str())
+		case "timestamp":
+			out.Timestamp = string(in.String())
+		case "count":
+			out.Count = int(in.IntStr())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson92900f22EncodeGithubComFacertElasticV7(out *jwriter.Writer, in CatCountResponseRow) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"epoch\":"
+		out.RawString(prefix[1:])
+		out.Int64Str(int64(in.Epoch))
+	}
+	{
+		const prefix string = ",\"timestamp\":"
+		out.RawString(prefix)
+		out.String(string(in.Timestamp))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatCountResponseRow) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson92900f22EncodeGithubComFacertElasticV7(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatCountResponseRow) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson92900f22DecodeGithubComFacertElasticV7(l, v)
+}
+func easyjson92900f22DecodeGithubComFacertElasticV71(in *jlexer.Lexer, out *CatCountResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(CatCountResponse, 0, 2)
+			} else {
+				*out = CatCountResponse{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 CatCountResponseRow
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson92900f22EncodeGithubComFacertElasticV71(out *jwriter.Writer, in CatCountResponse) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatCountResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson92900f22EncodeGithubComFacertElasticV71(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatCountResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson92900f22DecodeGithubComFacertElasticV71(l, v)
+}
@@ -4,6 +4,8 @@
 
 package elastic
 
+//go:generate easyjson -no_std_marshalers cat_health.go
+
 import (
 	"context"
 	"fmt"
@@ -137,11 +139,13 @@ func (s *CatHealthService) Do(ctx context.Context) (CatHealthResponse, error) {
 // -- Result of a get request.
 
 // CatHealthResponse is the outcome of CatHealthService.Do.
+//easyjson:json
 type CatHealthResponse []CatHealthResponseRow
 
 // CatHealthResponseRow is a single row in a CatHealthResponse.
 // Notice that not all of these fields might be filled; that depends
 // on the number of columns chose in the request (see CatHealthService.Columns).
+//easyjson:json
 type CatHealthResponseRow struct {
 	Epoch               int64  `json:"epoch,string"`          // e.g. 1527077996
 	Timestamp           string `json:"timestamp"`             // e.g. "12:19:56"
@@ -0,0 +1,214 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package elastic
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonAc250bb1DecodeGithubComFacertElasticV7(in *jlexer.Lexer, out *CatHealthResponseRow) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "epoch":
+			out.Epoch = int64(in.Int64Str())
+		case "timestamp":
+			out.Timestamp = string(in.String())
+		case "cluster":
+			out.Cluster = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "node.total":
+			out.NodeTotal = int(in.IntStr())
+		case "node.data":
+			out.NodeData = int(in.IntStr())
+		case "shards":
+			out.Shards = int(in.IntStr())
+		case "pri":
+			out.Pri = int(in.IntStr())
+		case "relo":
+			out.Relo = int(in.IntStr())
+		case "init":
+			out.Init = int(in.IntStr())
+		case "unassign":
+			out.Unassign = int(in.IntStr())
+		case "pending_tasks":
+			out.PendingTasks = int(in.IntStr())
+		case "max_task_wait_time":
+			out.MaxTaskWaitTime = string(in.String())
+		case "active_shards_percent":
+			out.ActiveShardsPercent = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonAc250bb1EncodeGithubComFacertElasticV7(out *jwriter.Writer, in CatHealthResponseRow) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"epoch\":"
+		out.RawString(prefix[1:])
+		out.Int64Str(int64(in.Epoch))
+	}
+	{
+		const prefix string = ",\"timestamp\":"
+		out.RawString(prefix)
+		out.String(string(in.Timestamp))
+	}
+	{
+		const prefix string = ",\"cluster\":"
+		out.RawString(prefix)
+		out.String(string(in.Cluster))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"node.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.NodeTotal))
+	}
+	{
+		const prefix string = ",\"node.data\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.NodeData))
+	}
+	{
+		const prefix string = ",\"shards\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.Shards))
+	}
+	{
+		const prefix string = ",\"pri\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.Pri))
+	}
+	{
+		const prefix string = ",\"relo\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.Relo))
+	}
+	{
+		const prefix string = ",\"init\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.Init))
+	}
+	{
+		const prefix string = ",\"unassign\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.Unassign))
+	}
+	{
+		const prefix string = ",\"pending_tasks\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PendingTasks))
+	}
+	{
+		const prefix string = ",\"max_task_wait_time\":"
+		out.RawString(prefix)
+		out.String(string(in.MaxTaskWaitTime))
+	}
+	{
+		const prefix string = ",\"active_shards_percent\":"
+		out.RawString(prefix)
+		out.String(string(in.ActiveShardsPercent))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatHealthResponseRow) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonAc250bb1EncodeGithubComFacertElasticV7(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatHealthResponseRow) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonAc250bb1DecodeGithubComFacertElasticV7(l, v)
+}
+func easyjsonAc250bb1DecodeGithubComFacertElasticV71(in *jlexer.Lexer, out *CatHealthResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(CatHealthResponse, 0, 1)
+			} else {
+				*out = CatHealthResponse{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 CatHealthResponseRow
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonAc250bb1EncodeGithubComFacertElasticV71(out *jwriter.Writer, in CatHealthResponse) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatHealthResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonAc250bb1EncodeGithubComFacertElasticV71(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatHealthResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonAc250bb1DecodeGithubComFacertElasticV71(l, v)
+}
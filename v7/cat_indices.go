@@ -4,6 +4,8 @@
 
 package elastic
 
+//go:generate easyjson -no_std_marshalers cat_indices.go
+
 import (
 	"context"
 	"fmt"
@@ -182,12 +184,14 @@ func (s *CatIndicesService) Do(ctx context.Context) (CatIndicesResponse, error)
 // -- Result of a get request.
 
 // CatIndicesResponse is the outcome of CatIndicesService.Do.
+//easyjson:json
 type CatIndicesResponse []CatIndicesResponseRow
 
 // CatIndicesResponseRow specifies the data returned for one index
 // of a CatIndicesResponse. Notice that not all of these fields might
 // be filled; that depends on the number of columns chose in the
 // request (see CatIndicesService.Columns).
+//easyjson:json
 type CatIndicesResponseRow struct {
 	Health                       string `json:"health"`                              // "green", "yellow", or "red"
 	Status                       string `json:"status"`                              // "open" or "closed"
@@ -0,0 +1,1033 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package elastic
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson5feb41e8DecodeGithubComFacertElasticV7(in *jlexer.Lexer, out *CatIndicesResponseRow) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "health":
+			out.Health = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "index":
+			out.Index = string(in.String())
+		case "uuid":
+			out.UUID = string(in.String())
+		case "pri":
+			out.Pri = int(in.IntStr())
+		case "rep":
+			out.Rep = int(in.IntStr())
+		case "docs.count":
+			out.DocsCount = int(in.IntStr())
+		case "docs.deleted":
+			out.DocsDeleted = int(in.IntStr())
+		case "creation.date":
+			out.CreationDate = int64(in.Int64Str())
+		case "creation.date.string":
+			out.CreationDateString = string(in.String())
+		case "store.size":
+			out.StoreSize = string(in.String())
+		case "pri.store.size":
+			out.PriStoreSize = string(in.String())
+		case "completion.size":
+			out.CompletionSize = string(in.String())
+		case "pri.completion.size":
+			out.PriCompletionSize = string(in.String())
+		case "fielddata.memory_size":
+			out.FielddataMemorySize = string(in.String())
+		case "pri.fielddata.memory_size":
+			out.PriFielddataMemorySize = string(in.String())
+		case "fielddata.evictions":
+			out.FielddataEvictions = int(in.IntStr())
+		case "pri.fielddata.evictions":
+			out.PriFielddataEvictions = int(in.IntStr())
+		case "query_cache.memory_size":
+			out.QueryCacheMemorySize = string(in.String())
+		case "pri.query_cache.memory_size":
+			out.PriQueryCacheMemorySize = string(in.String())
+		case "query_cache.evictions":
+			out.QueryCacheEvictions = int(in.IntStr())
+		case "pri.query_cache.evictions":
+			out.PriQueryCacheEvictions = int(in.IntStr())
+		case "request_cache.memory_size":
+			out.RequestCacheMemorySize = string(in.String())
+		case "pri.request_cache.memory_size":
+			out.PriRequestCacheMemorySize = string(in.String())
+		case "request_cache.evictions":
+			out.RequestCacheEvictions = int(in.IntStr())
+		case "pri.request_cache.evictions":
+			out.PriRequestCacheEvictions = int(in.IntStr())
+		case "request_cache.hit_count":
+			out.RequestCacheHitCount = int(in.IntStr())
+		case "pri.request_cache.hit_count":
+			out.PriRequestCacheHitCount = int(in.IntStr())
+		case "request_cache.miss_count":
+			out.RequestCacheMissCount = int(in.IntStr())
+		case "pri.request_cache.miss_count":
+			out.PriRequestCacheMissCount = int(in.IntStr())
+		case "flush.total":
+			out.FlushTotal = int(in.IntStr())
+		case "pri.flush.total":
+			out.PriFlushTotal = int(in.IntStr())
+		case "flush.total_time":
+			out.FlushTotalTime = string(in.String())
+		case "pri.flush.total_time":
+			out.PriFlushTotalTime = string(in.String())
+		case "get.current":
+			out.GetCurrent = int(in.IntStr())
+		case "pri.get.current":
+			out.PriGetCurrent = int(in.IntStr())
+		case "get.time":
+			out.GetTime = string(in.String())
+		case "pri.get.time":
+			out.PriGetTime = string(in.String())
+		case "get.total":
+			out.GetTotal = int(in.IntStr())
+		case "pri.get.total":
+			out.PriGetTotal = int(in.IntStr())
+		case "get.exists_time":
+			out.GetExistsTime = string(in.String())
+		case "pri.get.exists_time":
+			out.PriGetExistsTime = string(in.String())
+		case "get.exists_total":
+			out.GetExistsTotal = int(in.IntStr())
+		case "pri.get.exists_total":
+			out.PriGetExistsTotal = int(in.IntStr())
+		case "get.missing_time":
+			out.GetMissingTime = string(in.String())
+		case "pri.get.missing_time":
+			out.PriGetMissingTime = string(in.String())
+		case "get.missing_total":
+			out.GetMissingTotal = int(in.IntStr())
+		case "pri.get.missing_total":
+			out.PriGetMissingTotal = int(in.IntStr())
+		case "indexing.delete_current":
+			out.IndexingDeleteCurrent = int(in.IntStr())
+		case "pri.indexing.delete_current":
+			out.PriIndexingDeleteCurrent = int(in.IntStr())
+		case "indexing.delete_time":
+			out.IndexingDeleteTime = string(in.String())
+		case "pri.indexing.delete_time":
+			out.PriIndexingDeleteTime = string(in.String())
+		case "indexing.delete_total":
+			out.IndexingDeleteTotal = int(in.IntStr())
+		case "pri.indexing.delete_total":
+			out.PriIndexingDeleteTotal = int(in.IntStr())
+		case "indexing.index_current":
+			out.IndexingIndexCurrent = int(in.IntStr())
+		case "pri.indexing.index_current":
+			out.PriIndexingIndexCurrent = int(in.IntStr())
+		case "indexing.index_time":
+			out.IndexingIndexTime = string(in.String())
+		case "pri.indexing.index_time":
+			out.PriIndexingIndexTime = string(in.String())
+		case "indexing.index_total":
+			out.IndexingIndexTotal = int(in.IntStr())
+		case "pri.indexing.index_total":
+			out.PriIndexingIndexTotal = int(in.IntStr())
+		case "indexing.index_failed":
+			out.IndexingIndexFailed = int(in.IntStr())
+		case "pri.indexing.index_failed":
+			out.PriIndexingIndexFailed = int(in.IntStr())
+		case "merges.current":
+			out.MergesCurrent = int(in.IntStr())
+		case "pri.merges.current":
+			out.PriMergesCurrent = int(in.IntStr())
+		case "merges.current_docs":
+			out.MergesCurrentDocs = int(in.IntStr())
+		case "pri.merges.current_docs":
+			out.PriMergesCurrentDocs = int(in.IntStr())
+		case "merges.current_size":
+			out.MergesCurrentSize = string(in.String())
+		case "pri.merges.current_size":
+			out.PriMergesCurrentSize = string(in.String())
+		case "merges.total":
+			out.MergesTotal = int(in.IntStr())
+		case "pri.merges.total":
+			out.PriMergesTotal = int(in.IntStr())
+		case "merges.total_docs":
+			out.MergesTotalDocs = int(in.IntStr())
+		case "pri.merges.total_docs":
+			out.PriMergesTotalDocs = int(in.IntStr())
+		case "merges.total_size":
+			out.MergesTotalSize = string(in.String())
+		case "pri.merges.total_size":
+			out.PriMergesTotalSize = string(in.String())
+		case "merges.total_time":
+			out.MergesTotalTime = string(in.String())
+		case "pri.merges.total_time":
+			out.PriMergesTotalTime = string(in.String())
+		case "refresh.total":
+			out.RefreshTotal = int(in.IntStr())
+		case "pri.refresh.total":
+			out.PriRefreshTotal = int(in.IntStr())
+		case "refresh.external_total":
+			out.RefreshExternalTotal = int(in.IntStr())
+		case "pri.refresh.external_total":
+			out.PriRefreshExternalTotal = int(in.IntStr())
+		case "refresh.time":
+			out.RefreshTime = string(in.String())
+		case "pri.refresh.time":
+			out.PriRefreshTime = string(in.String())
+		case "refresh.external_time":
+			out.RefreshExternalTime = string(in.String())
+		case "pri.refresh.external_time":
+			out.PriRefreshExternalTime = string(in.String())
+		case "refresh.listeners":
+			out.RefreshListeners = int(in.IntStr())
+		case "pri.refresh.listeners":
+			out.PriRefreshListeners = int(in.IntStr())
+		case "search.fetch_current":
+			out.SearchFetchCurrent = int(in.IntStr())
+		case "pri.search.fetch_current":
+			out.PriSearchFetchCurrent = int(in.IntStr())
+		case "search.fetch_time":
+			out.SearchFetchTime = string(in.String())
+		case "pri.search.fetch_time":
+			out.PriSearchFetchTime = string(in.String())
+		case "search.fetch_total":
+			out.SearchFetchTotal = int(in.IntStr())
+		case "pri.search.fetch_total":
+			out.PriSearchFetchTotal = int(in.IntStr())
+		case "search.open_contexts":
+			out.SearchOpenContexts = int(in.IntStr())
+		case "pri.search.open_contexts":
+			out.PriSearchOpenContexts = int(in.IntStr())
+		case "search.query_current":
+			out.SearchQueryCurrent = int(in.IntStr())
+		case "pri.search.query_current":
+			out.PriSearchQueryCurrent = int(in.IntStr())
+		case "search.query_time":
+			out.SearchQueryTime = string(in.String())
+		case "pri.search.query_time":
+			out.PriSearchQueryTime = string(in.String())
+		case "search.query_total":
+			out.SearchQueryTotal = int(in.IntStr())
+		case "pri.search.query_total":
+			out.PriSearchQueryTotal = int(in.IntStr())
+		case "search.scroll_current":
+			out.SearchScrollCurrent = int(in.IntStr())
+		case "pri.search.scroll_current":
+			out.PriSearchScrollCurrent = int(in.IntStr())
+		case "search.scroll_time":
+			out.SearchScrollTime = string(in.String())
+		case "pri.search.scroll_time":
+			out.PriSearchScrollTime = string(in.String())
+		case "search.scroll_total":
+			out.SearchScrollTotal = int(in.IntStr())
+		case "pri.search.scroll_total":
+			out.PriSearchScrollTotal = int(in.IntStr())
+		case "search.throttled":
+			out.SearchThrottled = bool(in.Bool())
+		case "segments.count":
+			out.SegmentsCount = int(in.IntStr())
+		case "pri.segments.count":
+			out.PriSegmentsCount = int(in.IntStr())
+		case "segments.memory":
+			out.SegmentsMemory = string(in.String())
+		case "pri.segments.memory":
+			out.PriSegmentsMemory = string(in.String())
+		case "segments.index_writer_memory":
+			out.SegmentsIndexWriterMemory = string(in.String())
+		case "pri.segments.index_writer_memory":
+			out.PriSegmentsIndexWriterMemory = string(in.String())
+		case "segments.version_map_memory":
+			out.SegmentsVersionMapMemory = string(in.String())
+		case "pri.segments.version_map_memory":
+			out.PriSegmentsVersionMapMemory = string(in.String())
+		case "segments.fixed_bitset_memory":
+			out.SegmentsFixedBitsetMemory = string(in.String())
+		case "pri.segments.fixed_bitset_memory":
+			out.PriSegmentsFixedBitsetMemory = string(in.String())
+		case "warmer.current":
+			out.WarmerCurrent = int(in.IntStr())
+		case "pri.warmer.current":
+			out.PriWarmerCurrent = int(in.IntStr())
+		case "warmer.total":
+			out.WarmerTotal = int(in.IntStr())
+		case "pri.warmer.total":
+			out.PriWarmerTotal = int(in.IntStr())
+		case "warmer.total_time":
+			out.WarmerTotalTime = string(in.String())
+		case "pri.warmer.total_time":
+			out.PriWarmerTotalTime = string(in.String())
+		case "suggest.current":
+			out.SuggestCurrent = int(in.IntStr())
+		case "pri.suggest.current":
+			out.PriSuggestCurrent = int(in.IntStr())
+		case "suggest.time":
+			out.SuggestTime = string(in.String())
+		case "pri.suggest.time":
+			out.PriSuggestTime = string(in.String())
+		case "suggest.total":
+			out.SuggestTotal = int(in.IntStr())
+		case "pri.suggest.total":
+			out.PriSuggestTotal = int(in.IntStr())
+		case "memory.total":
+			out.MemoryTotal = string(in.String())
+		case "pri.memory.total":
+			out.PriMemoryTotal = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5feb41e8EncodeGithubComFacertElasticV7(out *jwriter.Writer, in CatIndicesResponseRow) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"health\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Health))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"index\":"
+		out.RawString(prefix)
+		out.String(string(in.Index))
+	}
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix)
+		out.String(string(in.UUID))
+	}
+	{
+		const prefix string = ",\"pri\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.Pri))
+	}
+	{
+		const prefix string = ",\"rep\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.Rep))
+	}
+	{
+		const prefix string = ",\"docs.count\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.DocsCount))
+	}
+	{
+		const prefix string = ",\"docs.deleted\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.DocsDeleted))
+	}
+	{
+		const prefix string = ",\"creation.date\":"
+		out.RawString(prefix)
+		out.Int64Str(int64(in.CreationDate))
+	}
+	{
+		const prefix string = ",\"creation.date.string\":"
+		out.RawString(prefix)
+		out.String(string(in.CreationDateString))
+	}
+	{
+		const prefix string = ",\"store.size\":"
+		out.RawString(prefix)
+		out.String(string(in.StoreSize))
+	}
+	{
+		const prefix string = ",\"pri.store.size\":"
+		out.RawString(prefix)
+		out.String(string(in.PriStoreSize))
+	}
+	{
+		const prefix string = ",\"completion.size\":"
+		out.RawString(prefix)
+		out.String(string(in.CompletionSize))
+	}
+	{
+		const prefix string = ",\"pri.completion.size\":"
+		out.RawString(prefix)
+		out.String(string(in.PriCompletionSize))
+	}
+	{
+		const prefix string = ",\"fielddata.memory_size\":"
+		out.RawString(prefix)
+		out.String(string(in.FielddataMemorySize))
+	}
+	{
+		const prefix string = ",\"pri.fielddata.memory_size\":"
+		out.RawString(prefix)
+		out.String(string(in.PriFielddataMemorySize))
+	}
+	{
+		const prefix string = ",\"fielddata.evictions\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.FielddataEvictions))
+	}
+	{
+		const prefix string = ",\"pri.fielddata.evictions\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriFielddataEvictions))
+	}
+	{
+		const prefix string = ",\"query_cache.memory_size\":"
+		out.RawString(prefix)
+		out.String(string(in.QueryCacheMemorySize))
+	}
+	{
+		const prefix string = ",\"pri.query_cache.memory_size\":"
+		out.RawString(prefix)
+		out.String(string(in.PriQueryCacheMemorySize))
+	}
+	{
+		const prefix string = ",\"query_cache.evictions\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.QueryCacheEvictions))
+	}
+	{
+		const prefix string = ",\"pri.query_cache.evictions\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriQueryCacheEvictions))
+	}
+	{
+		const prefix string = ",\"request_cache.memory_size\":"
+		out.RawString(prefix)
+		out.String(string(in.RequestCacheMemorySize))
+	}
+	{
+		const prefix string = ",\"pri.request_cache.memory_size\":"
+		out.RawString(prefix)
+		out.String(string(in.PriRequestCacheMemorySize))
+	}
+	{
+		const prefix string = ",\"request_cache.evictions\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.RequestCacheEvictions))
+	}
+	{
+		const prefix string = ",\"pri.request_cache.evictions\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriRequestCacheEvictions))
+	}
+	{
+		const prefix string = ",\"request_cache.hit_count\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.RequestCacheHitCount))
+	}
+	{
+		const prefix string = ",\"pri.request_cache.hit_count\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriRequestCacheHitCount))
+	}
+	{
+		const prefix string = ",\"request_cache.miss_count\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.RequestCacheMissCount))
+	}
+	{
+		const prefix string = ",\"pri.request_cache.miss_count\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriRequestCacheMissCount))
+	}
+	{
+		const prefix string = ",\"flush.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.FlushTotal))
+	}
+	{
+		const prefix string = ",\"pri.flush.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriFlushTotal))
+	}
+	{
+		const prefix string = ",\"flush.total_time\":"
+		out.RawString(prefix)
+		out.String(string(in.FlushTotalTime))
+	}
+	{
+		const prefix string = ",\"pri.flush.total_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriFlushTotalTime))
+	}
+	{
+		const prefix string = ",\"get.current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.GetCurrent))
+	}
+	{
+		const prefix string = ",\"pri.get.current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriGetCurrent))
+	}
+	{
+		const prefix string = ",\"get.time\":"
+		out.RawString(prefix)
+		out.String(string(in.GetTime))
+	}
+	{
+		const prefix string = ",\"pri.get.time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriGetTime))
+	}
+	{
+		const prefix string = ",\"get.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.GetTotal))
+	}
+	{
+		const prefix string = ",\"pri.get.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriGetTotal))
+	}
+	{
+		const prefix string = ",\"get.exists_time\":"
+		out.RawString(prefix)
+		out.String(string(in.GetExistsTime))
+	}
+	{
+		const prefix string = ",\"pri.get.exists_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriGetExistsTime))
+	}
+	{
+		const prefix string = ",\"get.exists_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.GetExistsTotal))
+	}
+	{
+		const prefix string = ",\"pri.get.exists_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriGetExistsTotal))
+	}
+	{
+		const prefix string = ",\"get.missing_time\":"
+		out.RawString(prefix)
+		out.String(string(in.GetMissingTime))
+	}
+	{
+		const prefix string = ",\"pri.get.missing_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriGetMissingTime))
+	}
+	{
+		const prefix string = ",\"get.missing_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.GetMissingTotal))
+	}
+	{
+		const prefix string = ",\"pri.get.missing_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriGetMissingTotal))
+	}
+	{
+		const prefix string = ",\"indexing.delete_current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.IndexingDeleteCurrent))
+	}
+	{
+		const prefix string = ",\"pri.indexing.delete_current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriIndexingDeleteCurrent))
+	}
+	{
+		const prefix string = ",\"indexing.delete_time\":"
+		out.RawString(prefix)
+		out.String(string(in.IndexingDeleteTime))
+	}
+	{
+		const prefix string = ",\"pri.indexing.delete_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriIndexingDeleteTime))
+	}
+	{
+		const prefix string = ",\"indexing.delete_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.IndexingDeleteTotal))
+	}
+	{
+		const prefix string = ",\"pri.indexing.delete_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriIndexingDeleteTotal))
+	}
+	{
+		const prefix string = ",\"indexing.index_current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.IndexingIndexCurrent))
+	}
+	{
+		const prefix string = ",\"pri.indexing.index_current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriIndexingIndexCurrent))
+	}
+	{
+		const prefix string = ",\"indexing.index_time\":"
+		out.RawString(prefix)
+		out.String(string(in.IndexingIndexTime))
+	}
+	{
+		const prefix string = ",\"pri.indexing.index_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriIndexingIndexTime))
+	}
+	{
+		const prefix string = ",\"indexing.index_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.IndexingIndexTotal))
+	}
+	{
+		const prefix string = ",\"pri.indexing.index_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriIndexingIndexTotal))
+	}
+	{
+		const prefix string = ",\"indexing.index_failed\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.IndexingIndexFailed))
+	}
+	{
+		const prefix string = ",\"pri.indexing.index_failed\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriIndexingIndexFailed))
+	}
+	{
+		const prefix string = ",\"merges.current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.MergesCurrent))
+	}
+	{
+		const prefix string = ",\"pri.merges.current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriMergesCurrent))
+	}
+	{
+		const prefix string = ",\"merges.current_docs\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.MergesCurrentDocs))
+	}
+	{
+		const prefix string = ",\"pri.merges.current_docs\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriMergesCurrentDocs))
+	}
+	{
+		const prefix string = ",\"merges.current_size\":"
+		out.RawString(prefix)
+		out.String(string(in.MergesCurrentSize))
+	}
+	{
+		const prefix string = ",\"pri.merges.current_size\":"
+		out.RawString(prefix)
+		out.String(string(in.PriMergesCurrentSize))
+	}
+	{
+		const prefix string = ",\"merges.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.MergesTotal))
+	}
+	{
+		const prefix string = ",\"pri.merges.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriMergesTotal))
+	}
+	{
+		const prefix string = ",\"merges.total_docs\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.MergesTotalDocs))
+	}
+	{
+		const prefix string = ",\"pri.merges.total_docs\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriMergesTotalDocs))
+	}
+	{
+		const prefix string = ",\"merges.total_size\":"
+		out.RawString(prefix)
+		out.String(string(in.MergesTotalSize))
+	}
+	{
+		const prefix string = ",\"pri.merges.total_size\":"
+		out.RawString(prefix)
+		out.String(string(in.PriMergesTotalSize))
+	}
+	{
+		const prefix string = ",\"merges.total_time\":"
+		out.RawString(prefix)
+		out.String(string(in.MergesTotalTime))
+	}
+	{
+		const prefix string = ",\"pri.merges.total_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriMergesTotalTime))
+	}
+	{
+		const prefix string = ",\"refresh.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.RefreshTotal))
+	}
+	{
+		const prefix string = ",\"pri.refresh.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriRefreshTotal))
+	}
+	{
+		const prefix string = ",\"refresh.external_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.RefreshExternalTotal))
+	}
+	{
+		const prefix string = ",\"pri.refresh.external_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriRefreshExternalTotal))
+	}
+	{
+		const prefix string = ",\"refresh.time\":"
+		out.RawString(prefix)
+		out.String(string(in.RefreshTime))
+	}
+	{
+		const prefix string = ",\"pri.refresh.time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriRefreshTime))
+	}
+	{
+		const prefix string = ",\"refresh.external_time\":"
+		out.RawString(prefix)
+		out.String(string(in.RefreshExternalTime))
+	}
+	{
+		const prefix string = ",\"pri.refresh.external_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriRefreshExternalTime))
+	}
+	{
+		const prefix string = ",\"refresh.listeners\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.RefreshListeners))
+	}
+	{
+		const prefix string = ",\"pri.refresh.listeners\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriRefreshListeners))
+	}
+	{
+		const prefix string = ",\"search.fetch_current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.SearchFetchCurrent))
+	}
+	{
+		const prefix string = ",\"pri.search.fetch_current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriSearchFetchCurrent))
+	}
+	{
+		const prefix string = ",\"search.fetch_time\":"
+		out.RawString(prefix)
+		out.String(string(in.SearchFetchTime))
+	}
+	{
+		const prefix string = ",\"pri.search.fetch_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriSearchFetchTime))
+	}
+	{
+		const prefix string = ",\"search.fetch_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.SearchFetchTotal))
+	}
+	{
+		const prefix string = ",\"pri.search.fetch_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriSearchFetchTotal))
+	}
+	{
+		const prefix string = ",\"search.open_contexts\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.SearchOpenContexts))
+	}
+	{
+		const prefix string = ",\"pri.search.open_contexts\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriSearchOpenContexts))
+	}
+	{
+		const prefix string = ",\"search.query_current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.SearchQueryCurrent))
+	}
+	{
+		const prefix string = ",\"pri.search.query_current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriSearchQueryCurrent))
+	}
+	{
+		const prefix string = ",\"search.query_time\":"
+		out.RawString(prefix)
+		out.String(string(in.SearchQueryTime))
+	}
+	{
+		const prefix string = ",\"pri.search.query_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriSearchQueryTime))
+	}
+	{
+		const prefix string = ",\"search.query_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.SearchQueryTotal))
+	}
+	{
+		const prefix string = ",\"pri.search.query_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriSearchQueryTotal))
+	}
+	{
+		const prefix string = ",\"search.scroll_current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.SearchScrollCurrent))
+	}
+	{
+		const prefix string = ",\"pri.search.scroll_current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriSearchScrollCurrent))
+	}
+	{
+		const prefix string = ",\"search.scroll_time\":"
+		out.RawString(prefix)
+		out.String(string(in.SearchScrollTime))
+	}
+	{
+		const prefix string = ",\"pri.search.scroll_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriSearchScrollTime))
+	}
+	{
+		const prefix string = ",\"search.scroll_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.SearchScrollTotal))
+	}
+	{
+		const prefix string = ",\"pri.search.scroll_total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriSearchScrollTotal))
+	}
+	{
+		const prefix string = ",\"search.throttled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.SearchThrottled))
+	}
+	{
+		const prefix string = ",\"segments.count\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.SegmentsCount))
+	}
+	{
+		const prefix string = ",\"pri.segments.count\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriSegmentsCount))
+	}
+	{
+		const prefix string = ",\"segments.memory\":"
+		out.RawString(prefix)
+		out.String(string(in.SegmentsMemory))
+	}
+	{
+		const prefix string = ",\"pri.segments.memory\":"
+		out.RawString(prefix)
+		out.String(string(in.PriSegmentsMemory))
+	}
+	{
+		const prefix string = ",\"segments.index_writer_memory\":"
+		out.RawString(prefix)
+		out.String(string(in.SegmentsIndexWriterMemory))
+	}
+	{
+		const prefix string = ",\"pri.segments.index_writer_memory\":"
+		out.RawString(prefix)
+		out.String(string(in.PriSegmentsIndexWriterMemory))
+	}
+	{
+		const prefix string = ",\"segments.version_map_memory\":"
+		out.RawString(prefix)
+		out.String(string(in.SegmentsVersionMapMemory))
+	}
+	{
+		const prefix string = ",\"pri.segments.version_map_memory\":"
+		out.RawString(prefix)
+		out.String(string(in.PriSegmentsVersionMapMemory))
+	}
+	{
+		const prefix string = ",\"segments.fixed_bitset_memory\":"
+		out.RawString(prefix)
+		out.String(string(in.SegmentsFixedBitsetMemory))
+	}
+	{
+		const prefix string = ",\"pri.segments.fixed_bitset_memory\":"
+		out.RawString(prefix)
+		out.String(string(in.PriSegmentsFixedBitsetMemory))
+	}
+	{
+		const prefix string = ",\"warmer.current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.WarmerCurrent))
+	}
+	{
+		const prefix string = ",\"pri.warmer.current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriWarmerCurrent))
+	}
+	{
+		const prefix string = ",\"warmer.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.WarmerTotal))
+	}
+	{
+		const prefix string = ",\"pri.warmer.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriWarmerTotal))
+	}
+	{
+		const prefix string = ",\"warmer.total_time\":"
+		out.RawString(prefix)
+		out.String(string(in.WarmerTotalTime))
+	}
+	{
+		const prefix string = ",\"pri.warmer.total_time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriWarmerTotalTime))
+	}
+	{
+		const prefix string = ",\"suggest.current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.SuggestCurrent))
+	}
+	{
+		const prefix string = ",\"pri.suggest.current\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriSuggestCurrent))
+	}
+	{
+		const prefix string = ",\"suggest.time\":"
+		out.RawString(prefix)
+		out.String(string(in.SuggestTime))
+	}
+	{
+		const prefix string = ",\"pri.suggest.time\":"
+		out.RawString(prefix)
+		out.String(string(in.PriSuggestTime))
+	}
+	{
+		const prefix string = ",\"suggest.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.SuggestTotal))
+	}
+	{
+		const prefix string = ",\"pri.suggest.total\":"
+		out.RawString(prefix)
+		out.IntStr(int(in.PriSuggestTotal))
+	}
+	{
+		const prefix string = ",\"memory.total\":"
+		out.RawString(prefix)
+		out.String(string(in.MemoryTotal))
+	}
+	{
+		const prefix string = ",\"pri.memory.total\":"
+		out.RawString(prefix)
+		out.String(string(in.PriMemoryTotal))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatIndicesResponseRow) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5feb41e8EncodeGithubComFacertElasticV7(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatIndicesResponseRow) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5feb41e8DecodeGithubComFacertElasticV7(l, v)
+}
+func easyjson5feb41e8DecodeGithubComFacertElasticV71(in *jlexer.Lexer, out *CatIndicesResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(CatIndicesResponse, 0, 1)
+			} else {
+				*out = CatIndicesResponse{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 CatIndicesResponseRow
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5feb41e8EncodeGithubComFacertElasticV71(out *jwriter.Writer, in CatIndicesResponse) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatIndicesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5feb41e8EncodeGithubComFacertElasticV71(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatIndicesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5feb41e8DecodeGithubComFacertElasticV71(l, v)
+}
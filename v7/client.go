@@ -136,6 +136,7 @@ type Client struct {
 	snifferCallback           SnifferCallback // callback to modify the sniffing decision
 	snifferStop               chan bool       // notify sniffer to stop, and notify back
 	decoder                   Decoder         // used to decode data sent from Elasticsearch
+	encoder                   Encoder         // used to encode data sent to Elasticsearch
 	basicAuth                 bool            // indicates whether to send HTTP Basic Auth credentials
 	basicAuthUsername         string          // username for HTTP Basic Auth
 	basicAuthPassword         string          // password for HTTP Basic Auth
@@ -230,6 +231,7 @@ func NewSimpleClient(options ...ClientOptionFunc) (*Client, error) {
 		cindex:                    -1,
 		scheme:                    DefaultScheme,
 		decoder:                   &DefaultDecoder{},
+		encoder:                   &DefaultEncoder{},
 		healthcheckEnabled:        false,
 		healthcheckTimeoutStartup: off,
 		healthcheckTimeout:        off,
@@ -315,6 +317,7 @@ func DialContext(ctx context.Context, options ...ClientOptionFunc) (*Client, err
 		cindex:                    -1,
 		scheme:                    DefaultScheme,
 		decoder:                   &DefaultDecoder{},
+		encoder:                   &DefaultEncoder{},
 		healthcheckEnabled:        DefaultHealthcheckEnabled,
 		healthcheckTimeoutStartup: DefaultHealthcheckTimeoutStartup,
 		healthcheckTimeout:        DefaultHealthcheckTimeout,
@@ -660,6 +663,22 @@ func SetDecoder(decoder Decoder) ClientOptionFunc {
 	}
 }
 
+// SetEncoder sets the Encoder to use when encoding request bodies sent
+// to Elasticsearch. DefaultEncoder is used by default.
+//
+// Notice that bulk requests cache their serialized form, i.e. a bulk
+// request is encoded with the Encoder of the first client that sends it.
+func SetEncoder(encoder Encoder) ClientOptionFunc {
+	return func(c *Client) error {
+		if encoder != nil {
+			c.encoder = encoder
+		} else {
+			c.encoder = &DefaultEncoder{}
+		}
+		return nil
+	}
+}
+
 // SetRequiredPlugins can be used to indicate that some plugins are required
 // before a Client will be created.
 func SetRequiredPlugins(plugins ...string) ClientOptionFunc {
@@ -1336,7 +1355,7 @@ func (c *Client) PerformRequest(ctx context.Context, opt PerformRequestOptions)
 
 		// Set body
 		if opt.Body != nil {
-			err = req.SetBody(opt.Body, gzipEnabled)
+			err = req.setBody(opt.Body, gzipEnabled, c.encoder)
 			if err != nil {
 				c.errorf("elastic: couldn't set body %+v for request: %v", opt.Body, err)
 				return nil, err
@@ -1442,6 +1461,11 @@ func (c *Client) DeleteByQuery(indices ...string) *DeleteByQueryService {
 	return NewDeleteByQueryService(c).Index(indices...)
 }
 
+// DeleteByQueryRethrottle changes the throttle of a running delete by query task.
+func (c *Client) DeleteByQueryRethrottle() *DeleteByQueryRethrottleService {
+	return NewDeleteByQueryRethrottleService(c)
+}
+
 // Update a document.
 func (c *Client) Update() *UpdateService {
 	return NewUpdateService(c)
@@ -1452,6 +1476,11 @@ func (c *Client) UpdateByQuery(indices ...string) *UpdateByQueryService {
 	return NewUpdateByQueryService(c).Index(indices...)
 }
 
+// UpdateByQueryRethrottle changes the throttle of a running update by query task.
+func (c *Client) UpdateByQueryRethrottle() *UpdateByQueryRethrottleService {
+	return NewUpdateByQueryRethrottleService(c)
+}
+
 // Bulk is the entry point to mass insert/update/delete documents.
 func (c *Client) Bulk() *BulkService {
 	return NewBulkService(c)
@@ -1470,6 +1499,11 @@ func (c *Client) Reindex() *ReindexService {
 	return NewReindexService(c)
 }
 
+// ReindexRethrottle changes the throttle of a running reindex task.
+func (c *Client) ReindexRethrottle() *ReindexRethrottleService {
+	return NewReindexRethrottleService(c)
+}
+
 // TermVectors returns information and statistics on terms in the fields
 // of a particular document.
 func (c *Client) TermVectors(index, typ string) *TermvectorsService {
@@ -1496,6 +1530,26 @@ func (c *Client) MultiSearch() *MultiSearchService {
 	return NewMultiSearchService(c)
 }
 
+// SearchTemplate runs a search with a mustache template.
+func (c *Client) SearchTemplate(indices ...string) *SearchTemplateService {
+	return NewSearchTemplateService(c).Index(indices...)
+}
+
+// MultiSearchTemplate runs several search templates in one roundtrip.
+func (c *Client) MultiSearchTemplate() *MultiSearchTemplateService {
+	return NewMultiSearchTemplateService(c)
+}
+
+// RenderSearchTemplate renders a search template into a search request body.
+func (c *Client) RenderSearchTemplate() *RenderSearchTemplateService {
+	return NewRenderSearchTemplateService(c)
+}
+
+// RankEval evaluates the quality of ranked search results.
+func (c *Client) RankEval(indices ...string) *RankEvalService {
+	return NewRankEvalService(c).Index(indices...)
+}
+
 // Count documents.
 func (c *Client) Count(indices ...string) *CountService {
 	return NewCountService(c).Index(indices...)
@@ -1535,11 +1589,33 @@ func (c *Client) Scroll(indices ...string) *ScrollService {
 	return NewScrollService(c).Index(indices...)
 }
 
+// SlicedScroll scrolls through documents with a number of slices in
+// parallel, prefetching pages while the results are being processed.
+func (c *Client) SlicedScroll(indices ...string) *SlicedScrollService {
+	return NewSlicedScrollService(c).Index(indices...)
+}
+
 // ClearScroll can be used to clear search contexts manually.
 func (c *Client) ClearScroll(scrollIds ...string) *ClearScrollService {
 	return NewClearScrollService(c).ScrollId(scrollIds...)
 }
 
+// OpenPointInTime opens a new Point in Time.
+func (c *Client) OpenPointInTime(indices ...string) *OpenPointInTimeService {
+	return NewOpenPointInTimeService(c).Index(indices...)
+}
+
+// ClosePointInTime closes an existing Point in Time.
+func (c *Client) ClosePointInTime(id string) *ClosePointInTimeService {
+	return NewClosePointInTimeService(c).ID(id)
+}
+
+// SearchAfter iterates over all search results with a point in time and
+// search_after. Use this instead of Scroll for deep pagination.
+func (c *Client) SearchAfter(indices ...string) *SearchAfterService {
+	return NewSearchAfterService(c).Index(indices...)
+}
+
 // -- Indices APIs --
 
 // CreateIndex returns a service to create a new index.
@@ -1884,6 +1960,28 @@ func (c *Client) XPackInfo() *XPackInfoService {
 	return NewXPackInfoService(c)
 }
 
+// -- X-Pack Async Search --
+
+// XPackAsyncSearchSubmit submits a search that runs asynchronously.
+func (c *Client) XPackAsyncSearchSubmit(indices ...string) *XPackAsyncSearchSubmitService {
+	return NewXPackAsyncSearchSubmitService(c).Index(indices...)
+}
+
+// XPackAsyncSearchGet retrieves the results of an async search.
+func (c *Client) XPackAsyncSearchGet(id string) *XPackAsyncSearchGetService {
+	return NewXPackAsyncSearchGetService(c).Id(id)
+}
+
+// XPackAsyncSearchStatus returns the status of an async search.
+func (c *Client) XPackAsyncSearchStatus(id string) *XPackAsyncSearchStatusService {
+	return NewXPackAsyncSearchStatusService(c).Id(id)
+}
+
+// XPackAsyncSearchDelete deletes an async search.
+func (c *Client) XPackAsyncSearchDelete(id string) *XPackAsyncSearchDeleteService {
+	return NewXPackAsyncSearchDeleteService(c).Id(id)
+}
+
 // -- X-Pack Index Lifecycle Management --
 
 // XPackIlmPutLifecycle adds or modifies an ilm policy.
@@ -0,0 +1,269 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+// Esbulk streams local files into an Elasticsearch index via BulkProcessor.
+//
+// Input files may be NDJSON (one JSON document per line), CSV with a
+// header line, or bulk API format (action and source lines) that is
+// passed through as-is. The format is detected from the file extension
+// (.ndjson, .jsonl, .json, .csv, .tsv, .bulk) unless specified with
+// -format. Gzip-compressed input is detected and decompressed
+// automatically. If no file is given, esbulk reads from stdin.
+//
+// Documents rejected by Elasticsearch can be written to a file with
+// -rejected. Each line of that file is a dead letter as written by
+// elastic.BulkDeadLetterWriter, with the response item (including the
+// error), the number of retries and the bulk lines of the rejected
+// request. After fixing the cause, load the file again with
+// -format=rejected.
+//
+// Example
+//
+// Load a gzipped NDJSON file into the index "orders", using the "order_id"
+// field as document id, with 8 workers and batches of 5.000 documents.
+//
+//     esbulk -url=http://127.0.0.1:9200/orders -id-field=order_id -workers=8 -bulk-actions=5000 orders.ndjson.gz
+//
+// Load a CSV file, renaming the "Customer ID" column to "customer_id" and
+// dropping all columns not listed.
+//
+//     esbulk -url=http://127.0.0.1:9200/customers -csv-fields="Customer ID=customer_id,name,email" customers.csv
+//
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/facert/elastic/v7"
+	"github.com/facert/elastic/v7/config"
+)
+
+func main() {
+	var (
+		url           = flag.String("url", "http://localhost:9200", "Elasticsearch URL, optionally with the index as path")
+		index         = flag.String("index", "", "Index name (overrides the index in the URL)")
+		format        = flag.String("format", formatAuto, "Input format: auto, ndjson, csv, bulk, or rejected")
+		opType        = flag.String("op-type", "index", "Operation type for NDJSON and CSV input: index or create")
+		idField       = flag.String("id-field", "", "Document field to use as document id (dots address nested fields)")
+		routingField  = flag.String("routing-field", "", "Document field to use as routing value (dots address nested fields)")
+		pipeline      = flag.String("pipeline", "", "Ingest pipeline to process documents with")
+		csvFields     = flag.String("csv-fields", "", "Comma-separated list of CSV columns to index, optionally renamed with column=field")
+		csvComma      = flag.String("csv-comma", ",", "CSV field delimiter")
+		numWorkers    = flag.Int("workers", 4, "Number of workers")
+		bulkActions   = flag.Int("bulk-actions", 1000, "Number of documents per bulk request (-1 to disable)")
+		bulkSize      = flag.Int("bulk-size", 5<<20, "Size of bulk requests in bytes (-1 to disable)")
+		flushInterval = flag.Duration("flush-interval", 5*time.Second, "Flush interval")
+		rejectedFile  = flag.String("rejected", "", "File to write rejected documents to")
+		progressEvery = flag.Duration("progress", 1*time.Second, "Progress display interval (0 to disable)")
+	)
+	flag.Parse()
+	log.SetFlags(0)
+
+	// Parse configuration from URL
+	cfg, err := config.Parse(*url)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *index != "" {
+		cfg.Index = *index
+	}
+	if *opType != "index" && *opType != "create" {
+		log.Fatalf("invalid operation type %q: expected index or create", *opType)
+	}
+	comma, size := utf8.DecodeRuneInString(*csvComma)
+	if size == 0 || size != len(*csvComma) {
+		log.Fatalf("invalid CSV delimiter %q", *csvComma)
+	}
+	fields, err := parseCSVFields(*csvFields)
+	if err != nil {
+		log.Fatal(err)
+	}
+	csvOpts := csvOptions{Comma: comma, Fields: fields}
+	builder := &requestBuilder{
+		index:        cfg.Index,
+		opType:       *opType,
+		pipeline:     *pipeline,
+		idField:      *idField,
+		routingField: *routingField,
+	}
+
+	filenames := flag.Args()
+	if len(filenames) == 0 {
+		filenames = []string{"-"}
+	}
+	var totalBytes int64
+	for _, filename := range filenames {
+		if filename == "-" {
+			totalBytes = 0
+			break
+		}
+		fi, err := os.Stat(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		totalBytes += fi.Size()
+	}
+	if cfg.Index == "" && needsIndex(*format, filenames) {
+		log.Fatal("no index specified")
+	}
+
+	// Create an Elasticsearch client from the parsed config
+	client, err := elastic.NewClientFromConfig(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	prog := newProgress(totalBytes)
+
+	var rejected *elastic.BulkDeadLetterWriter
+	sink := &countingSink{n: &prog.rejected}
+	if *rejectedFile != "" {
+		rejected, err = elastic.NewBulkDeadLetterFile(*rejectedFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		sink.next = rejected
+	}
+
+	// Create processor
+	p, err := client.BulkProcessor().
+		Name("esbulk").
+		Workers(*numWorkers).
+		BulkActions(*bulkActions).
+		BulkSize(*bulkSize).
+		FlushInterval(*flushInterval).
+		Stats(true).
+		DeadLetter(sink).
+		Do(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Stop reading on SIGINT and SIGTERM, but commit what has been read
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+		<-c
+		cancel()
+	}()
+
+	stopProgressC := make(chan struct{})
+	if *progressEvery > 0 {
+		go prog.Run(os.Stderr, p, *progressEvery, stopProgressC)
+	}
+
+	for _, filename := range filenames {
+		err = load(ctx, p, prog, filename, *format, builder, csvOpts)
+		if err != nil {
+			break
+		}
+	}
+
+	// Commit outstanding documents
+	if cerr := p.Close(); cerr != nil && err == nil {
+		err = cerr
+	}
+	close(stopProgressC)
+	prog.Print(os.Stderr, p)
+	if rejected != nil {
+		if cerr := rejected.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	if err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+	if atomic.LoadInt64(&prog.rejected) > 0 || atomic.LoadInt64(&prog.invalid) > 0 {
+		os.Exit(1)
+	}
+}
+
+// needsIndex returns true if an index is required to load the files,
+// i.e. unless all of them are in a format that includes the index.
+func needsIndex(format string, filenames []string) bool {
+	for _, filename := range filenames {
+		f := format
+		if f == formatAuto {
+			f = detectFormat(filename)
+		}
+		if f != formatBulk && f != formatRejected {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads all documents from a file and adds them to the bulk processor.
+func load(ctx context.Context, p *elastic.BulkProcessor, prog *progress, filename, format string, builder *requestBuilder, csvOpts csvOptions) error {
+	var in io.Reader = os.Stdin
+	if filename != "-" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	r, err := maybeGunzip(&countingReader{r: in, n: &prog.readBytes})
+	if err != nil {
+		return fmt.Errorf("%s: %v", filename, err)
+	}
+	if format == formatAuto {
+		format = detectFormat(filename)
+	}
+	docs, err := newDocReader(format, r, builder, csvOpts)
+	if err != nil {
+		return fmt.Errorf("%s: %v", filename, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		req, err := docs.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if _, ok := err.(*invalidDocError); ok {
+			atomic.AddInt64(&prog.invalid, 1)
+			log.Printf("%s: %v", filename, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", filename, err)
+		}
+		atomic.AddInt64(&prog.read, 1)
+		p.Add(req)
+	}
+}
+
+// countingSink counts rejected documents and passes them on to
+// the next sink, if any.
+type countingSink struct {
+	n    *int64
+	next elastic.BulkDeadLetterSink
+}
+
+func (s *countingSink) Send(letter *elastic.BulkDeadLetter) error {
+	atomic.AddInt64(s.n, 1)
+	if s.next == nil {
+		return nil
+	}
+	return s.next.Send(letter)
+}
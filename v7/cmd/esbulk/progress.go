@@ -0,0 +1,82 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/facert/elastic/v7"
+)
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	atomic.AddInt64(cr.n, int64(n))
+	return n, err
+}
+
+// progress tracks how far a load has come.
+type progress struct {
+	start      time.Time
+	totalBytes int64 // total input size in bytes, or 0 if unknown
+	readBytes  int64 // input bytes consumed so far (updated atomically)
+	read       int64 // # of documents read (updated atomically)
+	invalid    int64 // # of documents that could not be parsed (updated atomically)
+	rejected   int64 // # of documents rejected by Elasticsearch (updated atomically)
+}
+
+func newProgress(totalBytes int64) *progress {
+	return &progress{start: time.Now(), totalBytes: totalBytes}
+}
+
+// Run prints the progress to w every interval until stopC is closed.
+func (p *progress) Run(w io.Writer, bp *elastic.BulkProcessor, interval time.Duration, stopC <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			p.Print(w, bp)
+		case <-stopC:
+			return
+		}
+	}
+}
+
+// Print writes a single progress line to w.
+func (p *progress) Print(w io.Writer, bp *elastic.BulkProcessor) {
+	stats := bp.Stats()
+	read := atomic.LoadInt64(&p.read)
+	readBytes := atomic.LoadInt64(&p.readBytes)
+	elapsed := time.Since(p.start)
+
+	var rate float64
+	if secs := elapsed.Seconds(); secs > 0 {
+		rate = float64(stats.Succeeded) / secs
+	}
+	var percent string
+	if p.totalBytes > 0 {
+		percent = fmt.Sprintf(" (%5.1f%%)", 100*float64(readBytes)/float64(p.totalBytes))
+	}
+	fmt.Fprintf(w, "Read=%9d Succeeded=%9d Failed=%7d Rejected=%7d Invalid=%7d %9.0f docs/s %8.1f MB%s %v\n",
+		read,
+		stats.Succeeded,
+		stats.Failed,
+		atomic.LoadInt64(&p.rejected),
+		atomic.LoadInt64(&p.invalid),
+		rate,
+		float64(readBytes)/(1<<20),
+		percent,
+		elapsed.Truncate(time.Second),
+	)
+}
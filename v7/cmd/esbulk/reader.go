@@ -0,0 +1,430 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/facert/elastic/v7"
+)
+
+// Supported input formats.
+const (
+	formatAuto   = "auto"
+	formatNDJSON = "ndjson"
+	formatCSV    = "csv"
+	formatBulk   = "bulk"
+	// formatRejected reads the rejected documents file of a previous run
+	formatRejected = "rejected"
+)
+
+// detectFormat guesses the input format from the file name,
+// ignoring a trailing .gz extension. It falls back to NDJSON.
+func detectFormat(filename string) string {
+	ext := strings.ToLower(filepath.Ext(strings.TrimSuffix(strings.ToLower(filename), ".gz")))
+	switch ext {
+	case ".csv", ".tsv":
+		return formatCSV
+	case ".bulk":
+		return formatBulk
+	default:
+		return formatNDJSON
+	}
+}
+
+// maybeGunzip returns a reader that transparently decompresses r
+// if it starts with the gzip magic bytes.
+func maybeGunzip(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReaderSize(r, 64<<10)
+	magic, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
+
+// requestBuilder turns documents into bulk requests, applying the
+// settings common to all input formats.
+type requestBuilder struct {
+	index        string
+	opType       string
+	pipeline     string
+	idField      string
+	routingField string
+}
+
+// build returns a bulk index request for doc. Fields is the decoded
+// document used to extract the id and routing values; it may be nil
+// if neither is configured.
+func (b *requestBuilder) build(doc interface{}, fields map[string]interface{}) (*elastic.BulkIndexRequest, error) {
+	r := elastic.NewBulkIndexRequest().Index(b.index).Doc(doc)
+	if b.opType != "" {
+		r = r.OpType(b.opType)
+	}
+	if b.pipeline != "" {
+		r = r.Pipeline(b.pipeline)
+	}
+	if b.idField != "" {
+		id, err := lookupField(fields, b.idField)
+		if err != nil {
+			return nil, err
+		}
+		r = r.Id(id)
+	}
+	if b.routingField != "" {
+		routing, err := lookupField(fields, b.routingField)
+		if err != nil {
+			return nil, err
+		}
+		r = r.Routing(routing)
+	}
+	return r, nil
+}
+
+// needsFields returns true if documents need to be decoded to
+// build a request, i.e. if an id or routing field is configured.
+func (b *requestBuilder) needsFields() bool {
+	return b.idField != "" || b.routingField != ""
+}
+
+// lookupField returns the string value of the field with the given name.
+// Name may use dots to address fields in nested objects, e.g. "user.id".
+func lookupField(fields map[string]interface{}, name string) (string, error) {
+	if v, found := fields[name]; found {
+		return fieldString(name, v)
+	}
+	var cur interface{} = fields
+	for _, part := range strings.Split(name, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("field %q not found", name)
+		}
+		if cur, ok = m[part]; !ok {
+			return "", fmt.Errorf("field %q not found", name)
+		}
+	}
+	return fieldString(name, cur)
+}
+
+func fieldString(name string, v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool, float64, int, int64:
+		return fmt.Sprint(v), nil
+	case nil:
+		return "", fmt.Errorf("field %q is null", name)
+	default:
+		return "", fmt.Errorf("field %q is not a scalar value", name)
+	}
+}
+
+// invalidDocError is returned by a docReader for a document that
+// cannot be parsed. Reading may continue after such an error.
+type invalidDocError struct {
+	msg string
+}
+
+func (e *invalidDocError) Error() string {
+	return e.msg
+}
+
+// invalidf returns a new invalidDocError.
+func invalidf(format string, args ...interface{}) error {
+	return &invalidDocError{msg: fmt.Sprintf(format, args...)}
+}
+
+// docReader reads bulkable requests from an input stream.
+type docReader interface {
+	// Next returns the next request. It returns io.EOF when the
+	// input is exhausted, and an *invalidDocError if the current
+	// document cannot be parsed.
+	Next() (elastic.BulkableRequest, error)
+}
+
+// newDocReader returns a docReader for the given format.
+func newDocReader(format string, r io.Reader, b *requestBuilder, csvOpts csvOptions) (docReader, error) {
+	switch format {
+	case formatNDJSON:
+		return newNDJSONReader(r, b), nil
+	case formatCSV:
+		return newCSVReader(r, b, csvOpts)
+	case formatBulk:
+		return newBulkReader(r, b), nil
+	case formatRejected:
+		return &rejectedReader{r: elastic.NewBulkDeadLetterReader(r)}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// lineReader reads newline-delimited lines of arbitrary length,
+// skipping empty lines.
+type lineReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{r: bufio.NewReaderSize(r, 64<<10)}
+}
+
+// Next returns the next non-empty line without the trailing newline.
+func (lr *lineReader) Next() ([]byte, error) {
+	for {
+		line, err := lr.r.ReadBytes('\n')
+		if len(line) > 0 {
+			lr.line++
+			line = bytes.TrimSpace(line)
+			if len(line) > 0 {
+				return line, nil
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// -- NDJSON --
+
+// ndjsonReader reads one JSON document per line.
+type ndjsonReader struct {
+	lines   *lineReader
+	builder *requestBuilder
+}
+
+func newNDJSONReader(r io.Reader, b *requestBuilder) *ndjsonReader {
+	return &ndjsonReader{lines: newLineReader(r), builder: b}
+}
+
+func (r *ndjsonReader) Next() (elastic.BulkableRequest, error) {
+	line, err := r.lines.Next()
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if r.builder.needsFields() {
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.UseNumber()
+		if err := dec.Decode(&fields); err != nil {
+			return nil, invalidf("line %d: %v", r.lines.line, err)
+		}
+	} else if !json.Valid(line) {
+		return nil, invalidf("line %d: invalid JSON", r.lines.line)
+	}
+	req, err := r.builder.build(json.RawMessage(line), fields)
+	if err != nil {
+		return nil, invalidf("line %d: %v", r.lines.line, err)
+	}
+	return req, nil
+}
+
+// -- CSV --
+
+// csvOptions configures reading CSV files.
+type csvOptions struct {
+	Comma  rune
+	Fields map[string]string // maps CSV header names to document fields
+}
+
+// parseCSVFields parses a comma-separated list of header=field pairs.
+// A pair without "=" keeps the header name as field name.
+func parseCSVFields(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	m := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		switch {
+		case len(kv) == 1:
+			m[kv[0]] = kv[0]
+		case kv[0] == "" || kv[1] == "":
+			return nil, fmt.Errorf("invalid CSV field mapping %q", pair)
+		default:
+			m[kv[0]] = kv[1]
+		}
+	}
+	return m, nil
+}
+
+// csvReader reads CSV records, using the first record as header.
+// If a field mapping is given, only mapped columns are indexed,
+// using the mapped field names. Empty cells are omitted.
+type csvReader struct {
+	r       *csv.Reader
+	builder *requestBuilder
+	header  []string
+	record  int
+}
+
+func newCSVReader(r io.Reader, b *requestBuilder, opts csvOptions) (*csvReader, error) {
+	cr := csv.NewReader(r)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("missing CSV header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(header))
+	for i, col := range header {
+		col = strings.TrimSpace(col)
+		if opts.Fields == nil {
+			names[i] = col
+		} else {
+			names[i] = opts.Fields[col] // empty means: skip column
+		}
+	}
+	return &csvReader{r: cr, builder: b, header: names}, nil
+}
+
+func (r *csvReader) Next() (elastic.BulkableRequest, error) {
+	record, err := r.r.Read()
+	if perr, ok := err.(*csv.ParseError); ok {
+		r.record++
+		return nil, invalidf("%v", perr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.record++
+	doc := make(map[string]interface{}, len(record))
+	for i, value := range record {
+		if i >= len(r.header) || r.header[i] == "" || value == "" {
+			continue
+		}
+		doc[r.header[i]] = value
+	}
+	req, err := r.builder.build(doc, doc)
+	if err != nil {
+		return nil, invalidf("record %d: %v", r.record, err)
+	}
+	return req, nil
+}
+
+// -- Bulk passthrough --
+
+// bulkLines is a bulkable request that consists of pre-serialized
+// bulk API lines.
+type bulkLines []string
+
+func (r bulkLines) String() string {
+	return strings.Join(r, "\n")
+}
+
+func (r bulkLines) Source() ([]string, error) {
+	return r, nil
+}
+
+// bulkReader reads input in bulk API format, i.e. an action line
+// optionally followed by a source line. If the action does not specify
+// an index or pipeline, the defaults of the request builder are added.
+type bulkReader struct {
+	lines   *lineReader
+	builder *requestBuilder
+}
+
+func newBulkReader(r io.Reader, b *requestBuilder) *bulkReader {
+	return &bulkReader{lines: newLineReader(r), builder: b}
+}
+
+func (r *bulkReader) Next() (elastic.BulkableRequest, error) {
+	line, err := r.lines.Next()
+	if err != nil {
+		return nil, err
+	}
+	// Keep numbers like if_seq_no as they are when re-encoding the action
+	var action map[string]map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	if err := dec.Decode(&action); err != nil {
+		return nil, invalidf("line %d: invalid action: %v", r.lines.line, err)
+	}
+	if len(action) != 1 {
+		return nil, invalidf("line %d: expected exactly one action, got %d", r.lines.line, len(action))
+	}
+	var op string
+	var meta map[string]interface{}
+	for k, v := range action {
+		op, meta = k, v
+	}
+	if meta == nil {
+		meta = make(map[string]interface{})
+		action[op] = meta
+	}
+
+	var modified bool
+	if _, found := meta["_index"]; !found && r.builder.index != "" {
+		meta["_index"] = r.builder.index
+		modified = true
+	}
+	if _, found := meta["pipeline"]; !found && r.builder.pipeline != "" && (op == "index" || op == "create") {
+		meta["pipeline"] = r.builder.pipeline
+		modified = true
+	}
+	first := string(line)
+	if modified {
+		body, err := json.Marshal(action)
+		if err != nil {
+			return nil, err
+		}
+		first = string(body)
+	}
+
+	switch op {
+	case "delete":
+		return bulkLines{first}, nil
+	case "index", "create", "update":
+		source, err := r.lines.Next()
+		if err == io.EOF {
+			return nil, invalidf("line %d: missing source for %q action", r.lines.line, op)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return bulkLines{first, string(source)}, nil
+	default:
+		return nil, invalidf("line %d: unknown action %q", r.lines.line, op)
+	}
+}
+
+// -- Rejected documents --
+
+// rejectedReader reads the requests from a rejected documents file
+// written by a previous run.
+type rejectedReader struct {
+	r *elastic.BulkDeadLetterReader
+}
+
+func (r *rejectedReader) Next() (elastic.BulkableRequest, error) {
+	letter, err := r.r.Next()
+	if err != nil {
+		return nil, err
+	}
+	return letter.Request, nil
+}
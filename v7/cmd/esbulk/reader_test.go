@@ -0,0 +1,161 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func readAll(t *testing.T, r docReader) (lines []string, invalid int) {
+	t.Helper()
+	for {
+		req, err := r.Next()
+		if err == io.EOF {
+			return
+		}
+		if _, ok := err.(*invalidDocError); ok {
+			invalid++
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, req.String())
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		Filename string
+		Expected string
+	}{
+		{"orders.ndjson", formatNDJSON},
+		{"orders.jsonl.gz", formatNDJSON},
+		{"orders.json", formatNDJSON},
+		{"-", formatNDJSON},
+		{"orders.CSV", formatCSV},
+		{"orders.tsv.gz", formatCSV},
+		{"orders.bulk", formatBulk},
+		{"orders.bulk.gz", formatBulk},
+	}
+	for _, test := range tests {
+		if got := detectFormat(test.Filename); got != test.Expected {
+			t.Errorf("%s: expected %q; got: %q", test.Filename, test.Expected, got)
+		}
+	}
+}
+
+func TestMaybeGunzip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(`{"a":1}`))
+	zw.Close()
+
+	for _, in := range []io.Reader{&buf, strings.NewReader(`{"a":1}`)} {
+		r, err := maybeGunzip(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"a":1}`; string(got) != want {
+			t.Errorf("expected %q; got: %q", want, string(got))
+		}
+	}
+}
+
+func TestNDJSONReader(t *testing.T) {
+	input := `{"id":1,"user":{"name":"olivere"},"message":"Welcome"}
+
+{"id":"b","user":{"name":"sandrae"}}
+{"id":3,
+{"user":{"name":"sandrae"}}
+`
+	b := &requestBuilder{index: "test", pipeline: "p1", idField: "id", routingField: "user.name"}
+	lines, invalid := readAll(t, newNDJSONReader(strings.NewReader(input), b))
+	if want, have := 2, invalid; want != have {
+		t.Errorf("expected %d invalid documents; got: %d", want, have)
+	}
+	expected := []string{
+		`{"index":{"_index":"test","_id":"1","routing":"olivere","pipeline":"p1"}}` + "\n" +
+			`{"id":1,"user":{"name":"olivere"},"message":"Welcome"}`,
+		`{"index":{"_index":"test","_id":"b","routing":"sandrae","pipeline":"p1"}}` + "\n" +
+			`{"id":"b","user":{"name":"sandrae"}}`,
+	}
+	if want, have := len(expected), len(lines); want != have {
+		t.Fatalf("expected %d requests; got: %d", want, have)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("case #%d: expected\n%s\ngot:\n%s", i+1, expected[i], lines[i])
+		}
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	input := "Customer ID;name;email\n42;Oliver;\n43;Sandra;sandra@example.com\n"
+	fields, err := parseCSVFields("Customer ID=customer_id,name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &requestBuilder{index: "test", opType: "create", idField: "customer_id"}
+	r, err := newCSVReader(strings.NewReader(input), b, csvOptions{Comma: ';', Fields: fields})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, invalid := readAll(t, r)
+	if invalid != 0 {
+		t.Errorf("expected no invalid documents; got: %d", invalid)
+	}
+	expected := []string{
+		`{"create":{"_index":"test","_id":"42"}}` + "\n" + `{"customer_id":"42","name":"Oliver"}`,
+		`{"create":{"_index":"test","_id":"43"}}` + "\n" + `{"customer_id":"43","name":"Sandra"}`,
+	}
+	if want, have := len(expected), len(lines); want != have {
+		t.Fatalf("expected %d requests; got: %d", want, have)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("case #%d: expected\n%s\ngot:\n%s", i+1, expected[i], lines[i])
+		}
+	}
+}
+
+func TestBulkReader(t *testing.T) {
+	input := `{"index":{"_id":"1"}}
+{"message":"Welcome"}
+{"delete":{"_index":"other","_id":"2"}}
+{"update":{"_index":"other","_id":"3"}}
+{"doc":{"message":"Updated"}}
+{"index":{"_id":"4","version":9007199254740993,"version_type":"external"}}
+{"message":"Versioned"}
+`
+	b := &requestBuilder{index: "test", pipeline: "p1"}
+	lines, invalid := readAll(t, newBulkReader(strings.NewReader(input), b))
+	if invalid != 0 {
+		t.Errorf("expected no invalid documents; got: %d", invalid)
+	}
+	expected := []string{
+		`{"index":{"_id":"1","_index":"test","pipeline":"p1"}}` + "\n" + `{"message":"Welcome"}`,
+		`{"delete":{"_index":"other","_id":"2"}}`,
+		`{"update":{"_index":"other","_id":"3"}}` + "\n" + `{"doc":{"message":"Updated"}}`,
+		`{"index":{"_id":"4","_index":"test","pipeline":"p1","version":9007199254740993,"version_type":"external"}}` + "\n" + `{"message":"Versioned"}`,
+	}
+	if want, have := len(expected), len(lines); want != have {
+		t.Fatalf("expected %d requests; got: %d", want, have)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("case #%d: expected\n%s\ngot:\n%s", i+1, expected[i], lines[i])
+		}
+	}
+}
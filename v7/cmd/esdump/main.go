@@ -0,0 +1,164 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+// Esdump exports an index to local files and restores it from there.
+//
+// A dump contains the settings, mappings and aliases of the index and
+// all of its documents. Documents are exported with a parallel sliced
+// scroll, one NDJSON file per slice. See the dump package for details.
+//
+// Example
+//
+// Dump the index "orders" into the directory "orders-backup", using 4
+// slices and gzip compression.
+//
+//     esdump dump -url=http://127.0.0.1:9200/orders -slices=4 -gzip orders-backup
+//
+// Restore the dump into a new index "orders-copy", without the aliases
+// of the original index.
+//
+//     esdump restore -url=http://127.0.0.1:9200/orders-copy -aliases=false -workers=4 orders-backup
+//
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/facert/elastic/v7"
+	"github.com/facert/elastic/v7/config"
+	"github.com/facert/elastic/v7/dump"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s dump|restore [flags] <dir>\n", os.Args[0])
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+		<-c
+		cancel()
+	}()
+
+	var err error
+	switch os.Args[1] {
+	case "dump":
+		err = runDump(ctx, os.Args[2:])
+	case "restore":
+		err = runRestore(ctx, os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// newClient creates a client from a URL like http://localhost:9200/index
+// and returns it along with the index name.
+func newClient(url string) (*elastic.Client, string, error) {
+	cfg, err := config.Parse(url)
+	if err != nil {
+		return nil, "", err
+	}
+	client, err := elastic.NewClientFromConfig(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, cfg.Index, nil
+}
+
+func runDump(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	var (
+		url       = fs.String("url", "http://localhost:9200", "Elasticsearch URL with the index to dump as path")
+		slices    = fs.Int("slices", 1, "Number of slices to scroll in parallel")
+		size      = fs.Int("size", 1000, "Number of documents per scroll request and slice")
+		keepAlive = fs.String("keep-alive", "5m", "Scroll keep-alive")
+		compress  = fs.Bool("gzip", false, "Compress document files with gzip")
+	)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected exactly one directory, got %d", fs.NArg())
+	}
+
+	client, index, err := newClient(*url)
+	if err != nil {
+		return err
+	}
+	if index == "" {
+		return fmt.Errorf("no index specified in %q", *url)
+	}
+
+	start := time.Now()
+	meta, err := dump.NewDumper(client).
+		Index(index).
+		Dir(fs.Arg(0)).
+		Slices(*slices).
+		Size(*size).
+		KeepAlive(*keepAlive).
+		Compress(*compress).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Dumped %d documents of index %q into %s in %v", meta.Documents(), meta.Index, fs.Arg(0), time.Since(start).Truncate(time.Millisecond))
+	return nil
+}
+
+func runRestore(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	var (
+		url         = fs.String("url", "http://localhost:9200", "Elasticsearch URL, optionally with the index to restore into as path")
+		createIndex = fs.Bool("create-index", true, "Create the index before loading documents")
+		aliases     = fs.Bool("aliases", true, "Create the aliases of the dumped index")
+		workers     = fs.Int("workers", 1, "Number of bulk workers")
+		bulkActions = fs.Int("bulk-actions", 1000, "Number of documents per bulk request")
+		bulkSize    = fs.Int("bulk-size", 5<<20, "Size of bulk requests in bytes")
+	)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected exactly one directory, got %d", fs.NArg())
+	}
+
+	client, index, err := newClient(*url)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	res, err := dump.NewRestorer(client).
+		Dir(fs.Arg(0)).
+		Index(index).
+		CreateIndex(*createIndex).
+		Aliases(*aliases).
+		Workers(*workers).
+		BulkActions(*bulkActions).
+		BulkSize(*bulkSize).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Restored %d of %d documents into index %q in %v", res.Succeeded, res.Documents, res.Index, time.Since(start).Truncate(time.Millisecond))
+	if res.Failed > 0 {
+		return fmt.Errorf("%d documents failed", res.Failed)
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ package elastic
 import (
 	"bytes"
 	"encoding/json"
+
+	"github.com/mailru/easyjson"
 )
 
 // Decoder is used to decode responses from Elasticsearch.
@@ -18,11 +20,17 @@ type Decoder interface {
 }
 
 // DefaultDecoder uses json.Unmarshal from the Go standard library
-// to decode JSON data.
+// to decode JSON data. Response types with generated decoders, e.g.
+// SearchResult, BulkResponse, GetResult, and the cat API responses,
+// are decoded with github.com/mailru/easyjson instead.
 type DefaultDecoder struct{}
 
-// Decode decodes with json.Unmarshal from the Go standard library.
+// Decode decodes with easyjson if v implements easyjson.Unmarshaler,
+// and with json.Unmarshal from the Go standard library otherwise.
 func (u *DefaultDecoder) Decode(data []byte, v interface{}) error {
+	if m, ok := v.(easyjson.Unmarshaler); ok {
+		return easyjson.Unmarshal(data, m)
+	}
 	return json.Unmarshal(data, v)
 }
 
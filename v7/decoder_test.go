@@ -10,6 +10,10 @@ import (
 	"encoding/json"
 	"sync/atomic"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/mailru/easyjson"
 )
 
 type decoder struct {
@@ -47,3 +51,127 @@ func TestDecoder(t *testing.T) {
 		t.Errorf("expected at least 1 call of decoder; got: %d", dec.N)
 	}
 }
+
+// Responses used to compare and benchmark the generated decoders
+// with encoding/json.
+const (
+	testDecoderSearchResult = `{
+		"took": 12, "timed_out": false,
+		"_shards": {"total": 5, "successful": 5, "skipped": 0, "failed": 0},
+		"hits": {
+			"total": {"value": 2, "relation": "eq"},
+			"max_score": 1.5,
+			"hits": [
+				{
+					"_index": "twitter", "_type": "_doc", "_id": "1", "_score": 1.5,
+					"_seq_no": 3, "_primary_term": 1, "_version": 2,
+					"_source": {"user": "olivere", "message": "Welcome to Golang and Elasticsearch.", "retweets": 108},
+					"sort": [1.5, "1", 1234567890],
+					"fields": {"user.keyword": ["olivere"], "retweets": [108]},
+					"highlight": {"message": ["Welcome to <em>Golang</em>"]},
+					"matched_queries": ["q1"],
+					"_explanation": {"value": 1.5, "description": "sum of:", "details": [{"value": 1.5, "description": "weight(message:golang)"}]},
+					"inner_hits": {
+						"comments": {"hits": {"total": {"value": 1, "relation": "eq"}, "max_score": null, "hits": [
+							{"_index": "twitter", "_id": "1", "_nested": {"field": "comments", "offset": 0}, "_score": null, "_source": {"text": "Great"}}
+						]}}
+					}
+				},
+				{
+					"_index": "twitter", "_type": "_doc", "_id": "2", "_score": null,
+					"_source": {"user": "sandrae", "message": "Cycling is fun."},
+					"sort": [0.5, "2", 1234567891]
+				}
+			]
+		},
+		"aggregations": {
+			"users": {"doc_count_error_upper_bound": 0, "sum_other_doc_count": 0, "buckets": [{"key": "olivere", "doc_count": 1}, {"key": "sandrae", "doc_count": 1}]},
+			"retweets": {"value": 108.0}
+		},
+		"suggest": {
+			"user-suggest": [{"text": "olivr", "offset": 0, "length": 5, "options": [{"text": "olivere", "score": 0.8, "freq": 1}]}]
+		}
+	}`
+
+	testDecoderBulkResponse = `{
+		"took": 30, "errors": true,
+		"items": [
+			{"index": {"_index": "twitter", "_type": "_doc", "_id": "1", "_version": 1, "result": "created", "_shards": {"total": 2, "successful": 1, "failed": 0}, "status": 201, "_seq_no": 0, "_primary_term": 1}},
+			{"delete": {"_index": "twitter", "_type": "_doc", "_id": "2", "_version": 1, "result": "not_found", "status": 404}},
+			{"update": {"_index": "twitter", "_type": "_doc", "_id": "3", "status": 429, "error": {"type": "es_rejected_execution_exception", "reason": "rejected execution"}}}
+		]
+	}`
+
+	testDecoderGetResult = `{
+		"_index": "twitter", "_type": "_doc", "_id": "1", "_version": 2, "_seq_no": 3, "_primary_term": 1, "found": true,
+		"_source": {"user": "olivere", "message": "Welcome to Golang and Elasticsearch."},
+		"fields": {"retweets": [108]}
+	}`
+
+	testDecoderCatIndicesResponse = `[
+		{"health": "green", "status": "open", "index": "twitter", "uuid": "u8FNjxh8Rfy_awN11oDKYQ", "pri": "1", "rep": "0", "docs.count": "1200", "docs.deleted": "0", "store.size": "88.1kb", "pri.store.size": "88.1kb"},
+		{"health": "yellow", "status": "open", "index": "orders", "uuid": "ZCRsSRs1R1yM2QdKJMqLzQ", "pri": "5", "rep": "1", "docs.count": "0", "docs.deleted": "0", "store.size": "1kb", "pri.store.size": "1kb"}
+	]`
+)
+
+func TestDefaultDecoderGeneratedDecoders(t *testing.T) {
+	tests := []struct {
+		Data string
+		New  func() interface{}
+	}{
+		{testDecoderSearchResult, func() interface{} { return new(SearchResult) }},
+		{testDecoderBulkResponse, func() interface{} { return new(BulkResponse) }},
+		{testDecoderGetResult, func() interface{} { return new(GetResult) }},
+		{testDecoderCatIndicesResponse, func() interface{} { return new(CatIndicesResponse) }},
+	}
+	for i, tt := range tests {
+		want := tt.New()
+		if err := json.Unmarshal([]byte(tt.Data), want); err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		have := tt.New()
+		if err := new(DefaultDecoder).Decode([]byte(tt.Data), have); err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		if _, ok := have.(easyjson.Unmarshaler); !ok {
+			t.Errorf("case #%d: expected %T to have a generated decoder", i+1, have)
+		}
+		// SearchHit keeps the JSON of its fields only with easyjson
+		if diff := cmp.Diff(want, have, cmpopts.IgnoreUnexported(SearchHit{})); diff != "" {
+			t.Errorf("case #%d: generated decoder differs from encoding/json (-want +have):\n%s", i+1, diff)
+		}
+	}
+}
+
+func BenchmarkDecoders(b *testing.B) {
+	benchmarks := []struct {
+		Name string
+		Data string
+		New  func() interface{}
+	}{
+		{"SearchResult", testDecoderSearchResult, func() interface{} { return new(SearchResult) }},
+		{"BulkResponse", testDecoderBulkResponse, func() interface{} { return new(BulkResponse) }},
+		{"GetResult", testDecoderGetResult, func() interface{} { return new(GetResult) }},
+		{"CatIndicesResponse", testDecoderCatIndicesResponse, func() interface{} { return new(CatIndicesResponse) }},
+	}
+	for _, bm := range benchmarks {
+		data := []byte(bm.Data)
+		b.Run(bm.Name+"/encoding_json", func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if err := json.Unmarshal(data, bm.New()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(bm.Name+"/default", func(b *testing.B) {
+			dec := new(DefaultDecoder)
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if err := dec.Decode(data, bm.New()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
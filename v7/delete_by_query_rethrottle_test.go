@@ -0,0 +1,61 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "testing"
+
+func TestDeleteByQueryRethrottleBuildURL(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		Service  *DeleteByQueryRethrottleService
+		Expected string
+		Params   string
+	}{
+		{
+			client.DeleteByQueryRethrottle().TaskId("abc:42").RequestsPerSecond(100),
+			"/_delete_by_query/abc%3A42/_rethrottle",
+			"requests_per_second=100",
+		},
+		{
+			client.DeleteByQueryRethrottle().TaskIdFromNodeAndId("abc", 42).RequestsPerSecond(-1),
+			"/_delete_by_query/abc%3A42/_rethrottle",
+			"requests_per_second=-1",
+		},
+		{
+			client.DeleteByQueryRethrottle().TaskId("abc:42").RequestsPerSecond(0.5).GroupBy("none"),
+			"/_delete_by_query/abc%3A42/_rethrottle",
+			"group_by=none&requests_per_second=0.5",
+		},
+	}
+
+	for i, test := range tests {
+		if err := test.Service.Validate(); err != nil {
+			t.Fatalf("case #%d: expected no error, got %v", i+1, err)
+		}
+		path, params, err := test.Service.buildURL()
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		if path != test.Expected {
+			t.Errorf("case #%d: expected %q; got: %q", i+1, test.Expected, path)
+		}
+		if got := params.Encode(); got != test.Params {
+			t.Errorf("case #%d: expected params %q; got: %q", i+1, test.Params, got)
+		}
+	}
+}
+
+func TestDeleteByQueryRethrottleValidate(t *testing.T) {
+	client := setupTestClient(t)
+
+	err := client.DeleteByQueryRethrottle().Validate()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if want, have := "missing required fields: [TaskId RequestsPerSecond]", err.Error(); want != have {
+		t.Fatalf("expected %q; got: %q", want, have)
+	}
+}
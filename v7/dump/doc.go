@@ -0,0 +1,15 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+/*
+Package dump exports an Elasticsearch index to local files and restores
+it from there.
+
+A dump is a directory with a meta.json file, containing the settings,
+mappings, and aliases of the index, and one NDJSON file per scroll slice,
+containing the documents. Documents are exported with a parallel
+sliced scroll. Use a Dumper to create a dump and a Restorer to recreate
+the index from it.
+*/
+package dump
@@ -0,0 +1,224 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package dump
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/facert/elastic/v7"
+)
+
+// Dumper exports an index into a directory. It exports the settings,
+// mappings and aliases of the index into a meta file and all documents,
+// using a parallel sliced scroll, into one file per slice.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-request-scroll.html#sliced-scroll
+// for details on sliced scrolling.
+type Dumper struct {
+	client    *elastic.Client
+	index     string
+	dir       string
+	slices    int
+	size      int
+	keepAlive string
+	query     elastic.Query
+	compress  bool
+}
+
+// NewDumper creates a new Dumper.
+func NewDumper(client *elastic.Client) *Dumper {
+	return &Dumper{
+		client:    client,
+		slices:    1,
+		size:      1000,
+		keepAlive: "5m",
+	}
+}
+
+// Index is the name of the index to export.
+func (d *Dumper) Index(index string) *Dumper {
+	d.index = index
+	return d
+}
+
+// Dir is the directory to write the dump into. It is created if it
+// doesn't exist.
+func (d *Dumper) Dir(dir string) *Dumper {
+	d.dir = dir
+	return d
+}
+
+// Slices is the number of slices to scroll through in parallel.
+// Every slice is written into its own file. Defaults to 1.
+func (d *Dumper) Slices(slices int) *Dumper {
+	d.slices = slices
+	return d
+}
+
+// Size is the number of documents to retrieve per scroll request
+// and slice. Defaults to 1000.
+func (d *Dumper) Size(size int) *Dumper {
+	d.size = size
+	return d
+}
+
+// KeepAlive specifies how long the scroll contexts are kept alive
+// between requests, e.g. "5m" (the default).
+func (d *Dumper) KeepAlive(keepAlive string) *Dumper {
+	d.keepAlive = keepAlive
+	return d
+}
+
+// Query restricts the exported documents to those matching the query.
+// All documents are exported by default.
+func (d *Dumper) Query(query elastic.Query) *Dumper {
+	d.query = query
+	return d
+}
+
+// Compress enables gzip compression of the document files.
+func (d *Dumper) Compress(compress bool) *Dumper {
+	d.compress = compress
+	return d
+}
+
+// Validate checks if the operation is valid.
+func (d *Dumper) Validate() error {
+	var invalid []string
+	if d.index == "" {
+		invalid = append(invalid, "Index")
+	}
+	if d.dir == "" {
+		invalid = append(invalid, "Dir")
+	}
+	if d.slices < 1 {
+		invalid = append(invalid, "Slices")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do exports the index and returns the meta data of the dump.
+func (d *Dumper) Do(ctx context.Context) (*Meta, error) {
+	// Check pre-conditions
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(d.dir, 0755); err != nil {
+		return nil, err
+	}
+
+	// Get settings, mappings and aliases
+	indices, err := d.client.IndexGet(d.index).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(indices) != 1 {
+		return nil, fmt.Errorf("dump: %q must resolve to exactly one index, got %d", d.index, len(indices))
+	}
+	meta := &Meta{Created: time.Now().UTC()}
+	for name, info := range indices {
+		meta.Index = name
+		meta.Settings = info.Settings
+		meta.Mappings = info.Mappings
+		meta.Aliases = info.Aliases
+	}
+
+	// Export documents with one goroutine per slice
+	ext := ".ndjson"
+	if d.compress {
+		ext += ".gz"
+	}
+	meta.Files = make([]*MetaFile, d.slices)
+	for i := range meta.Files {
+		meta.Files[i] = &MetaFile{Name: fmt.Sprintf("docs-%03d%s", i, ext)}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for i := 0; i < d.slices; i++ {
+		wg.Add(1)
+		go func(slice int) {
+			defer wg.Done()
+			if err := d.dumpSlice(ctx, meta.Index, slice, meta.Files[slice]); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	// Write meta data last, so an incomplete dump cannot be restored
+	if err := writeMeta(d.dir, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// dumpSlice scrolls through a single slice and writes its documents
+// into the given file.
+func (d *Dumper) dumpSlice(ctx context.Context, index string, slice int, file *MetaFile) (err error) {
+	fw, err := createFile(filepath.Join(d.dir, file.Name), d.compress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := fw.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	svc := d.client.Scroll(index).
+		Size(d.size).
+		KeepAlive(d.keepAlive).
+		Sort("_doc", true)
+	if d.query != nil {
+		svc = svc.Query(d.query)
+	}
+	if d.slices > 1 {
+		svc = svc.Slice(elastic.NewSliceQuery().Id(slice).Max(d.slices))
+	}
+	defer svc.Clear(context.Background())
+
+	for {
+		res, err := svc.Do(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, hit := range res.Hits.Hits {
+			err := fw.Write(&document{
+				Id:      hit.Id,
+				Routing: hit.Routing,
+				Source:  hit.Source,
+			})
+			if err != nil {
+				return err
+			}
+			file.Documents++
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package dump
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// MetaFilename is the name of the file that contains the meta data
+	// of a dump, i.e. the index settings, mappings and aliases.
+	MetaFilename = "meta.json"
+)
+
+// Meta describes a dump.
+type Meta struct {
+	Index    string                 `json:"index"`
+	Created  time.Time              `json:"created"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+	Mappings map[string]interface{} `json:"mappings,omitempty"`
+	Aliases  map[string]interface{} `json:"aliases,omitempty"`
+	Files    []*MetaFile            `json:"files"`
+}
+
+// MetaFile describes a single file with documents.
+type MetaFile struct {
+	Name      string `json:"name"`
+	Documents int64  `json:"documents"`
+}
+
+// Documents returns the total number of documents in the dump.
+func (m *Meta) Documents() int64 {
+	var n int64
+	for _, f := range m.Files {
+		n += f.Documents
+	}
+	return n
+}
+
+// ReadMeta reads the meta data of the dump in the given directory.
+func ReadMeta(dir string) (*Meta, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, MetaFilename))
+	if err != nil {
+		return nil, err
+	}
+	m := new(Meta)
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// writeMeta writes the meta data of the dump into the given directory.
+func writeMeta(dir string, m *Meta) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, MetaFilename), data, 0644)
+}
+
+// document is a single line in a documents file.
+type document struct {
+	Id      string          `json:"_id"`
+	Routing string          `json:"_routing,omitempty"`
+	Source  json.RawMessage `json:"_source"`
+}
+
+// nonRestorableSettings lists the index settings that are set by
+// Elasticsearch and cannot be passed when creating an index.
+var nonRestorableSettings = [][]string{
+	{"index", "uuid"},
+	{"index", "creation_date"},
+	{"index", "provided_name"},
+	{"index", "version"},
+	{"index", "resize"},
+	{"index", "verified_before_close"},
+	{"index", "routing", "allocation", "initial_recovery"},
+}
+
+// restorableSettings returns a copy of the index settings without
+// those that are managed by Elasticsearch.
+func restorableSettings(settings map[string]interface{}) map[string]interface{} {
+	if settings == nil {
+		return nil
+	}
+	dst := copyMap(settings)
+	for _, path := range nonRestorableSettings {
+		removePath(dst, path)
+	}
+	return dst
+}
+
+// copyMap makes a deep copy of the nested maps in m.
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	dst := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if sub, ok := v.(map[string]interface{}); ok {
+			dst[k] = copyMap(sub)
+		} else {
+			dst[k] = v
+		}
+	}
+	return dst
+}
+
+// removePath removes the value at the given path. Settings may be
+// nested or flat, so e.g. "index.uuid" is removed as well.
+func removePath(m map[string]interface{}, path []string) {
+	delete(m, strings.Join(path, "."))
+	if len(path) == 1 {
+		return
+	}
+	if sub, ok := m[path[0]].(map[string]interface{}); ok {
+		removePath(sub, path[1:])
+		if len(sub) == 0 {
+			delete(m, path[0])
+		}
+	}
+}
+
+// fileWriter writes documents as NDJSON to a file, optionally gzipped.
+type fileWriter struct {
+	f   *os.File
+	zw  *gzip.Writer
+	bw  *bufio.Writer
+	enc *json.Encoder
+}
+
+func createFile(filename string, compress bool) (*fileWriter, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	fw := &fileWriter{f: f}
+	var w io.Writer = f
+	if compress {
+		fw.zw = gzip.NewWriter(f)
+		w = fw.zw
+	}
+	fw.bw = bufio.NewWriterSize(w, 256<<10)
+	fw.enc = json.NewEncoder(fw.bw)
+	return fw, nil
+}
+
+func (fw *fileWriter) Write(doc *document) error {
+	return fw.enc.Encode(doc)
+}
+
+func (fw *fileWriter) Close() error {
+	err := fw.bw.Flush()
+	if fw.zw != nil {
+		if cerr := fw.zw.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if cerr := fw.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// fileReader reads documents from a file written by fileWriter.
+type fileReader struct {
+	f   *os.File
+	zr  *gzip.Reader
+	dec *json.Decoder
+}
+
+func openFile(filename string) (*fileReader, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	fr := &fileReader{f: f}
+	br := bufio.NewReaderSize(f, 256<<10)
+	var r io.Reader = br
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		fr.zr, err = gzip.NewReader(br)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		r = fr.zr
+	}
+	fr.dec = json.NewDecoder(r)
+	return fr, nil
+}
+
+// Read returns the next document or io.EOF.
+func (fr *fileReader) Read() (*document, error) {
+	doc := new(document)
+	if err := fr.dec.Decode(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (fr *fileReader) Close() error {
+	if fr.zr != nil {
+		fr.zr.Close()
+	}
+	return fr.f.Close()
+}
@@ -0,0 +1,132 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package dump
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRestorableSettings(t *testing.T) {
+	var settings map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"index": {
+			"number_of_shards": "2",
+			"number_of_replicas": "1",
+			"uuid": "b3rXJdBkS6y9Bm2XqWl8vA",
+			"creation_date": "1561029021214",
+			"provided_name": "orders",
+			"version": {"created": "7000099"},
+			"routing": {"allocation": {"initial_recovery": {"_id": "abc"}, "include": {"zone": "a"}}}
+		},
+		"index.routing.allocation.initial_recovery._id": "abc"
+	}`), &settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := restorableSettings(settings)
+	want := map[string]interface{}{
+		"index": map[string]interface{}{
+			"number_of_shards":   "2",
+			"number_of_replicas": "1",
+			"routing": map[string]interface{}{
+				"allocation": map[string]interface{}{
+					"include": map[string]interface{}{"zone": "a"},
+				},
+			},
+		},
+		"index.routing.allocation.initial_recovery._id": "abc",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected\n%v\ngot:\n%v", want, got)
+	}
+	// The original settings must remain unchanged
+	if _, found := settings["index"].(map[string]interface{})["uuid"]; !found {
+		t.Fatal("expected original settings to be unchanged")
+	}
+}
+
+func TestFileRoundtrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	docs := []*document{
+		{Id: "1", Source: json.RawMessage(`{"user":"olivere"}`)},
+		{Id: "2", Routing: "sandrae", Source: json.RawMessage(`{"user":"sandrae"}`)},
+	}
+	for _, compress := range []bool{false, true} {
+		filename := filepath.Join(dir, "docs.ndjson")
+		fw, err := createFile(filename, compress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, doc := range docs {
+			if err := fw.Write(doc); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := fw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		fr, err := openFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []*document
+		for {
+			doc, err := fr.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, doc)
+		}
+		fr.Close()
+		if !reflect.DeepEqual(got, docs) {
+			t.Fatalf("compress=%v: expected %+v; got: %+v", compress, docs, got)
+		}
+	}
+}
+
+func TestMetaRoundtrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	meta := &Meta{
+		Index:    "orders",
+		Mappings: map[string]interface{}{"properties": map[string]interface{}{}},
+		Files: []*MetaFile{
+			{Name: "docs-000.ndjson", Documents: 2},
+			{Name: "docs-001.ndjson", Documents: 3},
+		},
+	}
+	if err := writeMeta(dir, meta); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadMeta(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, meta) {
+		t.Fatalf("expected %+v; got: %+v", meta, got)
+	}
+	if want, have := int64(5), got.Documents(); want != have {
+		t.Fatalf("expected %d documents; got: %d", want, have)
+	}
+}
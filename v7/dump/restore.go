@@ -0,0 +1,209 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package dump
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/facert/elastic/v7"
+)
+
+// Restorer recreates an index from a dump written by Dumper. It creates
+// the index with the dumped settings, mappings and aliases, and then
+// loads all documents via a BulkProcessor.
+type Restorer struct {
+	client      *elastic.Client
+	dir         string
+	index       string
+	createIndex bool
+	aliases     bool
+	workers     int
+	bulkActions int
+	bulkSize    int
+}
+
+// NewRestorer creates a new Restorer.
+func NewRestorer(client *elastic.Client) *Restorer {
+	return &Restorer{
+		client:      client,
+		createIndex: true,
+		aliases:     true,
+		workers:     1,
+		bulkActions: 1000,
+		bulkSize:    5 << 20, // 5 MB
+	}
+}
+
+// Dir is the directory of the dump to restore.
+func (r *Restorer) Dir(dir string) *Restorer {
+	r.dir = dir
+	return r
+}
+
+// Index is the name of the index to restore into. It defaults to the
+// name of the dumped index.
+func (r *Restorer) Index(index string) *Restorer {
+	r.index = index
+	return r
+}
+
+// CreateIndex specifies whether to create the index before loading
+// documents (the default). Set it to false to load the documents into
+// an existing index.
+func (r *Restorer) CreateIndex(createIndex bool) *Restorer {
+	r.createIndex = createIndex
+	return r
+}
+
+// Aliases specifies whether to create the dumped aliases along with the
+// index (the default). You probably want to disable this when restoring
+// a copy next to the original index.
+func (r *Restorer) Aliases(aliases bool) *Restorer {
+	r.aliases = aliases
+	return r
+}
+
+// Workers is the number of concurrent bulk workers. Defaults to 1.
+func (r *Restorer) Workers(workers int) *Restorer {
+	r.workers = workers
+	return r
+}
+
+// BulkActions is the number of documents per bulk request.
+// Defaults to 1000.
+func (r *Restorer) BulkActions(bulkActions int) *Restorer {
+	r.bulkActions = bulkActions
+	return r
+}
+
+// BulkSize is the size of bulk requests in bytes. Defaults to 5 MB.
+func (r *Restorer) BulkSize(bulkSize int) *Restorer {
+	r.bulkSize = bulkSize
+	return r
+}
+
+// Validate checks if the operation is valid.
+func (r *Restorer) Validate() error {
+	var invalid []string
+	if r.dir == "" {
+		invalid = append(invalid, "Dir")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// RestoreResult is the outcome of Restorer.Do.
+type RestoreResult struct {
+	Index     string // name of the restored index
+	Documents int64  // # of documents read from the dump
+	Succeeded int64  // # of documents indexed successfully
+	Failed    int64  // # of documents that could not be indexed
+}
+
+// Do restores the dump. It returns an error if the index cannot be
+// created or the dump cannot be read. Documents that Elasticsearch
+// rejects are counted as failed in the result.
+func (r *Restorer) Do(ctx context.Context) (*RestoreResult, error) {
+	// Check pre-conditions
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	meta, err := ReadMeta(r.dir)
+	if err != nil {
+		return nil, err
+	}
+	ret := &RestoreResult{Index: r.index}
+	if ret.Index == "" {
+		ret.Index = meta.Index
+	}
+
+	// Create index
+	if r.createIndex {
+		body := map[string]interface{}{}
+		if settings := restorableSettings(meta.Settings); len(settings) > 0 {
+			body["settings"] = settings
+		}
+		if len(meta.Mappings) > 0 {
+			body["mappings"] = meta.Mappings
+		}
+		if r.aliases && len(meta.Aliases) > 0 {
+			body["aliases"] = meta.Aliases
+		}
+		if _, err := r.client.CreateIndex(ret.Index).BodyJson(body).Do(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Load documents
+	p, err := r.client.BulkProcessor().
+		Name("restore-" + ret.Index).
+		Workers(r.workers).
+		BulkActions(r.bulkActions).
+		BulkSize(r.bulkSize).
+		After(func(executionId int64, requests []elastic.BulkableRequest, response *elastic.BulkResponse, err error) {
+			if response == nil {
+				if err != nil {
+					atomic.AddInt64(&ret.Failed, int64(len(requests)))
+				}
+				return
+			}
+			atomic.AddInt64(&ret.Failed, int64(len(response.Failed())))
+		}).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range meta.Files {
+		if err = r.restoreFile(ctx, p, ret, filepath.Join(r.dir, file.Name)); err != nil {
+			break
+		}
+	}
+	if cerr := p.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret.Succeeded = ret.Documents - ret.Failed
+	return ret, nil
+}
+
+// restoreFile adds all documents in the given file to the bulk processor.
+func (r *Restorer) restoreFile(ctx context.Context, p *elastic.BulkProcessor, ret *RestoreResult, filename string) error {
+	fr, err := openFile(filename)
+	if err != nil {
+		return err
+	}
+	defer fr.Close()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		doc, err := fr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("dump: %s: %v", filename, err)
+		}
+		req := elastic.NewBulkIndexRequest().
+			Index(ret.Index).
+			Id(doc.Id).
+			Doc(doc.Source)
+		if doc.Routing != "" {
+			req = req.Routing(doc.Routing)
+		}
+		p.Add(req)
+		ret.Documents++
+	}
+}
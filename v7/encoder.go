@@ -0,0 +1,27 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+)
+
+// Encoder is used to encode request bodies sent to Elasticsearch,
+// including the lines of bulk requests and the search source.
+// Users of elastic can implement their own marshaler for advanced purposes
+// and set them per Client (see SetEncoder). If none is specified,
+// DefaultEncoder is used.
+type Encoder interface {
+	Encode(v interface{}) ([]byte, error)
+}
+
+// DefaultEncoder uses json.Marshal from the Go standard library
+// to encode JSON data.
+type DefaultEncoder struct{}
+
+// Encode encodes with json.Marshal from the Go standard library.
+func (u *DefaultEncoder) Encode(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
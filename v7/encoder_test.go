@@ -0,0 +1,104 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+type encoder struct {
+	N int64
+}
+
+func (e *encoder) Encode(v interface{}) ([]byte, error) {
+	atomic.AddInt64(&e.N, 1)
+	return json.Marshal(v)
+}
+
+func TestEncoder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	enc := &encoder{}
+	client, err := NewSimpleClient(SetURL(ts.URL), SetEncoder(enc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name     string
+		Do       func() error
+		Expected int64
+	}{
+		{
+			Name: "Index",
+			Do: func() error {
+				_, err := client.Index().Index(testIndexName).Id("1").BodyJson(tweet{User: "olivere"}).Do(context.Background())
+				return err
+			},
+			Expected: 1,
+		},
+		{
+			Name: "Search",
+			Do: func() error {
+				_, err := client.Search(testIndexName).Query(NewMatchAllQuery()).Do(context.Background())
+				return err
+			},
+			Expected: 1,
+		},
+		{
+			Name: "MultiSearch",
+			Do: func() error {
+				_, err := client.MultiSearch().
+					Add(NewSearchRequest().Index(testIndexName).Query(NewMatchAllQuery())).
+					Add(NewSearchRequest().Index(testIndexName).Query(NewTermQuery("user", "olivere"))).
+					Do(context.Background())
+				return err
+			},
+			Expected: 4, // header and body of each request
+		},
+		{
+			Name: "Bulk",
+			Do: func() error {
+				_, err := client.Bulk().
+					Add(NewBulkIndexRequest().Index(testIndexName).Id("1").Doc(tweet{User: "olivere"})).
+					Add(NewBulkUpdateRequest().Index(testIndexName).Id("1").Doc(map[string]interface{}{"retweets": 1})).
+					Add(NewBulkDeleteRequest().Index(testIndexName).Id("1")).
+					Do(context.Background())
+				return err
+			},
+			Expected: 5, // action and source lines, but no source for delete
+		},
+		{
+			Name: "BulkProcessor",
+			Do: func() error {
+				p, err := client.BulkProcessor().Do(context.Background())
+				if err != nil {
+					return err
+				}
+				p.Add(NewBulkIndexRequest().Index(testIndexName).Id("1").Doc(tweet{User: "olivere"}))
+				return p.Close()
+			},
+			Expected: 2,
+		},
+	}
+	for _, tt := range tests {
+		atomic.StoreInt64(&enc.N, 0)
+		if err := tt.Do(); err != nil {
+			t.Fatalf("%s: %v", tt.Name, err)
+		}
+		if want, have := tt.Expected, atomic.LoadInt64(&enc.N); want != have {
+			t.Errorf("%s: expected %d calls of encoder; got: %d", tt.Name, want, have)
+		}
+	}
+}
@@ -145,6 +145,34 @@ func IsForbidden(err interface{}) bool {
 	return IsStatusCode(err, http.StatusForbidden)
 }
 
+// IsSearchContextMissing returns true if the given error indicates that
+// Elasticsearch could not find the search context of a scroll, e.g. because
+// it has expired. The err parameter can be of type *elastic.Error or
+// elastic.Error.
+func IsSearchContextMissing(err interface{}) bool {
+	var details *ErrorDetails
+	switch e := err.(type) {
+	case *Error:
+		if e != nil {
+			details = e.Details
+		}
+	case Error:
+		details = e.Details
+	}
+	if details == nil {
+		return false
+	}
+	if details.Type == "search_context_missing_exception" {
+		return true
+	}
+	for _, cause := range details.RootCause {
+		if cause != nil && cause.Type == "search_context_missing_exception" {
+			return true
+		}
+	}
+	return false
+}
+
 // IsStatusCode returns true if the given error indicates that the Elasticsearch
 // operation returned the specified HTTP status code. The err parameter can be of
 // type *http.Response, *Error, Error, or int (indicating the HTTP status code).
@@ -0,0 +1,53 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// FieldAndFormat represents a field to retrieve with the fields
+// parameter of a search request, its name or wildcard pattern, and
+// its format (optional).
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/search-fields.html#search-fields-param
+// for details.
+type FieldAndFormat struct {
+	Field           string
+	Format          string
+	IncludeUnmapped *bool
+}
+
+// Source serializes the FieldAndFormat into JSON.
+func (f FieldAndFormat) Source() (interface{}, error) {
+	if f.Format == "" && f.IncludeUnmapped == nil {
+		return f.Field, nil
+	}
+	source := map[string]interface{}{
+		"field": f.Field,
+	}
+	if f.Format != "" {
+		source["format"] = f.Format
+	}
+	if f.IncludeUnmapped != nil {
+		source["include_unmapped"] = *f.IncludeUnmapped
+	}
+	return source, nil
+}
+
+// FieldsAndFormats is a slice of FieldAndFormat instances.
+type FieldsAndFormats []FieldAndFormat
+
+// Source serializes the FieldsAndFormats into JSON.
+func (f FieldsAndFormats) Source() (interface{}, error) {
+	if f == nil {
+		return nil, nil
+	}
+	v := make([]interface{}, 0)
+	for _, field := range f {
+		src, err := field.Source()
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, src)
+	}
+	return v, nil
+}
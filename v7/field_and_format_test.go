@@ -0,0 +1,63 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFieldAndFormat(t *testing.T) {
+	includeUnmapped := true
+	tests := []struct {
+		Field FieldAndFormat
+		Want  interface{}
+	}{
+		{
+			Field: FieldAndFormat{},
+			Want:  "",
+		},
+		{
+			Field: FieldAndFormat{Field: "user.*"},
+			Want:  "user.*",
+		},
+		{
+			Field: FieldAndFormat{Field: "created", Format: "epoch_millis"},
+			Want:  map[string]interface{}{"field": "created", "format": "epoch_millis"},
+		},
+		{
+			Field: FieldAndFormat{Field: "user.*", IncludeUnmapped: &includeUnmapped},
+			Want:  map[string]interface{}{"field": "user.*", "include_unmapped": true},
+		},
+	}
+	for _, tt := range tests {
+		have, err := tt.Field.Source()
+		if err != nil {
+			t.Fatalf("Source(%#v): err=%v", tt.Field, err)
+		}
+		if want := tt.Want; !cmp.Equal(want, have) {
+			t.Fatalf("Source(%#v): want %v, have %v", tt.Field, want, have)
+		}
+	}
+}
+
+func TestFieldsAndFormats(t *testing.T) {
+	fields := FieldsAndFormats{
+		{Field: "user"},
+		{Field: "created", Format: "yyyy-MM-dd"},
+	}
+	have, err := fields.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{
+		"user",
+		map[string]interface{}{"field": "created", "format": "yyyy-MM-dd"},
+	}
+	if !cmp.Equal(want, have) {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// GeoShapeGeometry is a shape that can be indexed into a geo_shape or
+// shape field, or be used in a GeoShapeQuery or ShapeQuery. Source returns
+// the GeoJSON representation of the shape, WKT its Well-Known Text
+// representation.
+//
+// Notice that both GeoJSON and WKT list coordinates in longitude,
+// latitude order.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/geo-shape.html#input-structure
+type GeoShapeGeometry interface {
+	Source() (interface{}, error)
+	WKT() (string, error)
+}
+
+// -- Point --
+
+// GeoShapePoint is a single geographic coordinate.
+type GeoShapePoint struct {
+	Lat float64
+	Lon float64
+}
+
+// NewGeoShapePoint creates a new GeoShapePoint by latitude and longitude.
+func NewGeoShapePoint(lat, lon float64) GeoShapePoint {
+	return GeoShapePoint{Lat: lat, Lon: lon}
+}
+
+// Source returns the GeoJSON of the point.
+func (p GeoShapePoint) Source() (interface{}, error) {
+	return map[string]interface{}{
+		"type":        "Point",
+		"coordinates": p.coordinates(),
+	}, nil
+}
+
+// WKT returns the Well-Known Text of the point.
+func (p GeoShapePoint) WKT() (string, error) {
+	return "POINT (" + p.wkt() + ")", nil
+}
+
+// MarshalJSON encodes the point as GeoJSON.
+func (p GeoShapePoint) MarshalJSON() ([]byte, error) {
+	return marshalGeometry(p)
+}
+
+func (p GeoShapePoint) coordinates() []float64 {
+	return []float64{p.Lon, p.Lat}
+}
+
+func (p GeoShapePoint) wkt() string {
+	return formatWKTFloat(p.Lon) + " " + formatWKTFloat(p.Lat)
+}
+
+// -- LineString --
+
+// GeoShapeLineString is a line through two or more points.
+type GeoShapeLineString []GeoShapePoint
+
+// Source returns the GeoJSON of the line string.
+func (ls GeoShapeLineString) Source() (interface{}, error) {
+	return map[string]interface{}{
+		"type":        "LineString",
+		"coordinates": pointsCoordinates(ls),
+	}, nil
+}
+
+// WKT returns the Well-Known Text of the line string.
+func (ls GeoShapeLineString) WKT() (string, error) {
+	if len(ls) == 0 {
+		return "LINESTRING EMPTY", nil
+	}
+	return "LINESTRING " + pointsWKT(ls), nil
+}
+
+// MarshalJSON encodes the line string as GeoJSON.
+func (ls GeoShapeLineString) MarshalJSON() ([]byte, error) {
+	return marshalGeometry(ls)
+}
+
+// -- Polygon --
+
+// GeoShapePolygon is a list of linear rings. The first ring is the outer
+// boundary of the polygon, all other rings are holes within it. Rings
+// that are not closed, i.e. whose last point differs from the first, are
+// closed when serialized.
+type GeoShapePolygon [][]GeoShapePoint
+
+// NewGeoShapePolygon creates a new GeoShapePolygon with the given outer
+// boundary.
+func NewGeoShapePolygon(points ...GeoShapePoint) GeoShapePolygon {
+	return GeoShapePolygon{points}
+}
+
+// AddHole adds a hole to the polygon.
+func (p GeoShapePolygon) AddHole(points ...GeoShapePoint) GeoShapePolygon {
+	return append(p, points)
+}
+
+// Source returns the GeoJSON of the polygon.
+func (p GeoShapePolygon) Source() (interface{}, error) {
+	return map[string]interface{}{
+		"type":        "Polygon",
+		"coordinates": p.coordinates(),
+	}, nil
+}
+
+// WKT returns the Well-Known Text of the polygon.
+func (p GeoShapePolygon) WKT() (string, error) {
+	if len(p) == 0 {
+		return "POLYGON EMPTY", nil
+	}
+	return "POLYGON " + p.wkt(), nil
+}
+
+// MarshalJSON encodes the polygon as GeoJSON.
+func (p GeoShapePolygon) MarshalJSON() ([]byte, error) {
+	return marshalGeometry(p)
+}
+
+func (p GeoShapePolygon) coordinates() [][][]float64 {
+	rings := make([][][]float64, 0, len(p))
+	for _, ring := range p {
+		rings = append(rings, pointsCoordinates(closeRing(ring)))
+	}
+	return rings
+}
+
+func (p GeoShapePolygon) wkt() string {
+	rings := make([]string, 0, len(p))
+	for _, ring := range p {
+		rings = append(rings, pointsWKT(closeRing(ring)))
+	}
+	return "(" + strings.Join(rings, ", ") + ")"
+}
+
+// -- MultiPolygon --
+
+// GeoShapeMultiPolygon is a list of polygons.
+type GeoShapeMultiPolygon []GeoShapePolygon
+
+// Source returns the GeoJSON of the multi polygon.
+func (mp GeoShapeMultiPolygon) Source() (interface{}, error) {
+	polygons := make([][][][]float64, 0, len(mp))
+	for _, p := range mp {
+		polygons = append(polygons, p.coordinates())
+	}
+	return map[string]interface{}{
+		"type":        "MultiPolygon",
+		"coordinates": polygons,
+	}, nil
+}
+
+// WKT returns the Well-Known Text of the multi polygon.
+func (mp GeoShapeMultiPolygon) WKT() (string, error) {
+	if len(mp) == 0 {
+		return "MULTIPOLYGON EMPTY", nil
+	}
+	polygons := make([]string, 0, len(mp))
+	for _, p := range mp {
+		polygons = append(polygons, p.wkt())
+	}
+	return "MULTIPOLYGON (" + strings.Join(polygons, ", ") + ")", nil
+}
+
+// MarshalJSON encodes the multi polygon as GeoJSON.
+func (mp GeoShapeMultiPolygon) MarshalJSON() ([]byte, error) {
+	return marshalGeometry(mp)
+}
+
+// -- Envelope --
+
+// GeoShapeEnvelope is a bounding rectangle, specified by its top left and
+// bottom right points. It is an Elasticsearch extension to GeoJSON and is
+// written as BBOX in WKT.
+type GeoShapeEnvelope struct {
+	TopLeft     GeoShapePoint
+	BottomRight GeoShapePoint
+}
+
+// NewGeoShapeEnvelope creates a new GeoShapeEnvelope by its top left and
+// bottom right points.
+func NewGeoShapeEnvelope(topLeft, bottomRight GeoShapePoint) GeoShapeEnvelope {
+	return GeoShapeEnvelope{TopLeft: topLeft, BottomRight: bottomRight}
+}
+
+// Source returns the GeoJSON of the envelope.
+func (e GeoShapeEnvelope) Source() (interface{}, error) {
+	return map[string]interface{}{
+		"type":        "envelope",
+		"coordinates": [][]float64{e.TopLeft.coordinates(), e.BottomRight.coordinates()},
+	}, nil
+}
+
+// WKT returns the Well-Known Text of the envelope, which is
+// BBOX (minLon, maxLon, maxLat, minLat).
+func (e GeoShapeEnvelope) WKT() (string, error) {
+	values := []string{
+		formatWKTFloat(e.TopLeft.Lon),
+		formatWKTFloat(e.BottomRight.Lon),
+		formatWKTFloat(e.TopLeft.Lat),
+		formatWKTFloat(e.BottomRight.Lat),
+	}
+	return "BBOX (" + strings.Join(values, ", ") + ")", nil
+}
+
+// MarshalJSON encodes the envelope as GeoJSON.
+func (e GeoShapeEnvelope) MarshalJSON() ([]byte, error) {
+	return marshalGeometry(e)
+}
+
+// -- Circle --
+
+// GeoShapeCircle is a circle specified by its center and radius, e.g.
+// "100m". It is an Elasticsearch extension to GeoJSON and has no WKT
+// representation.
+type GeoShapeCircle struct {
+	Center GeoShapePoint
+	Radius string
+}
+
+// NewGeoShapeCircle creates a new GeoShapeCircle by its center and radius.
+func NewGeoShapeCircle(center GeoShapePoint, radius string) GeoShapeCircle {
+	return GeoShapeCircle{Center: center, Radius: radius}
+}
+
+// Source returns the GeoJSON of the circle.
+func (c GeoShapeCircle) Source() (interface{}, error) {
+	return map[string]interface{}{
+		"type":        "circle",
+		"coordinates": c.Center.coordinates(),
+		"radius":      c.Radius,
+	}, nil
+}
+
+// WKT returns an error as circles cannot be represented in WKT.
+func (c GeoShapeCircle) WKT() (string, error) {
+	return "", errors.New("elastic: circle has no WKT representation")
+}
+
+// MarshalJSON encodes the circle as GeoJSON.
+func (c GeoShapeCircle) MarshalJSON() ([]byte, error) {
+	return marshalGeometry(c)
+}
+
+// -- GeometryCollection --
+
+// GeoShapeGeometryCollection is a list of geometries.
+type GeoShapeGeometryCollection []GeoShapeGeometry
+
+// Source returns the GeoJSON of the geometry collection.
+func (gc GeoShapeGeometryCollection) Source() (interface{}, error) {
+	geometries := make([]interface{}, 0, len(gc))
+	for _, g := range gc {
+		src, err := g.Source()
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, src)
+	}
+	return map[string]interface{}{
+		"type":       "GeometryCollection",
+		"geometries": geometries,
+	}, nil
+}
+
+// WKT returns the Well-Known Text of the geometry collection.
+func (gc GeoShapeGeometryCollection) WKT() (string, error) {
+	if len(gc) == 0 {
+		return "GEOMETRYCOLLECTION EMPTY", nil
+	}
+	geometries := make([]string, 0, len(gc))
+	for _, g := range gc {
+		wkt, err := g.WKT()
+		if err != nil {
+			return "", err
+		}
+		geometries = append(geometries, wkt)
+	}
+	return "GEOMETRYCOLLECTION (" + strings.Join(geometries, ", ") + ")", nil
+}
+
+// MarshalJSON encodes the geometry collection as GeoJSON.
+func (gc GeoShapeGeometryCollection) MarshalJSON() ([]byte, error) {
+	return marshalGeometry(gc)
+}
+
+// -- Helpers --
+
+func marshalGeometry(g GeoShapeGeometry) ([]byte, error) {
+	src, err := g.Source()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(src)
+}
+
+func formatWKTFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func pointsCoordinates(points []GeoShapePoint) [][]float64 {
+	coords := make([][]float64, 0, len(points))
+	for _, p := range points {
+		coords = append(coords, p.coordinates())
+	}
+	return coords
+}
+
+func pointsWKT(points []GeoShapePoint) string {
+	list := make([]string, 0, len(points))
+	for _, p := range points {
+		list = append(list, p.wkt())
+	}
+	return "(" + strings.Join(list, ", ") + ")"
+}
+
+// closeRing returns the ring with its first point appended if the ring
+// is not closed.
+func closeRing(ring []GeoShapePoint) []GeoShapePoint {
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		closed := make([]GeoShapePoint, len(ring), len(ring)+1)
+		copy(closed, ring)
+		return append(closed, ring[0])
+	}
+	return ring
+}
@@ -0,0 +1,125 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeometry(t *testing.T) {
+	zone := NewGeoShapePolygon(
+		NewGeoShapePoint(52.5, 13.3),
+		NewGeoShapePoint(52.5, 13.5),
+		NewGeoShapePoint(52.4, 13.5),
+		NewGeoShapePoint(52.4, 13.3),
+	).AddHole(
+		NewGeoShapePoint(52.46, 13.4),
+		NewGeoShapePoint(52.46, 13.42),
+		NewGeoShapePoint(52.44, 13.42),
+		NewGeoShapePoint(52.46, 13.4),
+	)
+
+	tests := []struct {
+		Geometry GeoShapeGeometry
+		GeoJSON  string
+		WKT      string
+	}{
+		{
+			NewGeoShapePoint(38.897676, -77.03653),
+			`{"coordinates":[-77.03653,38.897676],"type":"Point"}`,
+			`POINT (-77.03653 38.897676)`,
+		},
+		{
+			GeoShapeLineString{NewGeoShapePoint(40.7, -74), NewGeoShapePoint(41, -73.5)},
+			`{"coordinates":[[-74,40.7],[-73.5,41]],"type":"LineString"}`,
+			`LINESTRING (-74 40.7, -73.5 41)`,
+		},
+		{
+			zone,
+			`{"coordinates":[[[13.3,52.5],[13.5,52.5],[13.5,52.4],[13.3,52.4],[13.3,52.5]],[[13.4,52.46],[13.42,52.46],[13.42,52.44],[13.4,52.46]]],"type":"Polygon"}`,
+			`POLYGON ((13.3 52.5, 13.5 52.5, 13.5 52.4, 13.3 52.4, 13.3 52.5), (13.4 52.46, 13.42 52.46, 13.42 52.44, 13.4 52.46))`,
+		},
+		{
+			GeoShapeMultiPolygon{
+				NewGeoShapePolygon(NewGeoShapePoint(0, 0), NewGeoShapePoint(0, 1), NewGeoShapePoint(1, 1)),
+				NewGeoShapePolygon(NewGeoShapePoint(2, 2), NewGeoShapePoint(2, 3), NewGeoShapePoint(3, 3)),
+			},
+			`{"coordinates":[[[[0,0],[1,0],[1,1],[0,0]]],[[[2,2],[3,2],[3,3],[2,2]]]],"type":"MultiPolygon"}`,
+			`MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((2 2, 3 2, 3 3, 2 2)))`,
+		},
+		{
+			NewGeoShapeEnvelope(NewGeoShapePoint(53, 13), NewGeoShapePoint(52, 14)),
+			`{"coordinates":[[13,53],[14,52]],"type":"envelope"}`,
+			`BBOX (13, 14, 53, 52)`,
+		},
+		{
+			GeoShapeGeometryCollection{NewGeoShapePoint(1, 2), GeoShapeLineString{NewGeoShapePoint(1, 2), NewGeoShapePoint(3, 4)}},
+			`{"geometries":[{"coordinates":[2,1],"type":"Point"},{"coordinates":[[2,1],[4,3]],"type":"LineString"}],"type":"GeometryCollection"}`,
+			`GEOMETRYCOLLECTION (POINT (2 1), LINESTRING (2 1, 4 3))`,
+		},
+		{
+			GeoShapeLineString{},
+			`{"coordinates":[],"type":"LineString"}`,
+			`LINESTRING EMPTY`,
+		},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.Geometry)
+		if err != nil {
+			t.Fatalf("marshaling to JSON failed: %v", err)
+		}
+		if got := string(data); got != tt.GeoJSON {
+			t.Errorf("expected\n%s\n,got:\n%s", tt.GeoJSON, got)
+		}
+		wkt, err := tt.Geometry.WKT()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wkt != tt.WKT {
+			t.Errorf("expected\n%s\n,got:\n%s", tt.WKT, wkt)
+		}
+	}
+}
+
+func TestGeometryCircle(t *testing.T) {
+	c := NewGeoShapeCircle(NewGeoShapePoint(52.5, 13.4), "100m")
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"coordinates":[13.4,52.5],"radius":"100m","type":"circle"}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+	if _, err := c.WKT(); err == nil {
+		t.Fatal("expected error for WKT of circle")
+	}
+	if _, err := (GeoShapeGeometryCollection{NewGeoShapePoint(1, 2), c}).WKT(); err == nil {
+		t.Fatal("expected error for WKT of geometry collection with circle")
+	}
+}
+
+func TestGeometryInDocument(t *testing.T) {
+	type DeliveryZone struct {
+		Name string           `json:"name"`
+		Area GeoShapeGeometry `json:"area"`
+	}
+	zone := DeliveryZone{
+		Name: "Center",
+		Area: NewGeoShapePolygon(NewGeoShapePoint(52.5, 13.3), NewGeoShapePoint(52.5, 13.5), NewGeoShapePoint(52.4, 13.5)),
+	}
+	data, err := json.Marshal(zone)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"name":"Center","area":{"coordinates":[[[13.3,52.5],[13.5,52.5],[13.5,52.4],[13.3,52.5]]],"type":"Polygon"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -4,6 +4,8 @@
 
 package elastic
 
+//go:generate easyjson -no_std_marshalers get.go
+
 import (
 	"context"
 	"encoding/json"
@@ -247,6 +249,7 @@ func (s *GetService) Do(ctx context.Context) (*GetResult, error) {
 // -- Result of a get request.
 
 // GetResult is the outcome of GetService.Do.
+//easyjson:json
 type GetResult struct {
 	Index       string                 `json:"_index"`   // index meta field
 	Type        string                 `json:"_type"`    // type meta field
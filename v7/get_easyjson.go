@@ -0,0 +1,512 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package elastic
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson89020eaDecodeGithubComFacertElasticV7(in *jlexer.Lexer, out *GetResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "_index":
+			out.Index = string(in.String())
+		case "_type":
+			out.Type = string(in.String())
+		case "_id":
+			out.Id = string(in.String())
+		case "_uid":
+			out.Uid = string(in.String())
+		case "_routing":
+			out.Routing = string(in.String())
+		case "_parent":
+			out.Parent = string(in.String())
+		case "_version":
+			if in.IsNull() {
+				in.Skip()
+				out.Version = nil
+			} else {
+				if out.Version == nil {
+					out.Version = new(int64)
+				}
+				*out.Version = int64(in.Int64())
+			}
+		case "_seq_no":
+			if in.IsNull() {
+				in.Skip()
+				out.SeqNo = nil
+			} else {
+				if out.SeqNo == nil {
+					out.SeqNo = new(int64)
+				}
+				*out.SeqNo = int64(in.Int64())
+			}
+		case "_primary_term":
+			if in.IsNull() {
+				in.Skip()
+				out.PrimaryTerm = nil
+			} else {
+				if out.PrimaryTerm == nil {
+					out.PrimaryTerm = new(int64)
+				}
+				*out.PrimaryTerm = int64(in.Int64())
+			}
+		case "_source":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Source).UnmarshalJSON(data))
+			}
+		case "found":
+			out.Found = bool(in.Bool())
+		case "fields":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Fields = make(map[string]interface{})
+				} else {
+					out.Fields = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v1 interface{}
+					if m, ok := v1.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v1.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v1 = in.Interface()
+					}
+					(out.Fields)[key] = v1
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+				out.Error = nil
+			} else {
+				if out.Error == nil {
+					out.Error = new(ErrorDetails)
+				}
+				easyjson89020eaDecodeGithubComFacertElasticV71(in, out.Error)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson89020eaEncodeGithubComFacertElasticV7(out *jwriter.Writer, in GetResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"_index\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Index))
+	}
+	{
+		const prefix string = ",\"_type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"_id\":"
+		out.RawString(prefix)
+		out.String(string(in.Id))
+	}
+	{
+		const prefix string = ",\"_uid\":"
+		out.RawString(prefix)
+		out.String(string(in.Uid))
+	}
+	{
+		const prefix string = ",\"_routing\":"
+		out.RawString(prefix)
+		out.String(string(in.Routing))
+	}
+	{
+		const prefix string = ",\"_parent\":"
+		out.RawString(prefix)
+		out.String(string(in.Parent))
+	}
+	{
+		const prefix string = ",\"_version\":"
+		out.RawString(prefix)
+		if in.Version == nil {
+			out.RawString("null")
+		} else {
+			out.Int64(int64(*in.Version))
+		}
+	}
+	{
+		const prefix string = ",\"_seq_no\":"
+		out.RawString(prefix)
+		if in.SeqNo == nil {
+			out.RawString("null")
+		} else {
+			out.Int64(int64(*in.SeqNo))
+		}
+	}
+	{
+		const prefix string = ",\"_primary_term\":"
+		out.RawString(prefix)
+		if in.PrimaryTerm == nil {
+			out.RawString("null")
+		} else {
+			out.Int64(int64(*in.PrimaryTerm))
+		}
+	}
+	if len(in.Source) != 0 {
+		const prefix string = ",\"_source\":"
+		out.RawString(prefix)
+		out.Raw((in.Source).MarshalJSON())
+	}
+	if in.Found {
+		const prefix string = ",\"found\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Found))
+	}
+	if len(in.Fields) != 0 {
+		const prefix string = ",\"fields\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v2First := true
+			for v2Name, v2Value := range in.Fields {
+				if v2First {
+					v2First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v2Name))
+				out.RawByte(':')
+				if m, ok := v2Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v2Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v2Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.Error != nil {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		easyjson89020eaEncodeGithubComFacertElasticV71(out, *in.Error)
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GetResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson89020eaEncodeGithubComFacertElasticV7(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GetResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson89020eaDecodeGithubComFacertElasticV7(l, v)
+}
+func easyjson89020eaDecodeGithubComFacertElasticV71(in *jlexer.Lexer, out *ErrorDetails) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "resource.type":
+			out.ResourceType = string(in.String())
+		case "resource.id":
+			out.ResourceId = string(in.String())
+		case "index":
+			out.Index = string(in.String())
+		case "phase":
+			out.Phase = string(in.String())
+		case "grouped":
+			out.Grouped = bool(in.Bool())
+		case "caused_by":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.CausedBy = make(map[string]interface{})
+				} else {
+					out.CausedBy = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v3 interface{}
+					if m, ok := v3.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v3.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v3 = in.Interface()
+					}
+					(out.CausedBy)[key] = v3
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "root_cause":
+			if in.IsNull() {
+				in.Skip()
+				out.RootCause = nil
+			} else {
+				in.Delim('[')
+				if out.RootCause == nil {
+					if !in.IsDelim(']') {
+						out.RootCause = make([]*ErrorDetails, 0, 8)
+					} else {
+						out.RootCause = []*ErrorDetails{}
+					}
+				} else {
+					out.RootCause = (out.RootCause)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 *ErrorDetails
+					if in.IsNull() {
+						in.Skip()
+						v4 = nil
+					} else {
+						if v4 == nil {
+							v4 = new(ErrorDetails)
+						}
+						easyjson89020eaDecodeGithubComFacertElasticV71(in, v4)
+					}
+					out.RootCause = append(out.RootCause, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "failed_shards":
+			if in.IsNull() {
+				in.Skip()
+				out.FailedShards = nil
+			} else {
+				in.Delim('[')
+				if out.FailedShards == nil {
+					if !in.IsDelim(']') {
+						out.FailedShards = make([]map[string]interface{}, 0, 8)
+					} else {
+						out.FailedShards = []map[string]interface{}{}
+					}
+				} else {
+					out.FailedShards = (out.FailedShards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 map[string]interface{}
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v5 = make(map[string]interface{})
+						} else {
+							v5 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v6 interface{}
+							if m, ok := v6.(easyjson.Unmarshaler); ok {
+								m.UnmarshalEasyJSON(in)
+							} else if m, ok := v6.(json.Unmarshaler); ok {
+								_ = m.UnmarshalJSON(in.Raw())
+							} else {
+								v6 = in.Interface()
+							}
+							(v5)[key] = v6
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					out.FailedShards = append(out.FailedShards, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson89020eaEncodeGithubComFacertElasticV71(out *jwriter.Writer, in ErrorDetails) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	if in.ResourceType != "" {
+		const prefix string = ",\"resource.type\":"
+		out.RawString(prefix)
+		out.String(string(in.ResourceType))
+	}
+	if in.ResourceId != "" {
+		const prefix string = ",\"resource.id\":"
+		out.RawString(prefix)
+		out.String(string(in.ResourceId))
+	}
+	if in.Index != "" {
+		const prefix string = ",\"index\":"
+		out.RawString(prefix)
+		out.String(string(in.Index))
+	}
+	if in.Phase != "" {
+		const prefix string = ",\"phase\":"
+		out.RawString(prefix)
+		out.String(string(in.Phase))
+	}
+	if in.Grouped {
+		const prefix string = ",\"grouped\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Grouped))
+	}
+	if len(in.CausedBy) != 0 {
+		const prefix string = ",\"caused_by\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v7First := true
+			for v7Name, v7Value := range in.CausedBy {
+				if v7First {
+					v7First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v7Name))
+				out.RawByte(':')
+				if m, ok := v7Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v7Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v7Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.RootCause) != 0 {
+		const prefix string = ",\"root_cause\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.RootCause {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				if v9 == nil {
+					out.RawString("null")
+				} else {
+					easyjson89020eaEncodeGithubComFacertElasticV71(out, *v9)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.FailedShards) != 0 {
+		const prefix string = ",\"failed_shards\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v10, v11 := range in.FailedShards {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				if v11 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+					out.RawString(`null`)
+				} else {
+					out.RawByte('{')
+					v12First := true
+					for v12Name, v12Value := range v11 {
+						if v12First {
+							v12First = false
+						} else {
+							out.RawByte(',')
+						}
+						out.String(string(v12Name))
+						out.RawByte(':')
+						if m, ok := v12Value.(easyjson.Marshaler); ok {
+							m.MarshalEasyJSON(out)
+						} else if m, ok := v12Value.(json.Marshaler); ok {
+							out.Raw(m.MarshalJSON())
+						} else {
+							out.Raw(json.Marshal(v12Value))
+						}
+					}
+					out.RawByte('}')
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
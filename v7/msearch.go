@@ -6,7 +6,6 @@ package elastic
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -78,11 +77,11 @@ func (s *MultiSearchService) Do(ctx context.Context) (*MultiSearchResult, error)
 			sr = sr.Index(s.indices...)
 		}
 
-		header, err := json.Marshal(sr.header())
+		header, err := s.client.encoder.Encode(sr.header())
 		if err != nil {
 			return nil, err
 		}
-		body, err := sr.Body()
+		body, err := sr.body(s.client.encoder)
 		if err != nil {
 			return nil, err
 		}
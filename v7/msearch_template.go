@@ -0,0 +1,298 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/facert/elastic/v7/uritemplates"
+)
+
+// MultiSearchTemplateService runs several search templates in one
+// roundtrip.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/multi-search-template.html
+// for details.
+type MultiSearchTemplateService struct {
+	client                *Client
+	pretty                bool
+	requests              []*SearchTemplateRequest
+	index                 []string
+	searchType            string
+	maxConcurrentSearches *int
+}
+
+// NewMultiSearchTemplateService creates a new MultiSearchTemplateService.
+func NewMultiSearchTemplateService(client *Client) *MultiSearchTemplateService {
+	return &MultiSearchTemplateService{
+		client: client,
+	}
+}
+
+// Add adds search template requests.
+func (s *MultiSearchTemplateService) Add(requests ...*SearchTemplateRequest) *MultiSearchTemplateService {
+	s.requests = append(s.requests, requests...)
+	return s
+}
+
+// Index sets the default indices for requests that do not specify
+// their own indices.
+func (s *MultiSearchTemplateService) Index(index ...string) *MultiSearchTemplateService {
+	s.index = append(s.index, index...)
+	return s
+}
+
+// SearchType sets the search operation type. Valid values are:
+// "dfs_query_then_fetch" and "query_then_fetch".
+func (s *MultiSearchTemplateService) SearchType(searchType string) *MultiSearchTemplateService {
+	s.searchType = searchType
+	return s
+}
+
+// MaxConcurrentSearches sets the maximum number of searches to execute
+// in parallel.
+func (s *MultiSearchTemplateService) MaxConcurrentSearches(max int) *MultiSearchTemplateService {
+	s.maxConcurrentSearches = &max
+	return s
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *MultiSearchTemplateService) Pretty(pretty bool) *MultiSearchTemplateService {
+	s.pretty = pretty
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *MultiSearchTemplateService) buildURL() (string, url.Values, error) {
+	var err error
+	var path string
+
+	if len(s.index) > 0 {
+		path, err = uritemplates.Expand("/{index}/_msearch/template", map[string]string{
+			"index": strings.Join(s.index, ","),
+		})
+	} else {
+		path = "/_msearch/template"
+	}
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.searchType != "" {
+		params.Set("search_type", s.searchType)
+	}
+	if v := s.maxConcurrentSearches; v != nil {
+		params.Set("max_concurrent_searches", fmt.Sprintf("%v", *v))
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *MultiSearchTemplateService) Validate() error {
+	var invalid []string
+	if len(s.requests) == 0 {
+		invalid = append(invalid, "Requests")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	for _, r := range s.requests {
+		if err := r.template.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *MultiSearchTemplateService) Do(ctx context.Context) (*MultiSearchResult, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Set body
+	var lines []string
+	for _, r := range s.requests {
+		header, err := s.client.encoder.Encode(r.header())
+		if err != nil {
+			return nil, err
+		}
+		body, err := s.client.encoder.Encode(r.template.body())
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, string(header))
+		lines = append(lines, string(body))
+	}
+	body := strings.Join(lines, "\n") + "\n" // add trailing \n
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method: "POST",
+		Path:   path,
+		Params: params,
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(MultiSearchResult)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// -- Request --
+
+// SearchTemplateRequest is a single search template request of a
+// MultiSearchTemplateService.
+type SearchTemplateRequest struct {
+	index             []string
+	template          searchTemplate
+	searchType        string
+	routing           string
+	preference        string
+	ignoreUnavailable *bool
+	allowNoIndices    *bool
+	expandWildcards   string
+}
+
+// NewSearchTemplateRequest creates a new SearchTemplateRequest.
+func NewSearchTemplateRequest() *SearchTemplateRequest {
+	return &SearchTemplateRequest{}
+}
+
+// Index sets the names of the indices to search.
+func (r *SearchTemplateRequest) Index(index ...string) *SearchTemplateRequest {
+	r.index = append(r.index, index...)
+	return r
+}
+
+// Id sets the id of a stored template.
+func (r *SearchTemplateRequest) Id(id string) *SearchTemplateRequest {
+	r.template.id = id
+	return r
+}
+
+// Source sets an inline template, either as a string or as a
+// serializable object.
+func (r *SearchTemplateRequest) Source(source interface{}) *SearchTemplateRequest {
+	r.template.source = source
+	return r
+}
+
+// Params sets the parameters of the template, e.g. a map or a struct
+// that serializes into a JSON object.
+func (r *SearchTemplateRequest) Params(params interface{}) *SearchTemplateRequest {
+	r.template.params = params
+	return r
+}
+
+// Param adds a parameter of the template. It replaces parameters set
+// via Params that are not a map[string]interface{}.
+func (r *SearchTemplateRequest) Param(name string, value interface{}) *SearchTemplateRequest {
+	r.template.param(name, value)
+	return r
+}
+
+// Explain indicates whether to return an explanation of the score
+// for each hit.
+func (r *SearchTemplateRequest) Explain(explain bool) *SearchTemplateRequest {
+	r.template.explain = &explain
+	return r
+}
+
+// Profile indicates whether to profile the query execution.
+func (r *SearchTemplateRequest) Profile(profile bool) *SearchTemplateRequest {
+	r.template.profile = &profile
+	return r
+}
+
+// SearchType sets the search operation type. Valid values are:
+// "dfs_query_then_fetch" and "query_then_fetch".
+func (r *SearchTemplateRequest) SearchType(searchType string) *SearchTemplateRequest {
+	r.searchType = searchType
+	return r
+}
+
+// Routing is a list of specific routing values to control the shards
+// the search will be executed on.
+func (r *SearchTemplateRequest) Routing(routings ...string) *SearchTemplateRequest {
+	r.routing = strings.Join(routings, ",")
+	return r
+}
+
+// Preference sets the preference to execute the search.
+func (r *SearchTemplateRequest) Preference(preference string) *SearchTemplateRequest {
+	r.preference = preference
+	return r
+}
+
+// IgnoreUnavailable indicates whether the specified concrete indices
+// should be ignored when unavailable (missing or closed).
+func (r *SearchTemplateRequest) IgnoreUnavailable(ignoreUnavailable bool) *SearchTemplateRequest {
+	r.ignoreUnavailable = &ignoreUnavailable
+	return r
+}
+
+// AllowNoIndices indicates whether to ignore if a wildcard indices
+// expression resolves into no concrete indices.
+func (r *SearchTemplateRequest) AllowNoIndices(allowNoIndices bool) *SearchTemplateRequest {
+	r.allowNoIndices = &allowNoIndices
+	return r
+}
+
+// ExpandWildcards indicates whether to expand wildcard expression to
+// concrete indices that are open, closed or both.
+func (r *SearchTemplateRequest) ExpandWildcards(expandWildcards string) *SearchTemplateRequest {
+	r.expandWildcards = expandWildcards
+	return r
+}
+
+// header returns the header line of the request in a multi search
+// template request.
+func (r *SearchTemplateRequest) header() interface{} {
+	h := make(map[string]interface{})
+	if len(r.index) > 0 {
+		h["index"] = strings.Join(r.index, ",")
+	}
+	if r.searchType != "" {
+		h["search_type"] = r.searchType
+	}
+	if r.routing != "" {
+		h["routing"] = r.routing
+	}
+	if r.preference != "" {
+		h["preference"] = r.preference
+	}
+	if r.ignoreUnavailable != nil {
+		h["ignore_unavailable"] = *r.ignoreUnavailable
+	}
+	if r.allowNoIndices != nil {
+		h["allow_no_indices"] = *r.allowNoIndices
+	}
+	if r.expandWildcards != "" {
+		h["expand_wildcards"] = r.expandWildcards
+	}
+	return h
+}
@@ -0,0 +1,69 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMultiSearchTemplate(t *testing.T) {
+	rec := &templateTestServer{
+		response: `{"responses":[{"hits":{"total":{"value":2,"relation":"eq"},"hits":[]}},{"hits":{"total":{"value":3,"relation":"eq"},"hits":[]}}]}`,
+	}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.MultiSearchTemplate().
+		Index("products").
+		MaxConcurrentSearches(2).
+		Add(
+			NewSearchTemplateRequest().Id("product-search").Param("text", "phone"),
+			NewSearchTemplateRequest().Index("archive").Preference("_local").Source(`{"query":{"match_all":{}}}`),
+		).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(res.Responses); want != have {
+		t.Fatalf("expected %d responses; got: %d", want, have)
+	}
+	if want, have := int64(3), res.Responses[1].TotalHits(); want != have {
+		t.Errorf("expected %d hits; got: %d", want, have)
+	}
+	if want, have := "/products/_msearch/template", rec.path; want != have {
+		t.Errorf("expected path %q; got: %q", want, have)
+	}
+	if want, have := "max_concurrent_searches=2", rec.query; want != have {
+		t.Errorf("expected query %q; got: %q", want, have)
+	}
+	want := `{}
+{"id":"product-search","params":{"text":"phone"}}
+{"index":"archive","preference":"_local"}
+{"source":"{\"query\":{\"match_all\":{}}}"}
+`
+	if have := rec.body; want != have {
+		t.Errorf("expected body\n%s\ngot\n%s", want, have)
+	}
+}
+
+func TestMultiSearchTemplateValidate(t *testing.T) {
+	client, err := NewSimpleClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.MultiSearchTemplate().Validate(); err == nil {
+		t.Fatal("expected error without requests")
+	}
+	if err := client.MultiSearchTemplate().Add(NewSearchTemplateRequest()).Validate(); err == nil {
+		t.Fatal("expected error for request without Id or Source")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ClosePointInTimeService closes a point in time.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/point-in-time-api.html
+// for details.
+type ClosePointInTimeService struct {
+	client  *Client
+	pretty  bool
+	headers http.Header
+	id      string
+}
+
+// NewClosePointInTimeService creates a new ClosePointInTimeService.
+func NewClosePointInTimeService(client *Client) *ClosePointInTimeService {
+	return &ClosePointInTimeService{
+		client: client,
+	}
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *ClosePointInTimeService) Pretty(pretty bool) *ClosePointInTimeService {
+	s.pretty = pretty
+	return s
+}
+
+// Header adds a header to the request.
+func (s *ClosePointInTimeService) Header(name string, value string) *ClosePointInTimeService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// ID to close.
+func (s *ClosePointInTimeService) ID(id string) *ClosePointInTimeService {
+	s.id = id
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *ClosePointInTimeService) buildURL() (string, url.Values, error) {
+	// Build URL
+	path := "/_pit"
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *ClosePointInTimeService) Validate() error {
+	var invalid []string
+	if s.id == "" {
+		invalid = append(invalid, "ID")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *ClosePointInTimeService) Do(ctx context.Context) (*ClosePointInTimeResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup HTTP request body
+	body := map[string]interface{}{
+		"id": s.id,
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "DELETE",
+		Path:    path,
+		Params:  params,
+		Body:    body,
+		Headers: s.headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(ClosePointInTimeResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ClosePointInTimeResponse is the result of ClosePointInTimeService.Do.
+type ClosePointInTimeResponse struct {
+	Succeeded bool `json:"succeeded"`
+	NumFreed  int  `json:"num_freed"`
+}
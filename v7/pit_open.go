@@ -0,0 +1,177 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/facert/elastic/v7/uritemplates"
+)
+
+// OpenPointInTimeService opens a point in time that can be used in
+// subsequent searches.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/point-in-time-api.html
+// for details.
+type OpenPointInTimeService struct {
+	client            *Client
+	pretty            bool
+	headers           http.Header
+	index             []string
+	preference        string
+	routing           string
+	ignoreUnavailable *bool
+	expandWildcards   string
+	keepAlive         string
+}
+
+// NewOpenPointInTimeService creates a new OpenPointInTimeService.
+func NewOpenPointInTimeService(client *Client) *OpenPointInTimeService {
+	return &OpenPointInTimeService{
+		client: client,
+	}
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *OpenPointInTimeService) Pretty(pretty bool) *OpenPointInTimeService {
+	s.pretty = pretty
+	return s
+}
+
+// Header adds a header to the request.
+func (s *OpenPointInTimeService) Header(name string, value string) *OpenPointInTimeService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Index is a list of index names to open the point in time for.
+func (s *OpenPointInTimeService) Index(index ...string) *OpenPointInTimeService {
+	s.index = append(s.index, index...)
+	return s
+}
+
+// Preference specifies the node or shard the operation should be performed on.
+func (s *OpenPointInTimeService) Preference(preference string) *OpenPointInTimeService {
+	s.preference = preference
+	return s
+}
+
+// Routing is a specific routing value.
+func (s *OpenPointInTimeService) Routing(routing string) *OpenPointInTimeService {
+	s.routing = routing
+	return s
+}
+
+// IgnoreUnavailable indicates whether specified concrete indices should
+// be ignored when unavailable (missing or closed).
+func (s *OpenPointInTimeService) IgnoreUnavailable(ignoreUnavailable bool) *OpenPointInTimeService {
+	s.ignoreUnavailable = &ignoreUnavailable
+	return s
+}
+
+// ExpandWildcards indicates whether to expand wildcard expression to
+// concrete indices that are open, closed or both.
+func (s *OpenPointInTimeService) ExpandWildcards(expandWildcards string) *OpenPointInTimeService {
+	s.expandWildcards = expandWildcards
+	return s
+}
+
+// KeepAlive indicates the time to keep the point in time alive, e.g. "1m".
+func (s *OpenPointInTimeService) KeepAlive(keepAlive string) *OpenPointInTimeService {
+	s.keepAlive = keepAlive
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *OpenPointInTimeService) buildURL() (string, url.Values, error) {
+	// Build URL
+	path, err := uritemplates.Expand("/{index}/_pit", map[string]string{
+		"index": strings.Join(s.index, ","),
+	})
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.preference != "" {
+		params.Set("preference", s.preference)
+	}
+	if s.routing != "" {
+		params.Set("routing", s.routing)
+	}
+	if s.ignoreUnavailable != nil {
+		params.Set("ignore_unavailable", fmt.Sprintf("%v", *s.ignoreUnavailable))
+	}
+	if s.expandWildcards != "" {
+		params.Set("expand_wildcards", s.expandWildcards)
+	}
+	if s.keepAlive != "" {
+		params.Set("keep_alive", s.keepAlive)
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *OpenPointInTimeService) Validate() error {
+	var invalid []string
+	if len(s.index) == 0 {
+		invalid = append(invalid, "Index")
+	}
+	if s.keepAlive == "" {
+		invalid = append(invalid, "KeepAlive")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *OpenPointInTimeService) Do(ctx context.Context) (*OpenPointInTimeResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "POST",
+		Path:    path,
+		Params:  params,
+		Headers: s.headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(OpenPointInTimeResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// OpenPointInTimeResponse is the result of OpenPointInTimeService.Do.
+type OpenPointInTimeResponse struct {
+	Id string `json:"id"`
+}
@@ -0,0 +1,61 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"testing"
+)
+
+func TestOpenPointInTimeBuildURL(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		Indices   []string
+		KeepAlive string
+		Expected  string
+	}{
+		{
+			[]string{"index1"},
+			"1m",
+			"/index1/_pit?keep_alive=1m",
+		},
+		{
+			[]string{"index1", "index2"},
+			"5m",
+			"/index1%2Cindex2/_pit?keep_alive=5m",
+		},
+	}
+
+	for i, test := range tests {
+		path, params, err := client.OpenPointInTime(test.Indices...).KeepAlive(test.KeepAlive).buildURL()
+		if err != nil {
+			t.Errorf("case #%d: %v", i+1, err)
+			continue
+		}
+		if got := path + "?" + params.Encode(); got != test.Expected {
+			t.Errorf("case #%d: expected %q; got: %q", i+1, test.Expected, got)
+		}
+	}
+}
+
+func TestPointInTimeValidate(t *testing.T) {
+	client := setupTestClient(t)
+
+	if err := client.OpenPointInTime().KeepAlive("1m").Validate(); err == nil {
+		t.Error("expected error for missing index")
+	}
+	if err := client.OpenPointInTime("index1").Validate(); err == nil {
+		t.Error("expected error for missing keep alive")
+	}
+	if err := client.OpenPointInTime("index1").KeepAlive("1m").Validate(); err != nil {
+		t.Errorf("expected no error; got: %v", err)
+	}
+	if err := client.ClosePointInTime("").Validate(); err == nil {
+		t.Error("expected error for missing id")
+	}
+	if err := client.ClosePointInTime("pit_id").Validate(); err != nil {
+		t.Errorf("expected no error; got: %v", err)
+	}
+}
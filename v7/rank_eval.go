@@ -0,0 +1,398 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/facert/elastic/v7/uritemplates"
+)
+
+// RankEvalService evaluates the quality of ranked search results over
+// a set of typical search queries. For each query, it compares the hits
+// with a list of manually rated documents and computes a metric like
+// precision or discounted cumulative gain.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/search-rank-eval.html
+// for details.
+type RankEvalService struct {
+	client                *Client
+	pretty                bool
+	index                 []string
+	requests              []*RankEvalRequest
+	metric                RankEvalMetric
+	templates             []*RankEvalTemplate
+	maxConcurrentSearches *int
+	searchType            string
+	ignoreUnavailable     *bool
+	allowNoIndices        *bool
+	expandWildcards       string
+}
+
+// NewRankEvalService creates a new RankEvalService.
+func NewRankEvalService(client *Client) *RankEvalService {
+	return &RankEvalService{
+		client: client,
+	}
+}
+
+// Index sets the names of the indices to run the queries on.
+func (s *RankEvalService) Index(index ...string) *RankEvalService {
+	s.index = append(s.index, index...)
+	return s
+}
+
+// Add adds rated requests to evaluate.
+func (s *RankEvalService) Add(requests ...*RankEvalRequest) *RankEvalService {
+	s.requests = append(s.requests, requests...)
+	return s
+}
+
+// Metric sets the metric to evaluate the requests with,
+// e.g. NewRankEvalPrecision. It defaults to precision.
+func (s *RankEvalService) Metric(metric RankEvalMetric) *RankEvalService {
+	s.metric = metric
+	return s
+}
+
+// Template adds a search template that requests can refer to by id.
+func (s *RankEvalService) Template(templates ...*RankEvalTemplate) *RankEvalService {
+	s.templates = append(s.templates, templates...)
+	return s
+}
+
+// MaxConcurrentSearches sets the maximum number of queries that are
+// run concurrently. It defaults to 10.
+func (s *RankEvalService) MaxConcurrentSearches(max int) *RankEvalService {
+	s.maxConcurrentSearches = &max
+	return s
+}
+
+// SearchType sets the search operation type. Valid values are:
+// "dfs_query_then_fetch" and "query_then_fetch".
+func (s *RankEvalService) SearchType(searchType string) *RankEvalService {
+	s.searchType = searchType
+	return s
+}
+
+// IgnoreUnavailable indicates whether the specified concrete indices
+// should be ignored when unavailable (missing or closed).
+func (s *RankEvalService) IgnoreUnavailable(ignoreUnavailable bool) *RankEvalService {
+	s.ignoreUnavailable = &ignoreUnavailable
+	return s
+}
+
+// AllowNoIndices indicates whether to ignore if a wildcard indices
+// expression resolves into no concrete indices.
+func (s *RankEvalService) AllowNoIndices(allowNoIndices bool) *RankEvalService {
+	s.allowNoIndices = &allowNoIndices
+	return s
+}
+
+// ExpandWildcards indicates whether to expand wildcard expression to
+// concrete indices that are open, closed or both.
+func (s *RankEvalService) ExpandWildcards(expandWildcards string) *RankEvalService {
+	s.expandWildcards = expandWildcards
+	return s
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *RankEvalService) Pretty(pretty bool) *RankEvalService {
+	s.pretty = pretty
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *RankEvalService) buildURL() (string, url.Values, error) {
+	var err error
+	var path string
+
+	if len(s.index) > 0 {
+		path, err = uritemplates.Expand("/{index}/_rank_eval", map[string]string{
+			"index": strings.Join(s.index, ","),
+		})
+	} else {
+		path = "/_rank_eval"
+	}
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.searchType != "" {
+		params.Set("search_type", s.searchType)
+	}
+	if s.ignoreUnavailable != nil {
+		params.Set("ignore_unavailable", fmt.Sprintf("%v", *s.ignoreUnavailable))
+	}
+	if s.allowNoIndices != nil {
+		params.Set("allow_no_indices", fmt.Sprintf("%v", *s.allowNoIndices))
+	}
+	if s.expandWildcards != "" {
+		params.Set("expand_wildcards", s.expandWildcards)
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *RankEvalService) Validate() error {
+	var invalid []string
+	if len(s.requests) == 0 {
+		invalid = append(invalid, "Requests")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Source returns the body of the request.
+func (s *RankEvalService) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+
+	requests := make([]interface{}, 0, len(s.requests))
+	for _, r := range s.requests {
+		src, err := r.Source()
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, src)
+	}
+	source["requests"] = requests
+
+	if s.metric != nil {
+		src, err := s.metric.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["metric"] = map[string]interface{}{
+			s.metric.Name(): src,
+		}
+	}
+	if len(s.templates) > 0 {
+		templates := make([]interface{}, 0, len(s.templates))
+		for _, t := range s.templates {
+			src, err := t.Source()
+			if err != nil {
+				return nil, err
+			}
+			templates = append(templates, src)
+		}
+		source["templates"] = templates
+	}
+	if s.maxConcurrentSearches != nil {
+		source["max_concurrent_searches"] = *s.maxConcurrentSearches
+	}
+	return source, nil
+}
+
+// Do executes the operation.
+func (s *RankEvalService) Do(ctx context.Context) (*RankEvalResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup HTTP request body
+	body, err := s.Source()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method: "POST",
+		Path:   path,
+		Params: params,
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(RankEvalResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// -- Request --
+
+// RankEvalRequest is a search request with the ratings of the documents
+// it is expected to return. The request is either a SearchSource or
+// refers to a RankEvalTemplate.
+type RankEvalRequest struct {
+	id           string
+	searchSource *SearchSource
+	templateId   string
+	params       interface{}
+	ratings      []RankEvalRatedDocument
+}
+
+// NewRankEvalRequest creates a new RankEvalRequest with the given id.
+func NewRankEvalRequest(id string) *RankEvalRequest {
+	return &RankEvalRequest{id: id}
+}
+
+// SearchSource sets the search request to evaluate.
+func (r *RankEvalRequest) SearchSource(searchSource *SearchSource) *RankEvalRequest {
+	r.searchSource = searchSource
+	return r
+}
+
+// Query sets the query of the search request to evaluate.
+func (r *RankEvalRequest) Query(query Query) *RankEvalRequest {
+	return r.SearchSource(NewSearchSource().Query(query))
+}
+
+// TemplateId sets the id of the RankEvalTemplate to build the search
+// request from.
+func (r *RankEvalRequest) TemplateId(templateId string) *RankEvalRequest {
+	r.templateId = templateId
+	return r
+}
+
+// Params sets the parameters of the template, e.g. a map or a struct
+// that serializes into a JSON object.
+func (r *RankEvalRequest) Params(params interface{}) *RankEvalRequest {
+	r.params = params
+	return r
+}
+
+// Rating adds the rating of a document.
+func (r *RankEvalRequest) Rating(index, id string, rating int) *RankEvalRequest {
+	r.ratings = append(r.ratings, RankEvalRatedDocument{Index: index, Id: id, Rating: rating})
+	return r
+}
+
+// Ratings adds the ratings of documents.
+func (r *RankEvalRequest) Ratings(ratings ...RankEvalRatedDocument) *RankEvalRequest {
+	r.ratings = append(r.ratings, ratings...)
+	return r
+}
+
+// Source returns JSON for the request.
+func (r *RankEvalRequest) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	source["id"] = r.id
+	if r.searchSource != nil {
+		src, err := r.searchSource.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["request"] = src
+	}
+	if r.templateId != "" {
+		source["template_id"] = r.templateId
+	}
+	if r.params != nil {
+		source["params"] = r.params
+	}
+	ratings := r.ratings
+	if ratings == nil {
+		ratings = []RankEvalRatedDocument{}
+	}
+	source["ratings"] = ratings
+	return source, nil
+}
+
+// RankEvalRatedDocument is the rating of a document for a RankEvalRequest.
+// Higher ratings are more relevant.
+type RankEvalRatedDocument struct {
+	Index  string `json:"_index"`
+	Id     string `json:"_id"`
+	Rating int    `json:"rating"`
+}
+
+// -- Template --
+
+// RankEvalTemplate is a search template that RankEvalRequests can refer
+// to by id, to avoid repeating the same search request with different
+// parameters.
+type RankEvalTemplate struct {
+	id     string
+	source interface{}
+}
+
+// NewRankEvalTemplate creates a new template with the given id and a
+// mustache template source, either as a string or as a serializable
+// object.
+func NewRankEvalTemplate(id string, source interface{}) *RankEvalTemplate {
+	return &RankEvalTemplate{id: id, source: source}
+}
+
+// Source returns JSON for the template.
+func (t *RankEvalTemplate) Source() (interface{}, error) {
+	return map[string]interface{}{
+		"id": t.id,
+		"template": map[string]interface{}{
+			"source": t.source,
+		},
+	}, nil
+}
+
+// -- Response --
+
+// RankEvalResponse is the response of RankEvalService.
+type RankEvalResponse struct {
+	MetricScore float64                          `json:"metric_score"`
+	Details     map[string]*RankEvalQueryDetails `json:"details,omitempty"`
+	Failures    map[string]*RankEvalFailure      `json:"failures,omitempty"`
+}
+
+// RankEvalQueryDetails are the results of a single RankEvalRequest.
+type RankEvalQueryDetails struct {
+	MetricScore   float64                           `json:"metric_score"`
+	UnratedDocs   []*RankEvalDocument               `json:"unrated_docs,omitempty"`
+	Hits          []*RankEvalHit                    `json:"hits,omitempty"`
+	MetricDetails map[string]*RankEvalMetricDetails `json:"metric_details,omitempty"`
+}
+
+// RankEvalDocument identifies a document, e.g. a hit without a rating.
+type RankEvalDocument struct {
+	Index string `json:"_index"`
+	Id    string `json:"_id"`
+}
+
+// RankEvalHit is a hit of a RankEvalRequest with its rating. Rating is
+// nil for unrated hits.
+type RankEvalHit struct {
+	Hit    *SearchHit `json:"hit"`
+	Rating *int       `json:"rating"`
+}
+
+// RankEvalMetricDetails are the metric specific details of a
+// RankEvalRequest, keyed by the name of the metric, e.g. "precision".
+// Only the fields of the metric are set.
+type RankEvalMetricDetails struct {
+	RelevantDocsRetrieved *int64   `json:"relevant_docs_retrieved,omitempty"` // precision and recall
+	DocsRetrieved         *int64   `json:"docs_retrieved,omitempty"`          // precision
+	RelevantDocs          *int64   `json:"relevant_docs,omitempty"`           // recall
+	FirstRelevant         *int64   `json:"first_relevant,omitempty"`          // mean_reciprocal_rank
+	DCG                   *float64 `json:"dcg,omitempty"`                     // dcg
+	IdealDCG              *float64 `json:"ideal_dcg,omitempty"`               // dcg
+	NormalizedDCG         *float64 `json:"normalized_dcg,omitempty"`          // dcg
+	UnratedDocs           *int64   `json:"unrated_docs,omitempty"`            // dcg and expected_reciprocal_rank
+}
+
+// RankEvalFailure is the error of a RankEvalRequest that failed.
+type RankEvalFailure struct {
+	Error *ErrorDetails `json:"error,omitempty"`
+}
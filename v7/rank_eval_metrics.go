@@ -0,0 +1,240 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// RankEvalMetric is a metric of RankEvalService.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/search-rank-eval.html#_available_evaluation_metrics
+// for details.
+type RankEvalMetric interface {
+	Name() string
+	Source() (interface{}, error)
+}
+
+// -- Precision --
+
+// RankEvalPrecision measures the fraction of relevant documents in the
+// top k hits (precision at k).
+type RankEvalPrecision struct {
+	k                       *int
+	relevantRatingThreshold *int
+	ignoreUnlabeled         *bool
+}
+
+// NewRankEvalPrecision creates a new precision metric.
+func NewRankEvalPrecision() *RankEvalPrecision {
+	return &RankEvalPrecision{}
+}
+
+// K sets the number of top hits to evaluate. It defaults to 10.
+func (m *RankEvalPrecision) K(k int) *RankEvalPrecision {
+	m.k = &k
+	return m
+}
+
+// RelevantRatingThreshold sets the rating from which a document is
+// considered relevant. It defaults to 1.
+func (m *RankEvalPrecision) RelevantRatingThreshold(threshold int) *RankEvalPrecision {
+	m.relevantRatingThreshold = &threshold
+	return m
+}
+
+// IgnoreUnlabeled specifies whether to ignore unrated documents instead
+// of counting them as irrelevant. It defaults to false.
+func (m *RankEvalPrecision) IgnoreUnlabeled(ignoreUnlabeled bool) *RankEvalPrecision {
+	m.ignoreUnlabeled = &ignoreUnlabeled
+	return m
+}
+
+// Name returns the name of the metric.
+func (m *RankEvalPrecision) Name() string {
+	return "precision"
+}
+
+// Source returns JSON for the metric.
+func (m *RankEvalPrecision) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	if m.k != nil {
+		source["k"] = *m.k
+	}
+	if m.relevantRatingThreshold != nil {
+		source["relevant_rating_threshold"] = *m.relevantRatingThreshold
+	}
+	if m.ignoreUnlabeled != nil {
+		source["ignore_unlabeled"] = *m.ignoreUnlabeled
+	}
+	return source, nil
+}
+
+// -- Recall --
+
+// RankEvalRecall measures the fraction of all relevant documents that
+// are in the top k hits (recall at k).
+type RankEvalRecall struct {
+	k                       *int
+	relevantRatingThreshold *int
+}
+
+// NewRankEvalRecall creates a new recall metric.
+func NewRankEvalRecall() *RankEvalRecall {
+	return &RankEvalRecall{}
+}
+
+// K sets the number of top hits to evaluate. It defaults to 10.
+func (m *RankEvalRecall) K(k int) *RankEvalRecall {
+	m.k = &k
+	return m
+}
+
+// RelevantRatingThreshold sets the rating from which a document is
+// considered relevant. It defaults to 1.
+func (m *RankEvalRecall) RelevantRatingThreshold(threshold int) *RankEvalRecall {
+	m.relevantRatingThreshold = &threshold
+	return m
+}
+
+// Name returns the name of the metric.
+func (m *RankEvalRecall) Name() string {
+	return "recall"
+}
+
+// Source returns JSON for the metric.
+func (m *RankEvalRecall) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	if m.k != nil {
+		source["k"] = *m.k
+	}
+	if m.relevantRatingThreshold != nil {
+		source["relevant_rating_threshold"] = *m.relevantRatingThreshold
+	}
+	return source, nil
+}
+
+// -- Mean reciprocal rank --
+
+// RankEvalMeanReciprocalRank computes the reciprocal of the rank of the
+// first relevant document in the top k hits, averaged over all requests.
+type RankEvalMeanReciprocalRank struct {
+	k                       *int
+	relevantRatingThreshold *int
+}
+
+// NewRankEvalMeanReciprocalRank creates a new mean reciprocal rank metric.
+func NewRankEvalMeanReciprocalRank() *RankEvalMeanReciprocalRank {
+	return &RankEvalMeanReciprocalRank{}
+}
+
+// K sets the number of top hits to evaluate. It defaults to 10.
+func (m *RankEvalMeanReciprocalRank) K(k int) *RankEvalMeanReciprocalRank {
+	m.k = &k
+	return m
+}
+
+// RelevantRatingThreshold sets the rating from which a document is
+// considered relevant. It defaults to 1.
+func (m *RankEvalMeanReciprocalRank) RelevantRatingThreshold(threshold int) *RankEvalMeanReciprocalRank {
+	m.relevantRatingThreshold = &threshold
+	return m
+}
+
+// Name returns the name of the metric.
+func (m *RankEvalMeanReciprocalRank) Name() string {
+	return "mean_reciprocal_rank"
+}
+
+// Source returns JSON for the metric.
+func (m *RankEvalMeanReciprocalRank) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	if m.k != nil {
+		source["k"] = *m.k
+	}
+	if m.relevantRatingThreshold != nil {
+		source["relevant_rating_threshold"] = *m.relevantRatingThreshold
+	}
+	return source, nil
+}
+
+// -- Discounted cumulative gain --
+
+// RankEvalDCG computes the discounted cumulative gain of the top k hits,
+// which takes both the rating and the position of documents into
+// account. Use Normalize to compute the normalized DCG (nDCG).
+type RankEvalDCG struct {
+	k         *int
+	normalize *bool
+}
+
+// NewRankEvalDCG creates a new discounted cumulative gain metric.
+func NewRankEvalDCG() *RankEvalDCG {
+	return &RankEvalDCG{}
+}
+
+// K sets the number of top hits to evaluate. It defaults to 10.
+func (m *RankEvalDCG) K(k int) *RankEvalDCG {
+	m.k = &k
+	return m
+}
+
+// Normalize specifies whether to compute the normalized DCG (nDCG).
+// It defaults to false.
+func (m *RankEvalDCG) Normalize(normalize bool) *RankEvalDCG {
+	m.normalize = &normalize
+	return m
+}
+
+// Name returns the name of the metric.
+func (m *RankEvalDCG) Name() string {
+	return "dcg"
+}
+
+// Source returns JSON for the metric.
+func (m *RankEvalDCG) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	if m.k != nil {
+		source["k"] = *m.k
+	}
+	if m.normalize != nil {
+		source["normalize"] = *m.normalize
+	}
+	return source, nil
+}
+
+// -- Expected reciprocal rank --
+
+// RankEvalExpectedReciprocalRank computes the expected reciprocal rank
+// (ERR) of the top k hits, i.e. the expected reciprocal of the position
+// at which a user stops looking through the hits.
+type RankEvalExpectedReciprocalRank struct {
+	maximumRelevance int
+	k                *int
+}
+
+// NewRankEvalExpectedReciprocalRank creates a new expected reciprocal
+// rank metric. maximumRelevance is the highest rating used in the
+// rated documents.
+func NewRankEvalExpectedReciprocalRank(maximumRelevance int) *RankEvalExpectedReciprocalRank {
+	return &RankEvalExpectedReciprocalRank{maximumRelevance: maximumRelevance}
+}
+
+// K sets the number of top hits to evaluate. It defaults to 10.
+func (m *RankEvalExpectedReciprocalRank) K(k int) *RankEvalExpectedReciprocalRank {
+	m.k = &k
+	return m
+}
+
+// Name returns the name of the metric.
+func (m *RankEvalExpectedReciprocalRank) Name() string {
+	return "expected_reciprocal_rank"
+}
+
+// Source returns JSON for the metric.
+func (m *RankEvalExpectedReciprocalRank) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	source["maximum_relevance"] = m.maximumRelevance
+	if m.k != nil {
+		source["k"] = *m.k
+	}
+	return source, nil
+}
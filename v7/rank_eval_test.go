@@ -0,0 +1,127 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRankEvalSource(t *testing.T) {
+	client, err := NewSimpleClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := client.RankEval("products").
+		Add(
+			NewRankEvalRequest("phone").
+				Query(NewMatchQuery("name", "phone")).
+				Rating("products", "1", 3).
+				Rating("products", "2", 0),
+			NewRankEvalRequest("tablet").
+				TemplateId("by_name").
+				Params(map[string]interface{}{"text": "tablet"}).
+				Ratings(RankEvalRatedDocument{Index: "products", Id: "7", Rating: 2}),
+		).
+		Template(NewRankEvalTemplate("by_name", `{"query":{"match":{"name":"{{text}}"}}}`)).
+		Metric(NewRankEvalDCG().K(5).Normalize(true)).
+		MaxConcurrentSearches(2)
+	src, err := s.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"max_concurrent_searches":2,"metric":{"dcg":{"k":5,"normalize":true}},"requests":[{"id":"phone","ratings":[{"_index":"products","_id":"1","rating":3},{"_index":"products","_id":"2","rating":0}],"request":{"query":{"match":{"name":{"query":"phone"}}}}},{"id":"tablet","params":{"text":"tablet"},"ratings":[{"_index":"products","_id":"7","rating":2}],"template_id":"by_name"}],"templates":[{"id":"by_name","template":{"source":"{\"query\":{\"match\":{\"name\":\"{{text}}\"}}}"}}]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRankEval(t *testing.T) {
+	rec := &templateTestServer{
+		response: `{
+			"metric_score": 0.5,
+			"details": {
+				"phone": {
+					"metric_score": 0.5,
+					"unrated_docs": [{"_index": "products", "_id": "9"}],
+					"hits": [
+						{"hit": {"_index": "products", "_id": "1", "_score": 2.5}, "rating": 3},
+						{"hit": {"_index": "products", "_id": "9", "_score": 1.5}, "rating": null}
+					],
+					"metric_details": {
+						"precision": {"relevant_docs_retrieved": 1, "docs_retrieved": 2}
+					}
+				}
+			},
+			"failures": {
+				"tablet": {"error": {"type": "index_not_found_exception", "reason": "no such index [archive]"}}
+			}
+		}`,
+	}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.RankEval("products").
+		Add(NewRankEvalRequest("phone").Query(NewMatchQuery("name", "phone")).Rating("products", "1", 3)).
+		Metric(NewRankEvalPrecision().K(2)).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/products/_rank_eval", rec.path; want != have {
+		t.Errorf("expected path %q; got: %q", want, have)
+	}
+	if want, have := 0.5, res.MetricScore; want != have {
+		t.Errorf("expected metric score %v; got: %v", want, have)
+	}
+	details := res.Details["phone"]
+	if details == nil {
+		t.Fatal("expected details for phone")
+	}
+	if want, have := 1, len(details.UnratedDocs); want != have {
+		t.Fatalf("expected %d unrated docs; got: %d", want, have)
+	}
+	if want, have := "9", details.UnratedDocs[0].Id; want != have {
+		t.Errorf("expected unrated doc %q; got: %q", want, have)
+	}
+	if want, have := 2, len(details.Hits); want != have {
+		t.Fatalf("expected %d hits; got: %d", want, have)
+	}
+	if details.Hits[0].Rating == nil || *details.Hits[0].Rating != 3 {
+		t.Errorf("expected rating 3; got: %v", details.Hits[0].Rating)
+	}
+	if details.Hits[1].Rating != nil {
+		t.Errorf("expected no rating; got: %v", *details.Hits[1].Rating)
+	}
+	precision := details.MetricDetails["precision"]
+	if precision == nil || precision.RelevantDocsRetrieved == nil || *precision.RelevantDocsRetrieved != 1 {
+		t.Errorf("expected 1 relevant doc retrieved; got: %+v", precision)
+	}
+	if f := res.Failures["tablet"]; f == nil || f.Error == nil || f.Error.Type != "index_not_found_exception" {
+		t.Errorf("expected failure for tablet; got: %+v", f)
+	}
+}
+
+func TestRankEvalValidate(t *testing.T) {
+	client, err := NewSimpleClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.RankEval().Validate(); err == nil {
+		t.Fatal("expected error without requests")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/facert/elastic/v7/uritemplates"
+)
+
+// RenderSearchTemplateService renders a search template with its
+// parameters into the search request body, without running the search.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/render-search-template-api.html
+// for details.
+type RenderSearchTemplateService struct {
+	client   *Client
+	pretty   bool
+	template searchTemplate
+}
+
+// NewRenderSearchTemplateService creates a new RenderSearchTemplateService.
+func NewRenderSearchTemplateService(client *Client) *RenderSearchTemplateService {
+	return &RenderSearchTemplateService{
+		client: client,
+	}
+}
+
+// Id sets the id of a stored template.
+func (s *RenderSearchTemplateService) Id(id string) *RenderSearchTemplateService {
+	s.template.id = id
+	return s
+}
+
+// Source sets an inline template, either as a string or as a
+// serializable object.
+func (s *RenderSearchTemplateService) Source(source interface{}) *RenderSearchTemplateService {
+	s.template.source = source
+	return s
+}
+
+// Params sets the parameters of the template, e.g. a map or a struct
+// that serializes into a JSON object.
+func (s *RenderSearchTemplateService) Params(params interface{}) *RenderSearchTemplateService {
+	s.template.params = params
+	return s
+}
+
+// Param adds a parameter of the template. It replaces parameters set
+// via Params that are not a map[string]interface{}.
+func (s *RenderSearchTemplateService) Param(name string, value interface{}) *RenderSearchTemplateService {
+	s.template.param(name, value)
+	return s
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *RenderSearchTemplateService) Pretty(pretty bool) *RenderSearchTemplateService {
+	s.pretty = pretty
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *RenderSearchTemplateService) buildURL() (string, url.Values, error) {
+	var err error
+	var path string
+
+	if s.template.id != "" {
+		path, err = uritemplates.Expand("/_render/template/{id}", map[string]string{
+			"id": s.template.id,
+		})
+	} else {
+		path = "/_render/template"
+	}
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *RenderSearchTemplateService) Validate() error {
+	return s.template.validate()
+}
+
+// Do executes the operation.
+func (s *RenderSearchTemplateService) Do(ctx context.Context) (*RenderSearchTemplateResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// The id is part of the path
+	body := s.template.body()
+	delete(body, "id")
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method: "POST",
+		Path:   path,
+		Params: params,
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(RenderSearchTemplateResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// RenderSearchTemplateResponse is the response of RenderSearchTemplateService.
+// Use ParseSearchSource to turn TemplateOutput into a SearchSource.
+type RenderSearchTemplateResponse struct {
+	TemplateOutput json.RawMessage `json:"template_output"`
+}
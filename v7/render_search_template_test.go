@@ -0,0 +1,48 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRenderSearchTemplate(t *testing.T) {
+	rec := &templateTestServer{
+		response: `{"template_output":{"query":{"match":{"name":"phone"}},"size":5}}`,
+	}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.RenderSearchTemplate().
+		Id("product-search").
+		Param("text", "phone").
+		Param("size", 5).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/_render/template/product-search", rec.path; want != have {
+		t.Errorf("expected path %q; got: %q", want, have)
+	}
+	if want, have := `{"params":{"size":5,"text":"phone"}}`, rec.body; want != have {
+		t.Errorf("expected body\n%s\ngot\n%s", want, have)
+	}
+
+	// The output can be parsed into a SearchSource
+	ss, err := ParseSearchSource(res.TemplateOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := `{"query":{"match":{"name":{"query":"phone"}}},"size":5}`, sourceJSON(t, ss); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+}
@@ -6,8 +6,6 @@ package elastic
 
 import (
 	"bytes"
-	"compress/gzip"
-	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -35,26 +33,41 @@ func (r *Request) SetBasicAuth(username, password string) {
 	((*http.Request)(r)).SetBasicAuth(username, password)
 }
 
+// BodyWriter is a request body that writes itself to the request as it
+// is being sent, instead of being serialized in memory first. WriteBody
+// is called again for every retry of the request.
+type BodyWriter interface {
+	WriteBody(w io.Writer) error
+}
+
 // SetBody encodes the body in the request. You may pass a flag to
 // compress the request via gzip.
 func (r *Request) SetBody(body interface{}, gzipCompress bool) error {
+	return r.setBody(body, gzipCompress, &DefaultEncoder{})
+}
+
+// setBody encodes the body in the request, using the given encoder
+// for bodies other than strings and BodyWriters.
+func (r *Request) setBody(body interface{}, gzipCompress bool, encoder Encoder) error {
 	switch b := body.(type) {
+	case BodyWriter:
+		return r.setBodyStream(b, gzipCompress)
 	case string:
 		if gzipCompress {
-			return r.setBodyGzip(b)
+			return r.setBodyGzip(b, encoder)
 		}
 		return r.setBodyString(b)
 	default:
 		if gzipCompress {
-			return r.setBodyGzip(body)
+			return r.setBodyGzip(body, encoder)
 		}
-		return r.setBodyJson(body)
+		return r.setBodyJson(body, encoder)
 	}
 }
 
-// setBodyJson encodes the body as a struct to be marshaled via json.Marshal.
-func (r *Request) setBodyJson(data interface{}) error {
-	body, err := json.Marshal(data)
+// setBodyJson encodes the body as a struct to be marshaled via the encoder.
+func (r *Request) setBodyJson(data interface{}, encoder Encoder) error {
+	body, err := encoder.Encode(data)
 	if err != nil {
 		return err
 	}
@@ -69,39 +82,59 @@ func (r *Request) setBodyString(body string) error {
 }
 
 // setBodyGzip gzip's the body. It accepts both strings and structs as body.
-// The latter will be encoded via json.Marshal.
-func (r *Request) setBodyGzip(body interface{}) error {
+// The latter will be encoded via the encoder. The compressed body is
+// written into a pooled buffer that is released when the body is closed.
+func (r *Request) setBodyGzip(body interface{}, encoder Encoder) error {
+	var data []byte
 	switch b := body.(type) {
 	case string:
-		buf := new(bytes.Buffer)
-		w := gzip.NewWriter(buf)
-		if _, err := w.Write([]byte(b)); err != nil {
-			return err
-		}
-		if err := w.Close(); err != nil {
-			return err
-		}
-		r.Header.Add("Content-Encoding", "gzip")
-		r.Header.Add("Vary", "Accept-Encoding")
-		return r.setBodyReader(bytes.NewReader(buf.Bytes()))
+		data = []byte(b)
 	default:
-		data, err := json.Marshal(b)
-		if err != nil {
-			return err
-		}
-		buf := new(bytes.Buffer)
-		w := gzip.NewWriter(buf)
-		if _, err := w.Write(data); err != nil {
+		var err error
+		if data, err = encoder.Encode(b); err != nil {
 			return err
 		}
-		if err := w.Close(); err != nil {
-			return err
+		r.Header.Set("Content-Type", "application/json")
+	}
+	buf := getBuffer()
+	w := getGzipWriter(buf)
+	_, err := w.Write(data)
+	if err == nil {
+		err = w.Close()
+	}
+	putGzipWriter(w)
+	if err != nil {
+		putBuffer(buf)
+		return err
+	}
+	r.Header.Add("Content-Encoding", "gzip")
+	r.Header.Add("Vary", "Accept-Encoding")
+	return r.setBodyReader(newPooledBody(buf))
+}
+
+// setBodyStream streams the body through a pipe, compressing it
+// via gzip on the fly if requested.
+func (r *Request) setBodyStream(body BodyWriter, gzipCompress bool) error {
+	pr, pw := io.Pipe()
+	go func() {
+		var err error
+		if gzipCompress {
+			w := getGzipWriter(pw)
+			if err = body.WriteBody(w); err == nil {
+				err = w.Close()
+			}
+			putGzipWriter(w)
+		} else {
+			err = body.WriteBody(pw)
 		}
+		// The reader gets io.EOF if err is nil
+		pw.CloseWithError(err)
+	}()
+	if gzipCompress {
 		r.Header.Add("Content-Encoding", "gzip")
 		r.Header.Add("Vary", "Accept-Encoding")
-		r.Header.Set("Content-Type", "application/json")
-		return r.setBodyReader(bytes.NewReader(buf.Bytes()))
 	}
+	return r.setBodyReader(pr)
 }
 
 // setBodyReader writes the body from an io.Reader.
@@ -117,6 +150,8 @@ func (r *Request) setBodyReader(body io.Reader) error {
 			r.ContentLength = int64(v.Len())
 		case *bytes.Buffer:
 			r.ContentLength = int64(v.Len())
+		case *pooledBody:
+			r.ContentLength = int64(v.Len())
 		}
 	}
 	return nil
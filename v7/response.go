@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
-	"io/ioutil"
 	"net/http"
 )
 
@@ -42,7 +41,7 @@ func (c *Client) newResponse(res *http.Response, maxBodySize int64) (*Response,
 			}
 			body = io.LimitReader(body, maxBodySize+1)
 		}
-		slurp, err := ioutil.ReadAll(body)
+		slurp, err := readBody(body, res.ContentLength)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,80 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "fmt"
+
+// RuntimeMapping defines a runtime field, i.e. a field that is evaluated
+// at query time by a script instead of being indexed. Runtime fields can
+// be queried, sorted, aggregated and retrieved with the fields parameter
+// like any other field. If the script is omitted, the value is taken
+// from the field of the same name in _source.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/runtime-search-request.html
+// for details.
+type RuntimeMapping struct {
+	typ    string
+	script *Script
+	format string
+}
+
+// NewRuntimeMapping creates a new runtime field of the given type, e.g.
+// "keyword", "long", "double", "date", "boolean", "ip" or "geo_point".
+func NewRuntimeMapping(typ string) *RuntimeMapping {
+	return &RuntimeMapping{typ: typ}
+}
+
+// Script sets the script that computes the value of the field. The
+// script emits values via emit(...).
+func (m *RuntimeMapping) Script(script *Script) *RuntimeMapping {
+	m.script = script
+	return m
+}
+
+// Format sets the format of a runtime field of type "date",
+// e.g. "yyyy-MM-dd".
+func (m *RuntimeMapping) Format(format string) *RuntimeMapping {
+	m.format = format
+	return m
+}
+
+// Source returns the JSON serializable data for the runtime field.
+func (m *RuntimeMapping) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	source["type"] = m.typ
+	if m.script != nil {
+		src, err := m.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["script"] = src
+	}
+	if m.format != "" {
+		source["format"] = m.format
+	}
+	return source, nil
+}
+
+// RuntimeMappings is a set of runtime fields by name.
+type RuntimeMappings map[string]*RuntimeMapping
+
+// Source serializes the RuntimeMappings into JSON.
+func (m RuntimeMappings) Source() (interface{}, error) {
+	if m == nil {
+		return nil, nil
+	}
+	source := make(map[string]interface{})
+	for name, mapping := range m {
+		if mapping == nil {
+			return nil, fmt.Errorf("RuntimeMappings expected a runtime mapping for %q", name)
+		}
+		src, err := mapping.Source()
+		if err != nil {
+			return nil, err
+		}
+		source[name] = src
+	}
+	return source, nil
+}
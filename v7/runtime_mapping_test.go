@@ -0,0 +1,74 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRuntimeMapping(t *testing.T) {
+	m := NewRuntimeMapping("keyword").
+		Script(NewScript("emit(doc['user'].value.toLowerCase())"))
+	src, err := m.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"script":{"source":"emit(doc['user'].value.toLowerCase())"},"type":"keyword"}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRuntimeMappingWithFormat(t *testing.T) {
+	m := NewRuntimeMapping("date").Format("yyyy-MM-dd")
+	src, err := m.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"format":"yyyy-MM-dd","type":"date"}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRuntimeMappings(t *testing.T) {
+	m := RuntimeMappings{
+		"day_of_week": NewRuntimeMapping("keyword").
+			Script(NewScript("emit(doc['created'].value.dayOfWeekEnum.toString())")),
+		"retweets_x2": NewRuntimeMapping("long").
+			Script(NewScript("emit(doc['retweets'].value * params.factor)").Param("factor", 2)),
+	}
+	src, err := m.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"day_of_week":{"script":{"source":"emit(doc['created'].value.dayOfWeekEnum.toString())"},"type":"keyword"},"retweets_x2":{"script":{"params":{"factor":2},"source":"emit(doc['retweets'].value * params.factor)"},"type":"long"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRuntimeMappingsWithNilMapping(t *testing.T) {
+	m := RuntimeMappings{"day_of_week": nil}
+	if _, err := m.Source(); err == nil {
+		t.Fatal("expected error with a nil runtime mapping")
+	}
+}
@@ -70,6 +70,19 @@ func (s *PutScriptService) BodyJson(body interface{}) *PutScriptService {
 	return s
 }
 
+// SearchTemplate sets the body to store a mustache search template, to
+// be used with e.g. SearchTemplateService. The template source is either
+// a string or a serializable object.
+func (s *PutScriptService) SearchTemplate(source interface{}) *PutScriptService {
+	s.bodyJson = map[string]interface{}{
+		"script": map[string]interface{}{
+			"lang":   "mustache",
+			"source": source,
+		},
+	}
+	return s
+}
+
 // BodyString is the document encoded as a string.
 func (s *PutScriptService) BodyString(body string) *PutScriptService {
 	s.bodyString = body
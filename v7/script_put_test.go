@@ -6,6 +6,7 @@ package elastic
 
 import (
 	"context"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -64,3 +65,35 @@ func TestPutScript(t *testing.T) {
 			Path:   "/_scripts/" + scriptID,
 		})
 }
+
+func TestPutScriptSearchTemplate(t *testing.T) {
+	rec := &templateTestServer{response: `{"acknowledged":true}`}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.PutScript().
+		Id("product-search").
+		SearchTemplate(map[string]interface{}{
+			"query": map[string]interface{}{
+				"match": map[string]interface{}{"name": "{{text}}"},
+			},
+		}).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Acknowledged {
+		t.Errorf("expected ack for PutScript op; got %v", res.Acknowledged)
+	}
+	if want, have := "/_scripts/product-search", rec.path; want != have {
+		t.Errorf("expected path %q; got: %q", want, have)
+	}
+	if want, have := `{"script":{"lang":"mustache","source":{"query":{"match":{"name":"{{text}}"}}}}}`, rec.body; want != have {
+		t.Errorf("expected body\n%s\ngot\n%s", want, have)
+	}
+}
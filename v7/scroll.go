@@ -6,6 +6,7 @@ package elastic
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -40,9 +41,12 @@ type ScrollService struct {
 	headers           http.Header
 	maxResponseSize   int64
 	filterPath        []string
+	resumable         bool
 
-	mu       sync.RWMutex
-	scrollId string
+	mu             sync.RWMutex
+	scrollId       string
+	lastSortValues []interface{} // sort values of the last hit, if resumable
+	resumed        bool          // continue with search_after instead of the scroll
 }
 
 // NewScrollService initializes and returns a new ScrollService.
@@ -272,16 +276,105 @@ func (s *ScrollService) ScrollId(scrollId string) *ScrollService {
 	return s
 }
 
+// Resumable enables resuming the scroll after its search context has
+// been lost, e.g. because the consumer was slower than KeepAlive. Do then
+// tracks the sort values of the last hit, and if Elasticsearch reports a
+// search_context_missing_exception, it continues with plain searches for
+// the remaining hits using search_after, so that no hit is returned twice.
+// Notice that these searches see changes to the index made after the
+// scroll was started, and that they do not have a scroll id.
+//
+// A resumable scroll requires a SearchSource with a deterministic sort
+// order, i.e. its last sort field must be unique per document, like an
+// id field. Sorting by "_doc" is not deterministic across scrolls.
+// Sliced scrolls cannot be resumable, as Elasticsearch only allows
+// slices in scrolls and point in time searches.
+func (s *ScrollService) Resumable(resumable bool) *ScrollService {
+	s.resumable = resumable
+	return s
+}
+
 // Do returns the next search result. It will return io.EOF as error if there
 // are no more search results.
 func (s *ScrollService) Do(ctx context.Context) (*SearchResult, error) {
 	s.mu.RLock()
 	nextScrollId := s.scrollId
+	resumed := s.resumed
 	s.mu.RUnlock()
+	if resumed {
+		// The search context is gone: Continue with search_after
+		return s.first(ctx)
+	}
 	if len(nextScrollId) == 0 {
+		if err := s.validateResumable(); err != nil {
+			return nil, err
+		}
 		return s.first(ctx)
 	}
-	return s.next(ctx)
+	res, err := s.next(ctx)
+	if err != nil && s.resumable && IsSearchContextMissing(err) {
+		return s.resume(ctx, err)
+	}
+	return res, err
+}
+
+// validateResumable checks if the scroll can be resumed.
+func (s *ScrollService) validateResumable() error {
+	if !s.resumable {
+		return nil
+	}
+	if s.body != nil {
+		return errors.New("elastic: resumable scroll requires a SearchSource instead of a body")
+	}
+	if !s.ss.hasSort() {
+		return errors.New("elastic: resumable scroll requires a deterministic sort order")
+	}
+	if s.ss.sliceQuery != nil {
+		// The search_after searches after a lost scroll cannot be sliced
+		return errors.New("elastic: resumable scroll cannot be sliced")
+	}
+	for _, sorter := range s.ss.sorters {
+		switch v := sorter.(type) {
+		case SortByDoc:
+			return errors.New(`elastic: resumable scroll cannot sort by "_doc"`)
+		case SortInfo:
+			if v.Field == "_doc" {
+				return errors.New(`elastic: resumable scroll cannot sort by "_doc"`)
+			}
+		}
+	}
+	return nil
+}
+
+// resume searches for the hits after the last hit that has been returned,
+// using search_after. Elasticsearch does not allow search_after in a scroll
+// context, so all subsequent pages are fetched with search_after as well.
+// It returns err if no hit has been returned yet.
+func (s *ScrollService) resume(ctx context.Context, err error) (*SearchResult, error) {
+	s.mu.Lock()
+	if len(s.lastSortValues) == 0 {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.scrollId = ""
+	s.resumed = true
+	s.mu.Unlock()
+	return s.first(ctx)
+}
+
+// trackSortValues remembers the sort values of the last hit of a
+// resumable scroll.
+func (s *ScrollService) trackSortValues(res *SearchResult) {
+	if !s.resumable || res.Hits == nil || len(res.Hits.Hits) == 0 {
+		return
+	}
+	sortValues := res.Hits.Hits[len(res.Hits.Hits)-1].Sort
+	if len(sortValues) == 0 {
+		return
+	}
+	s.mu.Lock()
+	s.lastSortValues = sortValues
+	s.mu.Unlock()
 }
 
 // Clear cancels the current scroll operation. If you don't do this manually,
@@ -333,6 +426,14 @@ func (s *ScrollService) first(ctx context.Context) (*SearchResult, error) {
 		return nil, err
 	}
 
+	s.mu.RLock()
+	resumed := s.resumed
+	s.mu.RUnlock()
+	if resumed {
+		// search_after cannot be used in a scroll context
+		params.Del("scroll")
+	}
+
 	// Get HTTP response
 	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
 		Method:          "POST",
@@ -352,12 +453,15 @@ func (s *ScrollService) first(ctx context.Context) (*SearchResult, error) {
 	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
 		return nil, err
 	}
-	s.mu.Lock()
-	s.scrollId = ret.ScrollId
-	s.mu.Unlock()
+	if !resumed {
+		s.mu.Lock()
+		s.scrollId = ret.ScrollId
+		s.mu.Unlock()
+	}
 	if ret.Hits == nil || len(ret.Hits.Hits) == 0 {
 		return ret, io.EOF
 	}
+	s.trackSortValues(ret)
 	return ret, nil
 }
 
@@ -440,6 +544,14 @@ func (s *ScrollService) bodyFirst() (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		// Continue after the last hit of a scroll that has been lost
+		s.mu.RLock()
+		resumed, searchAfter := s.resumed, s.lastSortValues
+		s.mu.RUnlock()
+		if m, ok := body.(map[string]interface{}); ok && resumed {
+			m["search_after"] = searchAfter
+		}
 	}
 
 	return body, nil
@@ -485,6 +597,7 @@ func (s *ScrollService) next(ctx context.Context) (*SearchResult, error) {
 	if ret.Hits == nil || len(ret.Hits.Hits) == 0 {
 		return ret, io.EOF
 	}
+	s.trackSortValues(ret)
 	return ret, nil
 }
 
@@ -0,0 +1,415 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSlicedScrollBufferSize is the default number of pages that
+	// SlicedScrollService fetches ahead of the consumer.
+	DefaultSlicedScrollBufferSize = 2
+
+	// slicedScrollClearTimeout is the time to wait for clearing the
+	// scroll of a slice. Scrolls are cleared with a context of their own,
+	// as the context of the scroll might have been cancelled.
+	slicedScrollClearTimeout = 30 * time.Second
+)
+
+// ErrSlicedScrollClosed is returned by SlicedScrollIterator.Next after the
+// iterator has been closed.
+var ErrSlicedScrollClosed = errors.New("elastic: sliced scroll is closed")
+
+// SlicedScrollService scrolls through the results of a search with a
+// number of slices in parallel. Every slice is scrolled in a goroutine
+// of its own that fetches the next page while the consumer processes the
+// current one. At most BufferSize pages are buffered for the consumer;
+// slices wait for the consumer when the buffer is full.
+//
+// Use Do to start scrolling and the returned SlicedScrollIterator to
+// iterate over the hits. The scroll of each slice is cleared when the
+// slice is done, fails, or the iteration is cancelled or closed.
+//
+// Sliced scrolling is supported in Elasticsearch 5.0 or later.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-request-scroll.html#sliced-scroll
+// for details.
+type SlicedScrollService struct {
+	client          *Client
+	retrier         Retrier
+	indices         []string
+	slices          int
+	field           string
+	keepAlive       string
+	ss              *SearchSource
+	size            *int
+	bufferSize      int
+	routing         []string
+	preference      string
+	headers         http.Header
+	maxResponseSize int64
+}
+
+// NewSlicedScrollService creates a new SlicedScrollService.
+func NewSlicedScrollService(client *Client) *SlicedScrollService {
+	return &SlicedScrollService{
+		client:     client,
+		slices:     2,
+		keepAlive:  DefaultScrollKeepAlive,
+		ss:         NewSearchSource(),
+		bufferSize: DefaultSlicedScrollBufferSize,
+	}
+}
+
+// Header sets headers on the requests.
+func (s *SlicedScrollService) Header(name string, value string) *SlicedScrollService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Retrier allows to set specific retry logic for the scrolls.
+// If not specified, it will use the client's default retrier.
+func (s *SlicedScrollService) Retrier(retrier Retrier) *SlicedScrollService {
+	s.retrier = retrier
+	return s
+}
+
+// Index sets the name of one or more indices to iterate over.
+func (s *SlicedScrollService) Index(indices ...string) *SlicedScrollService {
+	s.indices = append(s.indices, indices...)
+	return s
+}
+
+// Slices sets the number of slices to scroll in parallel. It defaults to 2.
+func (s *SlicedScrollService) Slices(slices int) *SlicedScrollService {
+	s.slices = slices
+	return s
+}
+
+// SliceField sets the field to slice by. It defaults to "_id" in
+// Elasticsearch. The field must be numeric and have doc values enabled.
+func (s *SlicedScrollService) SliceField(field string) *SlicedScrollService {
+	s.field = field
+	return s
+}
+
+// KeepAlive sets the maximum time after which the cursor of a slice will
+// expire. It is "5m" by default.
+func (s *SlicedScrollService) KeepAlive(keepAlive string) *SlicedScrollService {
+	s.keepAlive = keepAlive
+	return s
+}
+
+// Size specifies the number of documents Elasticsearch should return
+// per page of a slice.
+func (s *SlicedScrollService) Size(size int) *SlicedScrollService {
+	s.size = &size
+	return s
+}
+
+// BufferSize sets the maximum number of pages that are fetched ahead of
+// the consumer. It defaults to DefaultSlicedScrollBufferSize.
+func (s *SlicedScrollService) BufferSize(bufferSize int) *SlicedScrollService {
+	s.bufferSize = bufferSize
+	return s
+}
+
+// SearchSource sets the search source builder to use with this service.
+// The slice is set by the service.
+func (s *SlicedScrollService) SearchSource(searchSource *SearchSource) *SlicedScrollService {
+	s.ss = searchSource
+	if s.ss == nil {
+		s.ss = NewSearchSource()
+	}
+	return s
+}
+
+// Query sets the query to perform, e.g. MatchAllQuery.
+func (s *SlicedScrollService) Query(query Query) *SlicedScrollService {
+	s.ss = s.ss.Query(query)
+	return s
+}
+
+// FetchSource indicates whether the response should contain the stored
+// _source for every hit.
+func (s *SlicedScrollService) FetchSource(fetchSource bool) *SlicedScrollService {
+	s.ss = s.ss.FetchSource(fetchSource)
+	return s
+}
+
+// FetchSourceContext indicates how the _source should be fetched.
+func (s *SlicedScrollService) FetchSourceContext(fetchSourceContext *FetchSourceContext) *SlicedScrollService {
+	s.ss = s.ss.FetchSourceContext(fetchSourceContext)
+	return s
+}
+
+// Sort adds a sort order. Scrolls are sorted by "_doc" if no sort order
+// is specified.
+func (s *SlicedScrollService) Sort(field string, ascending bool) *SlicedScrollService {
+	s.ss = s.ss.Sort(field, ascending)
+	return s
+}
+
+// SortBy specifies a sort order.
+func (s *SlicedScrollService) SortBy(sorter ...Sorter) *SlicedScrollService {
+	s.ss = s.ss.SortBy(sorter...)
+	return s
+}
+
+// Routing is a list of specific routing values to control the shards
+// the search will be executed on.
+func (s *SlicedScrollService) Routing(routings ...string) *SlicedScrollService {
+	s.routing = append(s.routing, routings...)
+	return s
+}
+
+// Preference sets the preference to execute the search.
+func (s *SlicedScrollService) Preference(preference string) *SlicedScrollService {
+	s.preference = preference
+	return s
+}
+
+// MaxResponseSize sets an upper limit on the response body size that we accept,
+// to guard against OOM situations.
+func (s *SlicedScrollService) MaxResponseSize(maxResponseSize int64) *SlicedScrollService {
+	s.maxResponseSize = maxResponseSize
+	return s
+}
+
+// Validate checks if the operation is valid.
+func (s *SlicedScrollService) Validate() error {
+	var invalid []string
+	if s.slices <= 0 {
+		invalid = append(invalid, "Slices")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do starts scrolling through all slices in parallel. The context
+// controls the whole iteration. Use Close on the returned iterator
+// to stop early.
+func (s *SlicedScrollService) Do(ctx context.Context) (*SlicedScrollIterator, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Build the scrolls first, so that invalid search sources fail early
+	scrolls := make([]*ScrollService, s.slices)
+	for i := range scrolls {
+		body, err := s.body(i)
+		if err != nil {
+			return nil, err
+		}
+		svc := NewScrollService(s.client).
+			Index(s.indices...).
+			KeepAlive(s.keepAlive).
+			Body(body).
+			Retrier(s.retrier).
+			MaxResponseSize(s.maxResponseSize)
+		if s.size != nil {
+			svc = svc.Size(*s.size)
+		}
+		if len(s.routing) > 0 {
+			svc = svc.Routing(s.routing...)
+		}
+		if s.preference != "" {
+			svc = svc.Preference(s.preference)
+		}
+		for name, values := range s.headers {
+			for _, value := range values {
+				svc = svc.Header(name, value)
+			}
+		}
+		scrolls[i] = svc
+	}
+
+	bufferSize := s.bufferSize
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	it := &SlicedScrollIterator{
+		pages:  make(chan slicedScrollPage, bufferSize),
+		cancel: cancel,
+	}
+	for i, svc := range scrolls {
+		it.wg.Add(1)
+		go it.scroll(ctx, i, svc)
+	}
+	go func() {
+		it.wg.Wait()
+		close(it.pages)
+	}()
+	return it, nil
+}
+
+// body returns the search body for the given slice.
+func (s *SlicedScrollService) body(slice int) (interface{}, error) {
+	src, err := s.ss.Source()
+	if err != nil {
+		return nil, err
+	}
+	m, ok := src.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("elastic: unexpected search source of type %T", src)
+	}
+	body := make(map[string]interface{}, len(m)+2)
+	for k, v := range m {
+		body[k] = v
+	}
+	if _, found := body["sort"]; !found {
+		// Use efficient sorting when no sort order is specified
+		body["sort"] = []interface{}{"_doc"}
+	}
+	if s.slices > 1 {
+		sliceQuery := NewSliceQuery().Id(slice).Max(s.slices)
+		if s.field != "" {
+			sliceQuery = sliceQuery.Field(s.field)
+		}
+		sliceSrc, err := sliceQuery.Source()
+		if err != nil {
+			return nil, err
+		}
+		body["slice"] = sliceSrc
+	}
+	return body, nil
+}
+
+// SlicedScrollHit is a hit returned by SlicedScrollIterator.
+type SlicedScrollHit struct {
+	*SearchHit
+	Slice int // slice the hit has been returned by
+}
+
+// slicedScrollPage is a page of hits of a slice.
+type slicedScrollPage struct {
+	slice int
+	hits  []*SearchHit
+}
+
+// SlicedScrollIterator iterates over the hits of a SlicedScrollService.
+// It is not safe for concurrent use by multiple goroutines.
+type SlicedScrollIterator struct {
+	pages  chan slicedScrollPage
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	err      error // first error of a slice
+	clearErr error // first error clearing a scroll
+	closed   bool
+
+	page slicedScrollPage
+	pos  int
+}
+
+// Next returns the next hit. It returns io.EOF if all slices are done,
+// or the first error of any slice. An error of a slice stops all other
+// slices.
+func (it *SlicedScrollIterator) Next(ctx context.Context) (*SlicedScrollHit, error) {
+	for {
+		if it.pos < len(it.page.hits) {
+			hit := &SlicedScrollHit{SearchHit: it.page.hits[it.pos], Slice: it.page.slice}
+			it.pos++
+			return hit, nil
+		}
+		if err := it.error(); err != nil {
+			return nil, err
+		}
+		select {
+		case page, ok := <-it.pages:
+			if !ok {
+				if err := it.error(); err != nil {
+					return nil, err
+				}
+				return nil, io.EOF
+			}
+			it.page, it.pos = page, 0
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Close stops all slices and waits until their scrolls have been cleared.
+// It returns the first error clearing a scroll. It is safe to call Close
+// more than once, and after Next has returned io.EOF.
+func (it *SlicedScrollIterator) Close() error {
+	it.mu.Lock()
+	it.closed = true
+	it.mu.Unlock()
+	it.cancel()
+	it.wg.Wait()
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.clearErr
+}
+
+// error returns the first error of a slice, or ErrSlicedScrollClosed if
+// the iterator has been closed.
+func (it *SlicedScrollIterator) error() error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if it.closed {
+		return ErrSlicedScrollClosed
+	}
+	return it.err
+}
+
+// fail records the first error of a slice and stops all other slices.
+func (it *SlicedScrollIterator) fail(err error) {
+	it.mu.Lock()
+	if it.err == nil && !it.closed {
+		it.err = err
+	}
+	it.mu.Unlock()
+	it.cancel()
+}
+
+// scroll scrolls through a slice, sending its pages to the consumer.
+// It always clears the scroll, even if ctx has been cancelled.
+func (it *SlicedScrollIterator) scroll(ctx context.Context, slice int, svc *ScrollService) {
+	defer it.wg.Done()
+	defer func() {
+		clearCtx, cancel := context.WithTimeout(context.Background(), slicedScrollClearTimeout)
+		defer cancel()
+		if err := svc.Clear(clearCtx); err != nil {
+			it.mu.Lock()
+			if it.clearErr == nil {
+				it.clearErr = err
+			}
+			it.mu.Unlock()
+		}
+	}()
+
+	for {
+		res, err := svc.Do(ctx)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			it.fail(err)
+			return
+		}
+		select {
+		case it.pages <- slicedScrollPage{slice: slice, hits: res.Hits.Hits}:
+		case <-ctx.Done():
+			it.fail(ctx.Err())
+			return
+		}
+	}
+}
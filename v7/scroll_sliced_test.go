@@ -0,0 +1,282 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slicedScrollTestServer simulates the scroll API of Elasticsearch for
+// a number of slices with a fixed number of documents each.
+type slicedScrollTestServer struct {
+	t         *testing.T
+	docs      int // # of documents per slice
+	size      int // # of documents per page
+	failSlice int // slice to fail on its second page, or -1
+
+	mu       sync.Mutex
+	searches int
+	cleared  map[string]bool
+}
+
+func newSlicedScrollTestServer(t *testing.T, docs, size int) *slicedScrollTestServer {
+	return &slicedScrollTestServer{
+		t:         t,
+		docs:      docs,
+		size:      size,
+		failSlice: -1,
+		cleared:   make(map[string]bool),
+	}
+}
+
+func (s *slicedScrollTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Slice struct {
+			Id  int `json:"id"`
+			Max int `json:"max"`
+		} `json:"slice"`
+		Sort     []interface{} `json:"sort"`
+		ScrollId interface{}   `json:"scroll_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.t.Errorf("cannot decode body: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	var slice, page int
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/tweets/_search":
+		s.searches++
+		if r.URL.Query().Get("scroll") == "" {
+			s.t.Errorf("expected scroll parameter")
+		}
+		if len(body.Sort) != 1 || body.Sort[0] != "_doc" {
+			s.t.Errorf("expected sort by _doc; got: %v", body.Sort)
+		}
+		slice, page = body.Slice.Id, 0
+	case r.Method == "POST" && r.URL.Path == "/_search/scroll":
+		s.searches++
+		if _, err := fmt.Sscanf(body.ScrollId.(string), "slice-%d-page-%d", &slice, &page); err != nil {
+			s.t.Errorf("invalid scroll id %v", body.ScrollId)
+		}
+		page++
+	case r.Method == "DELETE" && r.URL.Path == "/_search/scroll":
+		for _, id := range body.ScrollId.([]interface{}) {
+			var slice int
+			fmt.Sscanf(id.(string), "slice-%d-", &slice)
+			s.cleared[fmt.Sprint(slice)] = true
+		}
+		fmt.Fprint(w, `{"succeeded":true,"num_freed":1}`)
+		return
+	default:
+		s.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		return
+	}
+
+	if slice == s.failSlice && page == 1 {
+		http.Error(w, `{"error":{"type":"test_exception","reason":"failed"},"status":500}`, http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, `{"_scroll_id":"slice-%d-page-%d","hits":{"total":{"value":%d,"relation":"eq"},"hits":[`, slice, page, s.docs)
+	for i := page * s.size; i < (page+1)*s.size && i < s.docs; i++ {
+		if i > page*s.size {
+			fmt.Fprint(w, ",")
+		}
+		fmt.Fprintf(w, `{"_index":"tweets","_id":"%d-%d","_source":{}}`, slice, i)
+	}
+	fmt.Fprint(w, `]}}`)
+}
+
+func (s *slicedScrollTestServer) clearedSlices() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var slices []string
+	for slice := range s.cleared {
+		slices = append(slices, slice)
+	}
+	sort.Strings(slices)
+	return slices
+}
+
+func TestSlicedScroll(t *testing.T) {
+	handler := newSlicedScrollTestServer(t, 5, 2)
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := client.SlicedScroll("tweets").
+		Slices(3).
+		Size(2).
+		Query(NewMatchAllQuery()).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[string]bool)
+	for {
+		hit, err := it.Next(context.Background())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("%d-", hit.Slice); hit.Id[:len(want)] != want {
+			t.Errorf("expected hit %q to be returned by slice %d", hit.Id, hit.Slice)
+		}
+		if seen[hit.Id] {
+			t.Errorf("duplicate hit %q", hit.Id)
+		}
+		seen[hit.Id] = true
+	}
+	if want, have := 15, len(seen); want != have {
+		t.Fatalf("expected %d hits; got: %d", want, have)
+	}
+	if err := it.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := fmt.Sprint([]string{"0", "1", "2"}), fmt.Sprint(handler.clearedSlices()); want != have {
+		t.Errorf("expected scrolls of slices %s to be cleared; got: %s", want, have)
+	}
+}
+
+func TestSlicedScrollError(t *testing.T) {
+	handler := newSlicedScrollTestServer(t, 100, 2)
+	handler.failSlice = 1
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := client.SlicedScroll("tweets").
+		Slices(3).
+		Size(2).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		_, err = it.Next(context.Background())
+		if err != nil {
+			break
+		}
+	}
+	if e, ok := err.(*Error); !ok || e.Status != http.StatusInternalServerError {
+		t.Fatalf("expected *Error with status 500; got: %#v", err)
+	}
+	if err := it.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Other slices might have been stopped before they got a scroll id
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if !handler.cleared["1"] {
+		t.Errorf("expected scroll of failed slice to be cleared; got: %v", handler.cleared)
+	}
+}
+
+func TestSlicedScrollClose(t *testing.T) {
+	handler := newSlicedScrollTestServer(t, 1000, 1)
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := client.SlicedScroll("tweets").
+		Slices(2).
+		Size(1).
+		BufferSize(1).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := it.Next(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Slices must wait for the consumer: 1 page has been consumed, 1 page
+	// is buffered, and each slice waits to hand over 1 more page
+	time.Sleep(100 * time.Millisecond)
+	handler.mu.Lock()
+	searches := handler.searches
+	handler.mu.Unlock()
+	if searches > 4 {
+		t.Errorf("expected at most %d searches while the consumer is idle; got: %d", 4, searches)
+	}
+
+	if err := it.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := it.Next(context.Background()); err != ErrSlicedScrollClosed {
+		t.Fatalf("expected %v; got: %v", ErrSlicedScrollClosed, err)
+	}
+	if want, have := fmt.Sprint([]string{"0", "1"}), fmt.Sprint(handler.clearedSlices()); want != have {
+		t.Errorf("expected scrolls of slices %s to be cleared; got: %s", want, have)
+	}
+}
+
+func TestSlicedScrollCancel(t *testing.T) {
+	handler := newSlicedScrollTestServer(t, 1000, 1)
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it, err := client.SlicedScroll("tweets").Slices(2).Size(1).Do(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wait until both slices have started scrolling
+	started := make(map[int]bool)
+	for len(started) < 2 {
+		hit, err := it.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		started[hit.Slice] = true
+	}
+	cancel()
+	for {
+		if _, err = it.Next(context.Background()); err != nil {
+			break
+		}
+	}
+	if !IsContextErr(err) {
+		t.Fatalf("expected context error; got: %v", err)
+	}
+	if err := it.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := fmt.Sprint([]string{"0", "1"}), fmt.Sprint(handler.clearedSlices()); want != have {
+		t.Errorf("expected scrolls of slices %s to be cleared; got: %s", want, have)
+	}
+}
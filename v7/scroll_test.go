@@ -7,8 +7,11 @@ package elastic
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
-	_ "net/http"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 )
 
@@ -619,3 +622,141 @@ func TestScrollWithFilterPathKeepingContext(t *testing.T) {
 		t.Fatal("expected to fail")
 	}
 }
+
+func TestScrollResumable(t *testing.T) {
+	const numDocs = 7
+
+	var (
+		mu       sync.Mutex
+		searches []map[string]interface{}
+		expired  bool
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil {
+			t.Errorf("cannot decode body: %v", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Documents are sorted by id; pages have 2 documents
+		var from int
+		scroll := true
+		switch r.URL.Path {
+		case "/" + testIndexName + "/_search":
+			searches = append(searches, body)
+			_, scroll = r.URL.Query()["scroll"]
+			if v, ok := body["search_after"].([]interface{}); ok {
+				if scroll {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error":{"root_cause":[{"type":"search_context_exception","reason":"`+"`search_after`"+` cannot be used in a scroll context."}],"type":"search_context_exception","reason":"`+"`search_after`"+` cannot be used in a scroll context."},"status":400}`)
+					return
+				}
+				n, _ := v[0].(json.Number).Int64()
+				from = int(n) + 1
+			}
+		case "/_search/scroll":
+			if !expired && body["scroll_id"] == "scroll-2" {
+				// Simulate a slow consumer
+				expired = true
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":{"root_cause":[{"type":"search_context_missing_exception","reason":"No search context found for id [2]"}],"type":"search_phase_execution_exception","reason":"all shards failed"},"status":404}`)
+				return
+			}
+			fmt.Sscanf(body["scroll_id"].(string), "scroll-%d", &from)
+		}
+		if scroll {
+			fmt.Fprintf(w, `{"_scroll_id":"scroll-%d","hits":{"hits":[`, from+2)
+		} else {
+			fmt.Fprint(w, `{"hits":{"hits":[`)
+		}
+		for i := from; i < from+2 && i < numDocs; i++ {
+			if i > from {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"_index":%q,"_id":"%d","sort":[%d]}`, testIndexName, i, i)
+		}
+		fmt.Fprint(w, `]}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Resumable scrolls require a deterministic sort order
+	if _, err := client.Scroll(testIndexName).Resumable(true).Do(context.TODO()); err == nil {
+		t.Fatal("expected error without sort order")
+	}
+
+	// Resumable scrolls cannot be sliced
+	sliced := client.Scroll(testIndexName).Sort("id", true).Slice(NewSliceQuery().Id(0).Max(2)).Resumable(true)
+	if _, err := sliced.Do(context.TODO()); err == nil {
+		t.Fatal("expected error for sliced scroll")
+	}
+
+	svc := client.Scroll(testIndexName).Size(2).Sort("id", true).Resumable(true)
+	var ids []string
+	for {
+		res, err := svc.Do(context.TODO())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, hit := range res.Hits.Hits {
+			ids = append(ids, hit.Id)
+		}
+	}
+	if want, have := "[0 1 2 3 4 5 6]", fmt.Sprint(ids); want != have {
+		t.Fatalf("expected ids %s; got: %s", want, have)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !expired {
+		t.Fatal("expected search context to expire")
+	}
+	// The scroll, then search_after after 1, 3, 5, and 6
+	if want, have := 5, len(searches); want != have {
+		t.Fatalf("expected %d searches; got: %d", want, have)
+	}
+	if _, found := searches[0]["search_after"]; found {
+		t.Errorf("expected no search_after in first search; got: %v", searches[0]["search_after"])
+	}
+	for i, want := range []string{"[1]", "[3]", "[5]", "[6]"} {
+		if have := fmt.Sprint(searches[i+1]["search_after"]); want != have {
+			t.Errorf("search %d: expected search_after %s; got: %s", i+1, want, have)
+		}
+	}
+}
+
+func TestScrollNotResumable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_search/scroll" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"type":"search_context_missing_exception","reason":"No search context found for id [1]"},"status":404}`)
+			return
+		}
+		fmt.Fprintf(w, `{"_scroll_id":"scroll-1","hits":{"hits":[{"_index":%q,"_id":"1","sort":[1]}]}}`, testIndexName)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := client.Scroll(testIndexName).Sort("id", true)
+	if _, err := svc.Do(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.Do(context.TODO())
+	if !IsSearchContextMissing(err) {
+		t.Fatalf("expected search_context_missing_exception; got: %v", err)
+	}
+}
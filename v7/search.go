@@ -4,6 +4,8 @@
 
 package elastic
 
+//go:generate easyjson -no_std_marshalers search.go
+
 import (
 	"context"
 	"encoding/json"
@@ -12,6 +14,9 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+
 	"github.com/facert/elastic/v7/uritemplates"
 )
 
@@ -285,6 +290,40 @@ func (s *SearchService) DocvalueFieldsWithFormat(docvalueFields ...DocvalueField
 	return s
 }
 
+// FetchField adds a single field to retrieve with the fields parameter
+// and return as part of the search.
+func (s *SearchService) FetchField(field string) *SearchService {
+	s.searchSource = s.searchSource.FetchField(field)
+	return s
+}
+
+// FetchFieldWithFormat adds a single field to retrieve with the fields
+// parameter and return as part of the search.
+func (s *SearchService) FetchFieldWithFormat(field FieldAndFormat) *SearchService {
+	s.searchSource = s.searchSource.FetchFieldWithFormat(field)
+	return s
+}
+
+// FetchFields adds one or more fields to retrieve with the fields
+// parameter and return as part of the search.
+func (s *SearchService) FetchFields(fields ...string) *SearchService {
+	s.searchSource = s.searchSource.FetchFields(fields...)
+	return s
+}
+
+// FetchFieldsWithFormat adds one or more fields to retrieve with the
+// fields parameter and return as part of the search.
+func (s *SearchService) FetchFieldsWithFormat(fields ...FieldAndFormat) *SearchService {
+	s.searchSource = s.searchSource.FetchFieldsWithFormat(fields...)
+	return s
+}
+
+// RuntimeMapping adds a runtime field that is only defined for this search.
+func (s *SearchService) RuntimeMapping(name string, mapping *RuntimeMapping) *SearchService {
+	s.searchSource = s.searchSource.RuntimeMapping(name, mapping)
+	return s
+}
+
 // NoStoredFields indicates that no stored fields should be loaded, resulting in only
 // id and type to be returned per field.
 func (s *SearchService) NoStoredFields() *SearchService {
@@ -477,6 +516,7 @@ func (s *SearchService) Do(ctx context.Context) (*SearchResult, error) {
 }
 
 // SearchResult is the result of a search in Elasticsearch.
+//easyjson:json
 type SearchResult struct {
 	TookInMillis int64          `json:"took,omitempty"`         // search time in milliseconds
 	ScrollId     string         `json:"_scroll_id,omitempty"`   // only used with Scroll and Scan operations
@@ -487,6 +527,7 @@ type SearchResult struct {
 	Error        *ErrorDetails  `json:"error,omitempty"`        // only used in MultiGet
 	Profile      *SearchProfile `json:"profile,omitempty"`      // profiling results, if optional Profile API was active for this search
 	Shards       *ShardsInfo    `json:"_shards,omitempty"`      // shard information
+	PitId        string         `json:"pit_id,omitempty"`       // Point In Time ID
 }
 
 // TotalHits is a convenience function to return the number of hits for
@@ -522,6 +563,7 @@ func (r *SearchResult) Each(typ reflect.Type) []interface{} {
 }
 
 // SearchHits specifies the list of search hits.
+//easyjson:json
 type SearchHits struct {
 	TotalHits *TotalHits   `json:"total,omitempty"`     // total number of hits found
 	MaxScore  *float64     `json:"max_score,omitempty"` // maximum score of all hits
@@ -536,6 +578,7 @@ type NestedHit struct {
 }
 
 // TotalHits specifies total number of hits and its relation
+//easyjson:json
 type TotalHits struct {
 	Value    int64  `json:"value"`    // value of the total hit count
 	Relation string `json:"relation"` // how the value should be interpreted: accurate ("eq") or a lower bound ("gte")
@@ -562,12 +605,113 @@ type SearchHit struct {
 	InnerHits      map[string]*SearchHitInnerHits `json:"inner_hits,omitempty"`      // inner hits with ES >= 1.5.0
 	Nested         *NestedHit                     `json:"_nested,omitempty"`         // for nested inner hits
 
+	rawFields json.RawMessage // fields as returned by Elasticsearch, see DecodeFields
+
 	// Shard
 	// HighlightFields
 	// SortValues
 	// MatchedFilters
 }
 
+// searchHit is decoded and encoded by the generated easyjson code on
+// behalf of SearchHit.
+//easyjson:json
+type searchHit SearchHit
+
+// UnmarshalEasyJSON decodes a hit with easyjson. It keeps the JSON of
+// the fields of the hit, so that DecodeFields can decode numbers without
+// the loss of precision of the float64 values in Fields.
+func (hit *SearchHit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	data := l.Raw()
+	if !l.Ok() {
+		return
+	}
+	in := jlexer.Lexer{Data: data}
+	(*searchHit)(hit).UnmarshalEasyJSON(&in)
+	if err := in.Error(); err != nil {
+		l.AddError(err)
+		return
+	}
+	hit.rawFields = nil
+	if hit.Fields == nil {
+		return
+	}
+	in = jlexer.Lexer{Data: data}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if key == "fields" {
+			hit.rawFields = append(json.RawMessage(nil), in.Raw()...)
+		} else {
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+}
+
+// MarshalEasyJSON encodes a hit with easyjson.
+func (hit SearchHit) MarshalEasyJSON(w *jwriter.Writer) {
+	searchHit(hit).MarshalEasyJSON(w)
+}
+
+// DecodeFields decodes the fields of the hit, e.g. the fields requested
+// via FetchFields, DocvalueFields or ScriptField, into v, e.g. a pointer
+// to a struct. Notice that Elasticsearch returns the values of every
+// field as an array, even if there is only one value.
+//
+// Hits decoded from a response are decoded from the JSON returned by
+// Elasticsearch, so numbers like longs above 2^53 keep their precision.
+func (hit *SearchHit) DecodeFields(v interface{}) error {
+	if hit.rawFields != nil {
+		return json.Unmarshal(hit.rawFields, v)
+	}
+	data, err := json.Marshal(hit.Fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// DecodeField decodes the values of the named field into v, e.g. a
+// pointer to a []string. It returns false if the hit has no values
+// for the field.
+func (hit *SearchHit) DecodeField(name string, v interface{}) (bool, error) {
+	values, found := hit.Fields[name]
+	if !found {
+		return false, nil
+	}
+	if hit.rawFields != nil {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(hit.rawFields, &fields); err != nil {
+			return true, err
+		}
+		if data, found := fields[name]; found {
+			return true, json.Unmarshal(data, v)
+		}
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return true, err
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// DecodeFieldValue decodes the first value of the named field into v,
+// e.g. a pointer to a string. It returns false if the hit has no values
+// for the field.
+func (hit *SearchHit) DecodeFieldValue(name string, v interface{}) (bool, error) {
+	var values []json.RawMessage
+	found, err := hit.DecodeField(name, &values)
+	if !found || err != nil {
+		return found, err
+	}
+	if len(values) == 0 {
+		return false, nil
+	}
+	return true, json.Unmarshal(values[0], v)
+}
+
 // SearchHitInnerHits is used for inner hits.
 type SearchHitInnerHits struct {
 	Hits *SearchHits `json:"hits,omitempty"`
@@ -0,0 +1,410 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const (
+	// DefaultPointInTimeKeepAlive is the default time a point in time
+	// will be kept alive by SearchAfterService.
+	DefaultPointInTimeKeepAlive = "5m"
+)
+
+// SearchAfterService iterates over all hits of a search with search_after
+// and a point in time. It is the recommended replacement for deep
+// pagination with ScrollService.
+//
+// The first call to Do opens a point in time on the indices, unless one is
+// passed with PointInTimeId. Every call to Do then returns the next page of
+// results sorted by the sort order of the search and the "_shard_doc"
+// tiebreaker of the point in time, and extends the keep alive of the point
+// in time. Do returns io.EOF when there are no more hits, after it has
+// closed the point in time. Use Close to close it early.
+//
+// A point in time passed with PointInTimeId is not closed by
+// SearchAfterService, as it might be used by others.
+//
+// Point in time requires Elasticsearch 7.10 or later, the "_shard_doc"
+// tiebreaker requires Elasticsearch 7.12 or later.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/paginate-search-results.html#search-after
+// for details.
+type SearchAfterService struct {
+	client            *Client
+	retrier           Retrier
+	indices           []string
+	keepAlive         string
+	ss                *SearchSource
+	size              *int
+	pretty            bool
+	routing           string
+	preference        string
+	ignoreUnavailable *bool
+	expandWildcards   string
+	headers           http.Header
+	maxResponseSize   int64
+
+	mu          sync.RWMutex
+	pitId       string
+	ownsPit     bool // true if the service opened the point in time
+	searchAfter []interface{}
+	done        bool
+}
+
+// NewSearchAfterService creates a new SearchAfterService.
+func NewSearchAfterService(client *Client) *SearchAfterService {
+	return &SearchAfterService{
+		client:    client,
+		ss:        NewSearchSource(),
+		keepAlive: DefaultPointInTimeKeepAlive,
+	}
+}
+
+// Header sets headers on the request.
+func (s *SearchAfterService) Header(name string, value string) *SearchAfterService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Retrier allows to set specific retry logic for this SearchAfterService.
+// If not specified, it will use the client's default retrier.
+func (s *SearchAfterService) Retrier(retrier Retrier) *SearchAfterService {
+	s.retrier = retrier
+	return s
+}
+
+// Index sets the name of one or more indices to iterate over.
+func (s *SearchAfterService) Index(indices ...string) *SearchAfterService {
+	s.indices = append(s.indices, indices...)
+	return s
+}
+
+// KeepAlive sets the time to keep the point in time alive between two
+// pages, e.g. "5m". It defaults to DefaultPointInTimeKeepAlive.
+func (s *SearchAfterService) KeepAlive(keepAlive string) *SearchAfterService {
+	s.keepAlive = keepAlive
+	return s
+}
+
+// PointInTimeId sets the id of an existing point in time to search in,
+// instead of opening a new one. It is not closed by the service.
+func (s *SearchAfterService) PointInTimeId(id string) *SearchAfterService {
+	s.mu.Lock()
+	s.pitId = id
+	s.ownsPit = false
+	s.mu.Unlock()
+	return s
+}
+
+// SearchAfter sets the sort values of the last hit returned previously,
+// e.g. to resume an iteration.
+func (s *SearchAfterService) SearchAfter(sortValues ...interface{}) *SearchAfterService {
+	s.mu.Lock()
+	s.searchAfter = sortValues
+	s.mu.Unlock()
+	return s
+}
+
+// Size specifies the number of documents Elasticsearch should return
+// per page.
+func (s *SearchAfterService) Size(size int) *SearchAfterService {
+	s.size = &size
+	return s
+}
+
+// SearchSource sets the search source builder to use with this service.
+// The point in time, the search_after values, and the size are set by
+// the service.
+func (s *SearchAfterService) SearchSource(searchSource *SearchSource) *SearchAfterService {
+	s.ss = searchSource
+	if s.ss == nil {
+		s.ss = NewSearchSource()
+	}
+	return s
+}
+
+// Query sets the query to perform, e.g. MatchAllQuery.
+func (s *SearchAfterService) Query(query Query) *SearchAfterService {
+	s.ss = s.ss.Query(query)
+	return s
+}
+
+// PostFilter is executed as the last filter. It only affects the
+// search hits but not facets. See
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-request-post-filter.html
+// for details.
+func (s *SearchAfterService) PostFilter(postFilter Query) *SearchAfterService {
+	s.ss = s.ss.PostFilter(postFilter)
+	return s
+}
+
+// FetchSource indicates whether the response should contain the stored
+// _source for every hit.
+func (s *SearchAfterService) FetchSource(fetchSource bool) *SearchAfterService {
+	s.ss = s.ss.FetchSource(fetchSource)
+	return s
+}
+
+// FetchSourceContext indicates how the _source should be fetched.
+func (s *SearchAfterService) FetchSourceContext(fetchSourceContext *FetchSourceContext) *SearchAfterService {
+	s.ss = s.ss.FetchSourceContext(fetchSourceContext)
+	return s
+}
+
+// Sort adds a sort order. The "_shard_doc" tiebreaker is added after
+// all sort orders.
+func (s *SearchAfterService) Sort(field string, ascending bool) *SearchAfterService {
+	s.ss = s.ss.Sort(field, ascending)
+	return s
+}
+
+// SortWithInfo specifies a sort order.
+func (s *SearchAfterService) SortWithInfo(info SortInfo) *SearchAfterService {
+	s.ss = s.ss.SortWithInfo(info)
+	return s
+}
+
+// SortBy specifies a sort order.
+func (s *SearchAfterService) SortBy(sorter ...Sorter) *SearchAfterService {
+	s.ss = s.ss.SortBy(sorter...)
+	return s
+}
+
+// TrackTotalHits controls if the total hit count for the query should be tracked.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-request-track-total-hits.html
+// for details.
+func (s *SearchAfterService) TrackTotalHits(trackTotalHits interface{}) *SearchAfterService {
+	s.ss = s.ss.TrackTotalHits(trackTotalHits)
+	return s
+}
+
+// Pretty asks Elasticsearch to pretty-print the returned JSON.
+func (s *SearchAfterService) Pretty(pretty bool) *SearchAfterService {
+	s.pretty = pretty
+	return s
+}
+
+// Routing is a list of specific routing values to control the shards
+// the point in time is opened on.
+func (s *SearchAfterService) Routing(routing string) *SearchAfterService {
+	s.routing = routing
+	return s
+}
+
+// Preference sets the preference to execute the search. Defaults to
+// randomize across shards ("random"). Can be set to "_local" to prefer
+// local shards, "_primary" to execute on primary shards only,
+// or a custom value which guarantees that the same order will be used
+// across different requests.
+func (s *SearchAfterService) Preference(preference string) *SearchAfterService {
+	s.preference = preference
+	return s
+}
+
+// IgnoreUnavailable indicates whether the specified concrete indices
+// should be ignored when unavailable (missing or closed).
+func (s *SearchAfterService) IgnoreUnavailable(ignoreUnavailable bool) *SearchAfterService {
+	s.ignoreUnavailable = &ignoreUnavailable
+	return s
+}
+
+// ExpandWildcards indicates whether to expand wildcard expression to
+// concrete indices that are open, closed or both.
+func (s *SearchAfterService) ExpandWildcards(expandWildcards string) *SearchAfterService {
+	s.expandWildcards = expandWildcards
+	return s
+}
+
+// MaxResponseSize sets an upper limit on the response body size that we accept,
+// to guard against OOM situations.
+func (s *SearchAfterService) MaxResponseSize(maxResponseSize int64) *SearchAfterService {
+	s.maxResponseSize = maxResponseSize
+	return s
+}
+
+// Do returns the next page of search results. It will return io.EOF as
+// error if there are no more search results.
+func (s *SearchAfterService) Do(ctx context.Context) (*SearchResult, error) {
+	s.mu.RLock()
+	pitId, done := s.pitId, s.done
+	s.mu.RUnlock()
+	if done {
+		return nil, io.EOF
+	}
+	if pitId == "" {
+		if err := s.open(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get URL and parameters for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP request body
+	body, err := s.body()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:          "POST",
+		Path:            path,
+		Params:          params,
+		Body:            body,
+		Retrier:         s.retrier,
+		Headers:         s.headers,
+		MaxResponseSize: s.maxResponseSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(SearchResult)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if ret.PitId != "" {
+		// The id of a point in time might change between searches
+		s.pitId = ret.PitId
+	}
+	if ret.Hits == nil || len(ret.Hits.Hits) == 0 {
+		s.mu.Unlock()
+		if err := s.Close(ctx); err != nil {
+			return ret, err
+		}
+		return ret, io.EOF
+	}
+	s.searchAfter = ret.Hits.Hits[len(ret.Hits.Hits)-1].Sort
+	s.mu.Unlock()
+	return ret, nil
+}
+
+// Close closes the point in time if it has been opened by the service.
+// It is safe to call Close more than once.
+func (s *SearchAfterService) Close(ctx context.Context) error {
+	s.mu.Lock()
+	pitId, ownsPit := s.pitId, s.ownsPit
+	s.done = true
+	s.ownsPit = false
+	s.mu.Unlock()
+	if pitId == "" || !ownsPit {
+		return nil
+	}
+	_, err := s.client.ClosePointInTime(pitId).Do(ctx)
+	return err
+}
+
+// open opens a new point in time on the indices.
+func (s *SearchAfterService) open(ctx context.Context) error {
+	svc := s.client.OpenPointInTime(s.indices...).
+		KeepAlive(s.keepAlive).
+		Routing(s.routing).
+		Preference(s.preference).
+		ExpandWildcards(s.expandWildcards)
+	if s.ignoreUnavailable != nil {
+		svc = svc.IgnoreUnavailable(*s.ignoreUnavailable)
+	}
+	for name, values := range s.headers {
+		for _, value := range values {
+			svc = svc.Header(name, value)
+		}
+	}
+	res, err := svc.Do(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Id == "" {
+		return fmt.Errorf("elastic: no point in time id returned for indices %v", s.indices)
+	}
+	s.mu.Lock()
+	s.pitId = res.Id
+	s.ownsPit = true
+	s.mu.Unlock()
+	return nil
+}
+
+// buildURL builds the URL for retrieving a page. A search with a point
+// in time must not specify indices, nor routing or preference.
+func (s *SearchAfterService) buildURL() (string, url.Values, error) {
+	path := "/_search"
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	return path, params, nil
+}
+
+// body returns the request to fetch the next page of results.
+func (s *SearchAfterService) body() (interface{}, error) {
+	src, err := s.ss.Source()
+	if err != nil {
+		return nil, err
+	}
+	body, ok := src.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("elastic: unexpected search source of type %T", src)
+	}
+
+	// Add the tiebreaker of the point in time
+	sort, _ := body["sort"].([]interface{})
+	if !hasShardDocSort(sort) {
+		sort = append(sort, "_shard_doc")
+	}
+	body["sort"] = sort
+	if s.size != nil && *s.size > 0 {
+		body["size"] = *s.size
+	}
+
+	s.mu.RLock()
+	body["pit"] = map[string]interface{}{
+		"id":         s.pitId,
+		"keep_alive": s.keepAlive,
+	}
+	if len(s.searchAfter) > 0 {
+		body["search_after"] = s.searchAfter
+	} else {
+		delete(body, "search_after")
+	}
+	s.mu.RUnlock()
+
+	return body, nil
+}
+
+// hasShardDocSort returns true if the serialized sort orders include
+// the "_shard_doc" field.
+func hasShardDocSort(sort []interface{}) bool {
+	for _, v := range sort {
+		switch t := v.(type) {
+		case string:
+			if t == "_shard_doc" {
+				return true
+			}
+		case map[string]interface{}:
+			if _, found := t["_shard_doc"]; found {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// searchAfterTestServer simulates the point in time and search APIs
+// of Elasticsearch for a fixed number of documents.
+type searchAfterTestServer struct {
+	t    *testing.T
+	docs int
+
+	mu     sync.Mutex
+	opened int
+	closed []string
+	bodies []map[string]interface{}
+}
+
+func (s *searchAfterTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/tweets/_pit":
+		s.opened++
+		if want, have := "1m", r.URL.Query().Get("keep_alive"); want != have {
+			s.t.Errorf("expected keep_alive=%q; got: %q", want, have)
+		}
+		fmt.Fprintf(w, `{"id":"pit-%d"}`, s.opened)
+	case r.Method == "DELETE" && r.URL.Path == "/_pit":
+		var body struct {
+			Id string `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			s.t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.closed = append(s.closed, body.Id)
+		fmt.Fprint(w, `{"succeeded":true,"num_freed":1}`)
+	case r.Method == "POST" && r.URL.Path == "/_search":
+		var body map[string]interface{}
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil {
+			s.t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.bodies = append(s.bodies, body)
+
+		size := 10
+		if v, ok := body["size"].(json.Number); ok {
+			n, _ := v.Int64()
+			size = int(n)
+		}
+		from := 0
+		if v, ok := body["search_after"].([]interface{}); ok && len(v) == 2 {
+			n, _ := v[1].(json.Number).Int64()
+			from = int(n) + 1
+		}
+		var hits []string
+		for i := from; i < from+size && i < s.docs; i++ {
+			hits = append(hits, fmt.Sprintf(`{"_index":"tweets","_id":"%d","_source":{"user":"olivere"},"sort":["olivere",%d]}`, i, i))
+		}
+		fmt.Fprintf(w, `{"pit_id":"pit-%d-%d","hits":{"total":{"value":%d,"relation":"eq"},"hits":[`, s.opened, len(s.bodies), s.docs)
+		for i, hit := range hits {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprint(w, hit)
+		}
+		fmt.Fprint(w, `]}}`)
+	default:
+		s.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		http.Error(w, `{"error":"unexpected request"}`, http.StatusBadRequest)
+	}
+}
+
+func TestSearchAfterService(t *testing.T) {
+	handler := &searchAfterTestServer{t: t, docs: 5}
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := client.SearchAfter("tweets").
+		KeepAlive("1m").
+		Size(2).
+		Query(NewMatchAllQuery()).
+		Sort("user", true)
+
+	var ids []string
+	for {
+		res, err := svc.Do(context.Background())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, hit := range res.Hits.Hits {
+			ids = append(ids, hit.Id)
+		}
+	}
+	if want, have := []string{"0", "1", "2", "3", "4"}, ids; !reflect.DeepEqual(want, have) {
+		t.Fatalf("expected ids %v; got: %v", want, have)
+	}
+
+	// Do after io.EOF must not issue requests
+	if _, err := svc.Do(context.Background()); err != io.EOF {
+		t.Fatalf("expected io.EOF; got: %v", err)
+	}
+	if err := svc.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if want, have := 1, handler.opened; want != have {
+		t.Errorf("expected %d point in time to be opened; got: %d", want, have)
+	}
+	if want, have := 4, len(handler.bodies); want != have {
+		t.Fatalf("expected %d searches; got: %d", want, have)
+	}
+	// Closes the latest point in time id returned
+	if want, have := []string{"pit-1-4"}, handler.closed; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected to close %v; got: %v", want, have)
+	}
+	for i, body := range handler.bodies {
+		pit, _ := body["pit"].(map[string]interface{})
+		wantId := "pit-1"
+		if i > 0 {
+			wantId = fmt.Sprintf("pit-1-%d", i)
+		}
+		if pit["id"] != wantId || pit["keep_alive"] != "1m" {
+			t.Errorf("search #%d: expected pit with id %q; got: %v", i+1, wantId, body["pit"])
+		}
+		sort, _ := json.Marshal(body["sort"])
+		if want, have := `[{"user":{"order":"asc"}},"_shard_doc"]`, string(sort); want != have {
+			t.Errorf("search #%d: expected sort %s; got: %s", i+1, want, have)
+		}
+		searchAfter, _ := json.Marshal(body["search_after"])
+		if want, have := []string{`null`, `["olivere",1]`, `["olivere",3]`, `["olivere",4]`}[i], string(searchAfter); want != have {
+			t.Errorf("search #%d: expected search_after %s; got: %s", i+1, want, have)
+		}
+	}
+}
+
+func TestSearchAfterServiceWithPointInTimeId(t *testing.T) {
+	handler := &searchAfterTestServer{t: t, docs: 3}
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := client.SearchAfter().
+		PointInTimeId("existing").
+		KeepAlive("1m").
+		SortBy(SortByShardDoc{}).
+		Size(2)
+	res, err := svc.Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(res.Hits.Hits); want != have {
+		t.Fatalf("expected %d hits; got: %d", want, have)
+	}
+	if err := svc.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Do(context.Background()); err != io.EOF {
+		t.Fatalf("expected io.EOF after Close; got: %v", err)
+	}
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if handler.opened != 0 {
+		t.Errorf("expected no point in time to be opened; got: %d", handler.opened)
+	}
+	if len(handler.closed) != 0 {
+		t.Errorf("expected existing point in time not to be closed; got: %v", handler.closed)
+	}
+	sort, _ := json.Marshal(handler.bodies[0]["sort"])
+	if want, have := `["_shard_doc"]`, string(sort); want != have {
+		t.Errorf("expected sort %s; got: %s", want, have)
+	}
+}
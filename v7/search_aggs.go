@@ -4,6 +4,8 @@
 
 package elastic
 
+//go:generate easyjson -no_std_marshalers search_aggs.go
+
 import (
 	"bytes"
 	"encoding/json"
@@ -21,6 +23,7 @@ type Aggregation interface {
 }
 
 // Aggregations is a list of aggregations that are part of a search result.
+//easyjson:json
 type Aggregations map[string]json.RawMessage
 
 // Min returns min aggregation results.
@@ -0,0 +1,21 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "encoding/json"
+
+// RawAggregation is an aggregation given as JSON that is passed to
+// Elasticsearch unchanged. ParseAggregation returns a RawAggregation
+// for aggregations it cannot represent with one of the aggregation
+// builders.
+//
+// Example:
+//   agg := elastic.RawAggregation(`{"terms":{"field":"user"}}`)
+type RawAggregation json.RawMessage
+
+// Source returns the JSON of the aggregation.
+func (a RawAggregation) Source() (interface{}, error) {
+	return json.RawMessage(a), nil
+}
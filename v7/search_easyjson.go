@@ -0,0 +1,2358 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package elastic
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonD4176298DecodeGithubComFacertElasticV7(in *jlexer.Lexer, out *searchHit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "_score":
+			if in.IsNull() {
+				in.Skip()
+				out.Score = nil
+			} else {
+				if out.Score == nil {
+					out.Score = new(float64)
+				}
+				*out.Score = float64(in.Float64())
+			}
+		case "_index":
+			out.Index = string(in.String())
+		case "_type":
+			out.Type = string(in.String())
+		case "_id":
+			out.Id = string(in.String())
+		case "_uid":
+			out.Uid = string(in.String())
+		case "_routing":
+			out.Routing = string(in.String())
+		case "_parent":
+			out.Parent = string(in.String())
+		case "_version":
+			if in.IsNull() {
+				in.Skip()
+				out.Version = nil
+			} else {
+				if out.Version == nil {
+					out.Version = new(int64)
+				}
+				*out.Version = int64(in.Int64())
+			}
+		case "_seq_no":
+			if in.IsNull() {
+				in.Skip()
+				out.SeqNo = nil
+			} else {
+				if out.SeqNo == nil {
+					out.SeqNo = new(int64)
+				}
+				*out.SeqNo = int64(in.Int64())
+			}
+		case "_primary_term":
+			if in.IsNull() {
+				in.Skip()
+				out.PrimaryTerm = nil
+			} else {
+				if out.PrimaryTerm == nil {
+					out.PrimaryTerm = new(int64)
+				}
+				*out.PrimaryTerm = int64(in.Int64())
+			}
+		case "sort":
+			if in.IsNull() {
+				in.Skip()
+				out.Sort = nil
+			} else {
+				in.Delim('[')
+				if out.Sort == nil {
+					if !in.IsDelim(']') {
+						out.Sort = make([]interface{}, 0, 4)
+					} else {
+						out.Sort = []interface{}{}
+					}
+				} else {
+					out.Sort = (out.Sort)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 interface{}
+					if m, ok := v1.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v1.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v1 = in.Interface()
+					}
+					out.Sort = append(out.Sort, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "highlight":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Highlight = make(SearchHitHighlight)
+				} else {
+					out.Highlight = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v2 []string
+					if in.IsNull() {
+						in.Skip()
+						v2 = nil
+					} else {
+						in.Delim('[')
+						if v2 == nil {
+							if !in.IsDelim(']') {
+								v2 = make([]string, 0, 4)
+							} else {
+								v2 = []string{}
+							}
+						} else {
+							v2 = (v2)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v3 string
+							v3 = string(in.String())
+							v2 = append(v2, v3)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Highlight)[key] = v2
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "_source":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Source).UnmarshalJSON(data))
+			}
+		case "fields":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Fields = make(map[string]interface{})
+				} else {
+					out.Fields = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v4 interface{}
+					if m, ok := v4.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v4.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v4 = in.Interface()
+					}
+					(out.Fields)[key] = v4
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "_explanation":
+			if in.IsNull() {
+				in.Skip()
+				out.Explanation = nil
+			} else {
+				if out.Explanation == nil {
+					out.Explanation = new(SearchExplanation)
+				}
+				easyjsonD4176298DecodeGithubComFacertElasticV71(in, out.Explanation)
+			}
+		case "matched_queries":
+			if in.IsNull() {
+				in.Skip()
+				out.MatchedQueries = nil
+			} else {
+				in.Delim('[')
+				if out.MatchedQueries == nil {
+					if !in.IsDelim(']') {
+						out.MatchedQueries = make([]string, 0, 4)
+					} else {
+						out.MatchedQueries = []string{}
+					}
+				} else {
+					out.MatchedQueries = (out.MatchedQueries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.MatchedQueries = append(out.MatchedQueries, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "inner_hits":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.InnerHits = make(map[string]*SearchHitInnerHits)
+				} else {
+					out.InnerHits = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v6 *SearchHitInnerHits
+					if in.IsNull() {
+						in.Skip()
+						v6 = nil
+					} else {
+						if v6 == nil {
+							v6 = new(SearchHitInnerHits)
+						}
+						easyjsonD4176298DecodeGithubComFacertElasticV72(in, v6)
+					}
+					(out.InnerHits)[key] = v6
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "_nested":
+			if in.IsNull() {
+				in.Skip()
+				out.Nested = nil
+			} else {
+				if out.Nested == nil {
+					out.Nested = new(NestedHit)
+				}
+				easyjsonD4176298DecodeGithubComFacertElasticV73(in, out.Nested)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV7(out *jwriter.Writer, in searchHit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Score != nil {
+		const prefix string = ",\"_score\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Float64(float64(*in.Score))
+	}
+	if in.Index != "" {
+		const prefix string = ",\"_index\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Index))
+	}
+	if in.Type != "" {
+		const prefix string = ",\"_type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Type))
+	}
+	if in.Id != "" {
+		const prefix string = ",\"_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Id))
+	}
+	if in.Uid != "" {
+		const prefix string = ",\"_uid\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Uid))
+	}
+	if in.Routing != "" {
+		const prefix string = ",\"_routing\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Routing))
+	}
+	if in.Parent != "" {
+		const prefix string = ",\"_parent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Parent))
+	}
+	if in.Version != nil {
+		const prefix string = ",\"_version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(*in.Version))
+	}
+	{
+		const prefix string = ",\"_seq_no\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.SeqNo == nil {
+			out.RawString("null")
+		} else {
+			out.Int64(int64(*in.SeqNo))
+		}
+	}
+	{
+		const prefix string = ",\"_primary_term\":"
+		out.RawString(prefix)
+		if in.PrimaryTerm == nil {
+			out.RawString("null")
+		} else {
+			out.Int64(int64(*in.PrimaryTerm))
+		}
+	}
+	if len(in.Sort) != 0 {
+		const prefix string = ",\"sort\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v7, v8 := range in.Sort {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				if m, ok := v8.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v8.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v8))
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Highlight) != 0 {
+		const prefix string = ",\"highlight\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v9First := true
+			for v9Name, v9Value := range in.Highlight {
+				if v9First {
+					v9First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v9Name))
+				out.RawByte(':')
+				if v9Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v10, v11 := range v9Value {
+						if v10 > 0 {
+							out.RawByte(',')
+						}
+						out.String(string(v11))
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Source) != 0 {
+		const prefix string = ",\"_source\":"
+		out.RawString(prefix)
+		out.Raw((in.Source).MarshalJSON())
+	}
+	if len(in.Fields) != 0 {
+		const prefix string = ",\"fields\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v12First := true
+			for v12Name, v12Value := range in.Fields {
+				if v12First {
+					v12First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v12Name))
+				out.RawByte(':')
+				if m, ok := v12Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v12Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v12Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.Explanation != nil {
+		const prefix string = ",\"_explanation\":"
+		out.RawString(prefix)
+		easyjsonD4176298EncodeGithubComFacertElasticV71(out, *in.Explanation)
+	}
+	if len(in.MatchedQueries) != 0 {
+		const prefix string = ",\"matched_queries\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v13, v14 := range in.MatchedQueries {
+				if v13 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v14))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.InnerHits) != 0 {
+		const prefix string = ",\"inner_hits\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v15First := true
+			for v15Name, v15Value := range in.InnerHits {
+				if v15First {
+					v15First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v15Name))
+				out.RawByte(':')
+				if v15Value == nil {
+					out.RawString("null")
+				} else {
+					easyjsonD4176298EncodeGithubComFacertElasticV72(out, *v15Value)
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.Nested != nil {
+		const prefix string = ",\"_nested\":"
+		out.RawString(prefix)
+		easyjsonD4176298EncodeGithubComFacertElasticV73(out, *in.Nested)
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v searchHit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD4176298EncodeGithubComFacertElasticV7(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *searchHit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD4176298DecodeGithubComFacertElasticV7(l, v)
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV73(in *jlexer.Lexer, out *NestedHit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "field":
+			out.Field = string(in.String())
+		case "offset":
+			out.Offset = int(in.Int())
+		case "_nested":
+			if in.IsNull() {
+				in.Skip()
+				out.Child = nil
+			} else {
+				if out.Child == nil {
+					out.Child = new(NestedHit)
+				}
+				easyjsonD4176298DecodeGithubComFacertElasticV73(in, out.Child)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV73(out *jwriter.Writer, in NestedHit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"field\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Field))
+	}
+	if in.Offset != 0 {
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix)
+		out.Int(int(in.Offset))
+	}
+	if in.Child != nil {
+		const prefix string = ",\"_nested\":"
+		out.RawString(prefix)
+		easyjsonD4176298EncodeGithubComFacertElasticV73(out, *in.Child)
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV72(in *jlexer.Lexer, out *SearchHitInnerHits) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "hits":
+			if in.IsNull() {
+				in.Skip()
+				out.Hits = nil
+			} else {
+				if out.Hits == nil {
+					out.Hits = new(SearchHits)
+				}
+				(*out.Hits).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV72(out *jwriter.Writer, in SearchHitInnerHits) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Hits != nil {
+		const prefix string = ",\"hits\":"
+		first = false
+		out.RawString(prefix[1:])
+		(*in.Hits).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV71(in *jlexer.Lexer, out *SearchExplanation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "value":
+			out.Value = float64(in.Float64())
+		case "description":
+			out.Description = string(in.String())
+		case "details":
+			if in.IsNull() {
+				in.Skip()
+				out.Details = nil
+			} else {
+				in.Delim('[')
+				if out.Details == nil {
+					if !in.IsDelim(']') {
+						out.Details = make([]SearchExplanation, 0, 1)
+					} else {
+						out.Details = []SearchExplanation{}
+					}
+				} else {
+					out.Details = (out.Details)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 SearchExplanation
+					easyjsonD4176298DecodeGithubComFacertElasticV71(in, &v16)
+					out.Details = append(out.Details, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV71(out *jwriter.Writer, in SearchExplanation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"value\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.Value))
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	if len(in.Details) != 0 {
+		const prefix string = ",\"details\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v17, v18 := range in.Details {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD4176298EncodeGithubComFacertElasticV71(out, v18)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV74(in *jlexer.Lexer, out *TotalHits) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "value":
+			out.Value = int64(in.Int64())
+		case "relation":
+			out.Relation = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV74(out *jwriter.Writer, in TotalHits) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"value\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Value))
+	}
+	{
+		const prefix string = ",\"relation\":"
+		out.RawString(prefix)
+		out.String(string(in.Relation))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TotalHits) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD4176298EncodeGithubComFacertElasticV74(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TotalHits) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD4176298DecodeGithubComFacertElasticV74(l, v)
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV75(in *jlexer.Lexer, out *SearchResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "took":
+			out.TookInMillis = int64(in.Int64())
+		case "_scroll_id":
+			out.ScrollId = string(in.String())
+		case "hits":
+			if in.IsNull() {
+				in.Skip()
+				out.Hits = nil
+			} else {
+				if out.Hits == nil {
+					out.Hits = new(SearchHits)
+				}
+				(*out.Hits).UnmarshalEasyJSON(in)
+			}
+		case "suggest":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Suggest = make(SearchSuggest)
+				} else {
+					out.Suggest = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v19 []SearchSuggestion
+					if in.IsNull() {
+						in.Skip()
+						v19 = nil
+					} else {
+						in.Delim('[')
+						if v19 == nil {
+							if !in.IsDelim(']') {
+								v19 = make([]SearchSuggestion, 0, 1)
+							} else {
+								v19 = []SearchSuggestion{}
+							}
+						} else {
+							v19 = (v19)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v20 SearchSuggestion
+							easyjsonD4176298DecodeGithubComFacertElasticV76(in, &v20)
+							v19 = append(v19, v20)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Suggest)[key] = v19
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "aggregations":
+			(out.Aggregations).UnmarshalEasyJSON(in)
+		case "timed_out":
+			out.TimedOut = bool(in.Bool())
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+				out.Error = nil
+			} else {
+				if out.Error == nil {
+					out.Error = new(ErrorDetails)
+				}
+				easyjsonD4176298DecodeGithubComFacertElasticV77(in, out.Error)
+			}
+		case "profile":
+			if in.IsNull() {
+				in.Skip()
+				out.Profile = nil
+			} else {
+				if out.Profile == nil {
+					out.Profile = new(SearchProfile)
+				}
+				easyjsonD4176298DecodeGithubComFacertElasticV78(in, out.Profile)
+			}
+		case "_shards":
+			if in.IsNull() {
+				in.Skip()
+				out.Shards = nil
+			} else {
+				if out.Shards == nil {
+					out.Shards = new(ShardsInfo)
+				}
+				easyjsonD4176298DecodeGithubComFacertElasticV79(in, out.Shards)
+			}
+		case "pit_id":
+			out.PitId = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV75(out *jwriter.Writer, in SearchResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.TookInMillis != 0 {
+		const prefix string = ",\"took\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.TookInMillis))
+	}
+	if in.ScrollId != "" {
+		const prefix string = ",\"_scroll_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ScrollId))
+	}
+	if in.Hits != nil {
+		const prefix string = ",\"hits\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.Hits).MarshalEasyJSON(out)
+	}
+	if len(in.Suggest) != 0 {
+		const prefix string = ",\"suggest\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v21First := true
+			for v21Name, v21Value := range in.Suggest {
+				if v21First {
+					v21First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v21Name))
+				out.RawByte(':')
+				if v21Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v22, v23 := range v21Value {
+						if v22 > 0 {
+							out.RawByte(',')
+						}
+						easyjsonD4176298EncodeGithubComFacertElasticV76(out, v23)
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Aggregations) != 0 {
+		const prefix string = ",\"aggregations\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Aggregations).MarshalEasyJSON(out)
+	}
+	if in.TimedOut {
+		const prefix string = ",\"timed_out\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.TimedOut))
+	}
+	if in.Error != nil {
+		const prefix string = ",\"error\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjsonD4176298EncodeGithubComFacertElasticV77(out, *in.Error)
+	}
+	if in.Profile != nil {
+		const prefix string = ",\"profile\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjsonD4176298EncodeGithubComFacertElasticV78(out, *in.Profile)
+	}
+	if in.Shards != nil {
+		const prefix string = ",\"_shards\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjsonD4176298EncodeGithubComFacertElasticV79(out, *in.Shards)
+	}
+	if in.PitId != "" {
+		const prefix string = ",\"pit_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.PitId))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SearchResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD4176298EncodeGithubComFacertElasticV75(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SearchResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD4176298DecodeGithubComFacertElasticV75(l, v)
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV79(in *jlexer.Lexer, out *ShardsInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "total":
+			out.Total = int(in.Int())
+		case "successful":
+			out.Successful = int(in.Int())
+		case "failed":
+			out.Failed = int(in.Int())
+		case "failures":
+			if in.IsNull() {
+				in.Skip()
+				out.Failures = nil
+			} else {
+				in.Delim('[')
+				if out.Failures == nil {
+					if !in.IsDelim(']') {
+						out.Failures = make([]*ShardFailure, 0, 8)
+					} else {
+						out.Failures = []*ShardFailure{}
+					}
+				} else {
+					out.Failures = (out.Failures)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v24 *ShardFailure
+					if in.IsNull() {
+						in.Skip()
+						v24 = nil
+					} else {
+						if v24 == nil {
+							v24 = new(ShardFailure)
+						}
+						easyjsonD4176298DecodeGithubComFacertElasticV710(in, v24)
+					}
+					out.Failures = append(out.Failures, v24)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV79(out *jwriter.Writer, in ShardsInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Total))
+	}
+	{
+		const prefix string = ",\"successful\":"
+		out.RawString(prefix)
+		out.Int(int(in.Successful))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int(int(in.Failed))
+	}
+	if len(in.Failures) != 0 {
+		const prefix string = ",\"failures\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v25, v26 := range in.Failures {
+				if v25 > 0 {
+					out.RawByte(',')
+				}
+				if v26 == nil {
+					out.RawString("null")
+				} else {
+					easyjsonD4176298EncodeGithubComFacertElasticV710(out, *v26)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV710(in *jlexer.Lexer, out *ShardFailure) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "_index":
+			out.Index = string(in.String())
+		case "_shard":
+			out.Shard = int(in.Int())
+		case "_node":
+			out.Node = string(in.String())
+		case "reason":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Reason = make(map[string]interface{})
+				} else {
+					out.Reason = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v27 interface{}
+					if m, ok := v27.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v27.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v27 = in.Interface()
+					}
+					(out.Reason)[key] = v27
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "status":
+			out.Status = string(in.String())
+		case "primary":
+			out.Primary = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV710(out *jwriter.Writer, in ShardFailure) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Index != "" {
+		const prefix string = ",\"_index\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Index))
+	}
+	if in.Shard != 0 {
+		const prefix string = ",\"_shard\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Shard))
+	}
+	if in.Node != "" {
+		const prefix string = ",\"_node\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Node))
+	}
+	if len(in.Reason) != 0 {
+		const prefix string = ",\"reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v28First := true
+			for v28Name, v28Value := range in.Reason {
+				if v28First {
+					v28First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v28Name))
+				out.RawByte(':')
+				if m, ok := v28Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v28Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v28Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.Status != "" {
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Status))
+	}
+	if in.Primary {
+		const prefix string = ",\"primary\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Primary))
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV78(in *jlexer.Lexer, out *SearchProfile) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "shards":
+			if in.IsNull() {
+				in.Skip()
+				out.Shards = nil
+			} else {
+				in.Delim('[')
+				if out.Shards == nil {
+					if !in.IsDelim(']') {
+						out.Shards = make([]SearchProfileShardResult, 0, 1)
+					} else {
+						out.Shards = []SearchProfileShardResult{}
+					}
+				} else {
+					out.Shards = (out.Shards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v29 SearchProfileShardResult
+					easyjsonD4176298DecodeGithubComFacertElasticV711(in, &v29)
+					out.Shards = append(out.Shards, v29)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV78(out *jwriter.Writer, in SearchProfile) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"shards\":"
+		out.RawString(prefix[1:])
+		if in.Shards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v30, v31 := range in.Shards {
+				if v30 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD4176298EncodeGithubComFacertElasticV711(out, v31)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV711(in *jlexer.Lexer, out *SearchProfileShardResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "searches":
+			if in.IsNull() {
+				in.Skip()
+				out.Searches = nil
+			} else {
+				in.Delim('[')
+				if out.Searches == nil {
+					if !in.IsDelim(']') {
+						out.Searches = make([]QueryProfileShardResult, 0, 1)
+					} else {
+						out.Searches = []QueryProfileShardResult{}
+					}
+				} else {
+					out.Searches = (out.Searches)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v32 QueryProfileShardResult
+					easyjsonD4176298DecodeGithubComFacertElasticV712(in, &v32)
+					out.Searches = append(out.Searches, v32)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "aggregations":
+			if in.IsNull() {
+				in.Skip()
+				out.Aggregations = nil
+			} else {
+				in.Delim('[')
+				if out.Aggregations == nil {
+					if !in.IsDelim(']') {
+						out.Aggregations = make([]ProfileResult, 0, 1)
+					} else {
+						out.Aggregations = []ProfileResult{}
+					}
+				} else {
+					out.Aggregations = (out.Aggregations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v33 ProfileResult
+					easyjsonD4176298DecodeGithubComFacertElasticV713(in, &v33)
+					out.Aggregations = append(out.Aggregations, v33)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV711(out *jwriter.Writer, in SearchProfileShardResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"searches\":"
+		out.RawString(prefix)
+		if in.Searches == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v34, v35 := range in.Searches {
+				if v34 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD4176298EncodeGithubComFacertElasticV712(out, v35)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"aggregations\":"
+		out.RawString(prefix)
+		if in.Aggregations == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v36, v37 := range in.Aggregations {
+				if v36 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD4176298EncodeGithubComFacertElasticV713(out, v37)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV713(in *jlexer.Lexer, out *ProfileResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "time":
+			out.NodeTime = string(in.String())
+		case "time_in_nanos":
+			out.NodeTimeNanos = int64(in.Int64())
+		case "breakdown":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Breakdown = make(map[string]int64)
+				} else {
+					out.Breakdown = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v38 int64
+					v38 = int64(in.Int64())
+					(out.Breakdown)[key] = v38
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "children":
+			if in.IsNull() {
+				in.Skip()
+				out.Children = nil
+			} else {
+				in.Delim('[')
+				if out.Children == nil {
+					if !in.IsDelim(']') {
+						out.Children = make([]ProfileResult, 0, 1)
+					} else {
+						out.Children = []ProfileResult{}
+					}
+				} else {
+					out.Children = (out.Children)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v39 ProfileResult
+					easyjsonD4176298DecodeGithubComFacertElasticV713(in, &v39)
+					out.Children = append(out.Children, v39)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV713(out *jwriter.Writer, in ProfileResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	if in.NodeTime != "" {
+		const prefix string = ",\"time\":"
+		out.RawString(prefix)
+		out.String(string(in.NodeTime))
+	}
+	if in.NodeTimeNanos != 0 {
+		const prefix string = ",\"time_in_nanos\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.NodeTimeNanos))
+	}
+	if len(in.Breakdown) != 0 {
+		const prefix string = ",\"breakdown\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v40First := true
+			for v40Name, v40Value := range in.Breakdown {
+				if v40First {
+					v40First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v40Name))
+				out.RawByte(':')
+				out.Int64(int64(v40Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Children) != 0 {
+		const prefix string = ",\"children\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v41, v42 := range in.Children {
+				if v41 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD4176298EncodeGithubComFacertElasticV713(out, v42)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV712(in *jlexer.Lexer, out *QueryProfileShardResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "query":
+			if in.IsNull() {
+				in.Skip()
+				out.Query = nil
+			} else {
+				in.Delim('[')
+				if out.Query == nil {
+					if !in.IsDelim(']') {
+						out.Query = make([]ProfileResult, 0, 1)
+					} else {
+						out.Query = []ProfileResult{}
+					}
+				} else {
+					out.Query = (out.Query)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v43 ProfileResult
+					easyjsonD4176298DecodeGithubComFacertElasticV713(in, &v43)
+					out.Query = append(out.Query, v43)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "rewrite_time":
+			out.RewriteTime = int64(in.Int64())
+		case "collector":
+			if in.IsNull() {
+				in.Skip()
+				out.Collector = nil
+			} else {
+				in.Delim('[')
+				if out.Collector == nil {
+					if !in.IsDelim(']') {
+						out.Collector = make([]interface{}, 0, 4)
+					} else {
+						out.Collector = []interface{}{}
+					}
+				} else {
+					out.Collector = (out.Collector)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v44 interface{}
+					if m, ok := v44.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v44.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v44 = in.Interface()
+					}
+					out.Collector = append(out.Collector, v44)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV712(out *jwriter.Writer, in QueryProfileShardResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.Query) != 0 {
+		const prefix string = ",\"query\":"
+		first = false
+		out.RawString(prefix[1:])
+		{
+			out.RawByte('[')
+			for v45, v46 := range in.Query {
+				if v45 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD4176298EncodeGithubComFacertElasticV713(out, v46)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.RewriteTime != 0 {
+		const prefix string = ",\"rewrite_time\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.RewriteTime))
+	}
+	if len(in.Collector) != 0 {
+		const prefix string = ",\"collector\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v47, v48 := range in.Collector {
+				if v47 > 0 {
+					out.RawByte(',')
+				}
+				if m, ok := v48.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v48.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v48))
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV77(in *jlexer.Lexer, out *ErrorDetails) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "resource.type":
+			out.ResourceType = string(in.String())
+		case "resource.id":
+			out.ResourceId = string(in.String())
+		case "index":
+			out.Index = string(in.String())
+		case "phase":
+			out.Phase = string(in.String())
+		case "grouped":
+			out.Grouped = bool(in.Bool())
+		case "caused_by":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.CausedBy = make(map[string]interface{})
+				} else {
+					out.CausedBy = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v49 interface{}
+					if m, ok := v49.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v49.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v49 = in.Interface()
+					}
+					(out.CausedBy)[key] = v49
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "root_cause":
+			if in.IsNull() {
+				in.Skip()
+				out.RootCause = nil
+			} else {
+				in.Delim('[')
+				if out.RootCause == nil {
+					if !in.IsDelim(']') {
+						out.RootCause = make([]*ErrorDetails, 0, 8)
+					} else {
+						out.RootCause = []*ErrorDetails{}
+					}
+				} else {
+					out.RootCause = (out.RootCause)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v50 *ErrorDetails
+					if in.IsNull() {
+						in.Skip()
+						v50 = nil
+					} else {
+						if v50 == nil {
+							v50 = new(ErrorDetails)
+						}
+						easyjsonD4176298DecodeGithubComFacertElasticV77(in, v50)
+					}
+					out.RootCause = append(out.RootCause, v50)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "failed_shards":
+			if in.IsNull() {
+				in.Skip()
+				out.FailedShards = nil
+			} else {
+				in.Delim('[')
+				if out.FailedShards == nil {
+					if !in.IsDelim(']') {
+						out.FailedShards = make([]map[string]interface{}, 0, 8)
+					} else {
+						out.FailedShards = []map[string]interface{}{}
+					}
+				} else {
+					out.FailedShards = (out.FailedShards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v51 map[string]interface{}
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v51 = make(map[string]interface{})
+						} else {
+							v51 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v52 interface{}
+							if m, ok := v52.(easyjson.Unmarshaler); ok {
+								m.UnmarshalEasyJSON(in)
+							} else if m, ok := v52.(json.Unmarshaler); ok {
+								_ = m.UnmarshalJSON(in.Raw())
+							} else {
+								v52 = in.Interface()
+							}
+							(v51)[key] = v52
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					out.FailedShards = append(out.FailedShards, v51)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV77(out *jwriter.Writer, in ErrorDetails) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	if in.ResourceType != "" {
+		const prefix string = ",\"resource.type\":"
+		out.RawString(prefix)
+		out.String(string(in.ResourceType))
+	}
+	if in.ResourceId != "" {
+		const prefix string = ",\"resource.id\":"
+		out.RawString(prefix)
+		out.String(string(in.ResourceId))
+	}
+	if in.Index != "" {
+		const prefix string = ",\"index\":"
+		out.RawString(prefix)
+		out.String(string(in.Index))
+	}
+	if in.Phase != "" {
+		const prefix string = ",\"phase\":"
+		out.RawString(prefix)
+		out.String(string(in.Phase))
+	}
+	if in.Grouped {
+		const prefix string = ",\"grouped\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Grouped))
+	}
+	if len(in.CausedBy) != 0 {
+		const prefix string = ",\"caused_by\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v53First := true
+			for v53Name, v53Value := range in.CausedBy {
+				if v53First {
+					v53First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v53Name))
+				out.RawByte(':')
+				if m, ok := v53Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v53Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v53Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.RootCause) != 0 {
+		const prefix string = ",\"root_cause\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v54, v55 := range in.RootCause {
+				if v54 > 0 {
+					out.RawByte(',')
+				}
+				if v55 == nil {
+					out.RawString("null")
+				} else {
+					easyjsonD4176298EncodeGithubComFacertElasticV77(out, *v55)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.FailedShards) != 0 {
+		const prefix string = ",\"failed_shards\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v56, v57 := range in.FailedShards {
+				if v56 > 0 {
+					out.RawByte(',')
+				}
+				if v57 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+					out.RawString(`null`)
+				} else {
+					out.RawByte('{')
+					v58First := true
+					for v58Name, v58Value := range v57 {
+						if v58First {
+							v58First = false
+						} else {
+							out.RawByte(',')
+						}
+						out.String(string(v58Name))
+						out.RawByte(':')
+						if m, ok := v58Value.(easyjson.Marshaler); ok {
+							m.MarshalEasyJSON(out)
+						} else if m, ok := v58Value.(json.Marshaler); ok {
+							out.Raw(m.MarshalJSON())
+						} else {
+							out.Raw(json.Marshal(v58Value))
+						}
+					}
+					out.RawByte('}')
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV76(in *jlexer.Lexer, out *SearchSuggestion) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		case "offset":
+			out.Offset = int(in.Int())
+		case "length":
+			out.Length = int(in.Int())
+		case "options":
+			if in.IsNull() {
+				in.Skip()
+				out.Options = nil
+			} else {
+				in.Delim('[')
+				if out.Options == nil {
+					if !in.IsDelim(']') {
+						out.Options = make([]SearchSuggestionOption, 0, 1)
+					} else {
+						out.Options = []SearchSuggestionOption{}
+					}
+				} else {
+					out.Options = (out.Options)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v59 SearchSuggestionOption
+					easyjsonD4176298DecodeGithubComFacertElasticV714(in, &v59)
+					out.Options = append(out.Options, v59)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV76(out *jwriter.Writer, in SearchSuggestion) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	{
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix)
+		out.Int(int(in.Offset))
+	}
+	{
+		const prefix string = ",\"length\":"
+		out.RawString(prefix)
+		out.Int(int(in.Length))
+	}
+	{
+		const prefix string = ",\"options\":"
+		out.RawString(prefix)
+		if in.Options == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v60, v61 := range in.Options {
+				if v60 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD4176298EncodeGithubComFacertElasticV714(out, v61)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV714(in *jlexer.Lexer, out *SearchSuggestionOption) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		case "_index":
+			out.Index = string(in.String())
+		case "_type":
+			out.Type = string(in.String())
+		case "_id":
+			out.Id = string(in.String())
+		case "score":
+			out.Score = float64(in.Float64())
+		case "_score":
+			out.ScoreUnderscore = float64(in.Float64())
+		case "highlighted":
+			out.Highlighted = string(in.String())
+		case "collate_match":
+			out.CollateMatch = bool(in.Bool())
+		case "freq":
+			out.Freq = int(in.Int())
+		case "_source":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Source).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV714(out *jwriter.Writer, in SearchSuggestionOption) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	{
+		const prefix string = ",\"_index\":"
+		out.RawString(prefix)
+		out.String(string(in.Index))
+	}
+	{
+		const prefix string = ",\"_type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"_id\":"
+		out.RawString(prefix)
+		out.String(string(in.Id))
+	}
+	{
+		const prefix string = ",\"score\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Score))
+	}
+	{
+		const prefix string = ",\"_score\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ScoreUnderscore))
+	}
+	{
+		const prefix string = ",\"highlighted\":"
+		out.RawString(prefix)
+		out.String(string(in.Highlighted))
+	}
+	{
+		const prefix string = ",\"collate_match\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.CollateMatch))
+	}
+	{
+		const prefix string = ",\"freq\":"
+		out.RawString(prefix)
+		out.Int(int(in.Freq))
+	}
+	{
+		const prefix string = ",\"_source\":"
+		out.RawString(prefix)
+		out.Raw((in.Source).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+func easyjsonD4176298DecodeGithubComFacertElasticV715(in *jlexer.Lexer, out *SearchHits) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "total":
+			if in.IsNull() {
+				in.Skip()
+				out.TotalHits = nil
+			} else {
+				if out.TotalHits == nil {
+					out.TotalHits = new(TotalHits)
+				}
+				(*out.TotalHits).UnmarshalEasyJSON(in)
+			}
+		case "max_score":
+			if in.IsNull() {
+				in.Skip()
+				out.MaxScore = nil
+			} else {
+				if out.MaxScore == nil {
+					out.MaxScore = new(float64)
+				}
+				*out.MaxScore = float64(in.Float64())
+			}
+		case "hits":
+			if in.IsNull() {
+				in.Skip()
+				out.Hits = nil
+			} else {
+				in.Delim('[')
+				if out.Hits == nil {
+					if !in.IsDelim(']') {
+						out.Hits = make([]*SearchHit, 0, 8)
+					} else {
+						out.Hits = []*SearchHit{}
+					}
+				} else {
+					out.Hits = (out.Hits)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v62 *SearchHit
+					if in.IsNull() {
+						in.Skip()
+						v62 = nil
+					} else {
+						if v62 == nil {
+							v62 = new(SearchHit)
+						}
+						(*v62).UnmarshalEasyJSON(in)
+					}
+					out.Hits = append(out.Hits, v62)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD4176298EncodeGithubComFacertElasticV715(out *jwriter.Writer, in SearchHits) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.TotalHits != nil {
+		const prefix string = ",\"total\":"
+		first = false
+		out.RawString(prefix[1:])
+		(*in.TotalHits).MarshalEasyJSON(out)
+	}
+	if in.MaxScore != nil {
+		const prefix string = ",\"max_score\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(*in.MaxScore))
+	}
+	if len(in.Hits) != 0 {
+		const prefix string = ",\"hits\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v63, v64 := range in.Hits {
+				if v63 > 0 {
+					out.RawByte(',')
+				}
+				if v64 == nil {
+					out.RawString("null")
+				} else {
+					(*v64).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SearchHits) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD4176298EncodeGithubComFacertElasticV715(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SearchHits) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD4176298DecodeGithubComFacertElasticV715(l, v)
+}
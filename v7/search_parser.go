@@ -0,0 +1,5396 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ParseQuery parses the JSON of a query, e.g. {"bool":{"must":...}}, into
+// the corresponding query builder, e.g. a *BoolQuery. Nested queries are
+// parsed as well.
+//
+// Query nodes that cannot be represented with one of the query builders,
+// e.g. because the query type or one of its parameters is not supported,
+// are returned as a RawStringQuery. The result of ParseQuery hence always
+// serializes to an equivalent query, although not necessarily to the
+// exact same JSON: e.g. shorthand forms are expanded.
+//
+// Example:
+//   q, err := elastic.ParseQuery([]byte(`{"bool":{"must":{"term":{"user":"olivere"}}}}`))
+//   if err != nil { ... }
+//   if boolQuery, ok := q.(*elastic.BoolQuery); ok {
+//     boolQuery = boolQuery.Filter(elastic.NewTermQuery("retweets", 0))
+//   }
+func ParseQuery(data []byte) (Query, error) {
+	v, err := decodeDSL(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := v.(map[string]interface{}); !ok {
+		return nil, errors.New("elastic: query must be a JSON object")
+	}
+	return parseQuery(v), nil
+}
+
+// ParseAggregation parses the JSON of a single aggregation, e.g.
+// {"terms":{"field":"user"},"aggs":{...}}, into the corresponding
+// aggregation builder, e.g. a *TermsAggregation. Sub-aggregations and
+// queries used in the aggregation are parsed as well.
+//
+// Aggregations that cannot be represented with one of the aggregation
+// builders are returned as a RawAggregation.
+func ParseAggregation(data []byte) (Aggregation, error) {
+	v, err := decodeDSL(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := v.(map[string]interface{}); !ok {
+		return nil, errors.New("elastic: aggregation must be a JSON object")
+	}
+	return parseAggregation(v), nil
+}
+
+// ParseAggregations parses the JSON of the "aggs" section of a search
+// request, i.e. an object of aggregations by name, into aggregation
+// builders. See ParseAggregation for details.
+func ParseAggregations(data []byte) (map[string]Aggregation, error) {
+	v, err := decodeDSL(data)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("elastic: aggregations must be a JSON object")
+	}
+	return parseAggregations(m), nil
+}
+
+// ParseSearchSource parses the JSON body of a search request into a
+// SearchSource. Queries, aggregations and sort clauses are parsed with
+// the same rules as ParseQuery and ParseAggregation, and sort clauses
+// that cannot be represented by a sorter are returned as a RawSorter.
+//
+// Top-level keys that are not supported by the parser, e.g. "highlight"
+// or "suggest", are kept as they are and serialized again by the Source
+// method of the SearchSource, unless they are overwritten with one of
+// the setters of SearchSource.
+func ParseSearchSource(data []byte) (*SearchSource, error) {
+	v, err := decodeDSL(data)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("elastic: search source must be a JSON object")
+	}
+	return parseSearchSource(m), nil
+}
+
+// parseSearchSource parses the body of a search request, or of inner hits.
+func parseSearchSource(m map[string]interface{}) *SearchSource {
+	ss := NewSearchSource()
+	for key, value := range m {
+		if parser, found := searchSourceParsers[key]; found && parser(ss, value) {
+			continue
+		}
+		if ss.unparsed == nil {
+			ss.unparsed = make(map[string]interface{})
+		}
+		ss.unparsed[key] = value
+	}
+	return ss
+}
+
+// decodeDSL decodes data into generic JSON values. Numbers are decoded
+// as json.Number to retain their exact representation.
+func decodeDSL(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("elastic: unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+// -- Helpers --
+
+// dslObject helps parsing a JSON object. It keeps track of the keys
+// that have been read and whether all values had the expected type,
+// so that parsers can tell whether a builder represents the object
+// completely.
+type dslObject struct {
+	m    map[string]interface{}
+	used map[string]bool
+	ok   bool
+}
+
+// newDSLObject returns a dslObject if v is a JSON object.
+func newDSLObject(v interface{}) (*dslObject, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return &dslObject{m: m, used: make(map[string]bool), ok: true}, true
+}
+
+// complete returns true if all keys have been read and all values
+// had the expected type.
+func (o *dslObject) complete() bool {
+	return o.ok && len(o.used) == len(o.m)
+}
+
+// value returns the value of key and marks it as read.
+func (o *dslObject) value(key string) (interface{}, bool) {
+	v, found := o.m[key]
+	if found {
+		o.used[key] = true
+	}
+	return v, found
+}
+
+// field returns the single key that has not been read yet, together with
+// its value. It is used for queries like {"term":{"user":"olivere"}}.
+func (o *dslObject) field() (string, interface{}, bool) {
+	if len(o.m)-len(o.used) != 1 {
+		o.ok = false
+		return "", nil, false
+	}
+	for k, v := range o.m {
+		if !o.used[k] {
+			o.used[k] = true
+			return k, v, true
+		}
+	}
+	return "", nil, false
+}
+
+func (o *dslObject) str(key string) (string, bool) {
+	v, found := o.value(key)
+	if !found {
+		return "", false
+	}
+	s, ok := v.(string)
+	if !ok {
+		o.ok = false
+	}
+	return s, ok
+}
+
+// strOrNumber returns the value of key as a string if it is a string
+// or a number, e.g. for "minimum_should_match".
+func (o *dslObject) strOrNumber(key string) (string, bool) {
+	v, found := o.value(key)
+	if !found {
+		return "", false
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	}
+	o.ok = false
+	return "", false
+}
+
+func (o *dslObject) float(key string) (float64, bool) {
+	v, found := o.value(key)
+	if !found {
+		return 0, false
+	}
+	if n, ok := v.(json.Number); ok {
+		if f, err := n.Float64(); err == nil {
+			return f, true
+		}
+	}
+	o.ok = false
+	return 0, false
+}
+
+func (o *dslObject) int(key string) (int, bool) {
+	v, found := o.value(key)
+	if !found {
+		return 0, false
+	}
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return int(i), true
+		}
+	}
+	o.ok = false
+	return 0, false
+}
+
+func (o *dslObject) boolean(key string) (bool, bool) {
+	v, found := o.value(key)
+	if !found {
+		return false, false
+	}
+	b, ok := v.(bool)
+	if !ok {
+		o.ok = false
+	}
+	return b, ok
+}
+
+func (o *dslObject) object(key string) (map[string]interface{}, bool) {
+	v, found := o.value(key)
+	if !found {
+		return nil, false
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		o.ok = false
+	}
+	return m, ok
+}
+
+func (o *dslObject) array(key string) ([]interface{}, bool) {
+	v, found := o.value(key)
+	if !found {
+		return nil, false
+	}
+	a, ok := v.([]interface{})
+	if !ok {
+		o.ok = false
+	}
+	return a, ok
+}
+
+// strings returns the value of key as a list of strings. A single
+// string is returned as a list with one element.
+func (o *dslObject) strings(key string) ([]string, bool) {
+	v, found := o.value(key)
+	if !found {
+		return nil, false
+	}
+	list, ok := dslStrings(v)
+	if !ok {
+		o.ok = false
+	}
+	return list, ok
+}
+
+// floats returns the value of key as a list of numbers.
+func (o *dslObject) floats(key string) ([]float64, bool) {
+	v, found := o.array(key)
+	if !found {
+		return nil, false
+	}
+	list := make([]float64, 0, len(v))
+	for _, n := range v {
+		n, ok := n.(json.Number)
+		if !ok {
+			o.ok = false
+			return nil, false
+		}
+		f, err := n.Float64()
+		if err != nil {
+			o.ok = false
+			return nil, false
+		}
+		list = append(list, f)
+	}
+	return list, true
+}
+
+// query returns the value of key parsed as a query.
+func (o *dslObject) query(key string) (Query, bool) {
+	v, found := o.object(key)
+	if !found {
+		return nil, false
+	}
+	return parseQuery(v), true
+}
+
+// queries returns the value of key parsed as a list of queries. A single
+// query is returned as a list with one element.
+func (o *dslObject) queries(key string) ([]Query, bool) {
+	v, found := o.value(key)
+	if !found {
+		return nil, false
+	}
+	var list []interface{}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		list = []interface{}{v}
+	case []interface{}:
+		list = v
+	default:
+		o.ok = false
+		return nil, false
+	}
+	queries := make([]Query, 0, len(list))
+	for _, q := range list {
+		if _, ok := q.(map[string]interface{}); !ok {
+			o.ok = false
+			return nil, false
+		}
+		queries = append(queries, parseQuery(q))
+	}
+	return queries, true
+}
+
+// script returns the value of key parsed as a script.
+func (o *dslObject) script(key string) (*Script, bool) {
+	v, found := o.value(key)
+	if !found {
+		return nil, false
+	}
+	script, ok := parseScript(v)
+	if !ok {
+		o.ok = false
+	}
+	return script, ok
+}
+
+// dslStrings converts a string or a list of strings to a list of strings.
+func dslStrings(v interface{}) ([]string, bool) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, true
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, s := range v {
+			s, ok := s.(string)
+			if !ok {
+				return nil, false
+			}
+			list = append(list, s)
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// dslNumber converts a json.Number into an int64 or a float64, for
+// builders that only serialize numbers of Go's built-in types.
+func dslNumber(v interface{}) interface{} {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return v
+}
+
+// dslOrder parses a single sort order like {"_count":"desc"}.
+func dslOrder(v interface{}) (string, bool, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return "", false, false
+	}
+	for field, order := range m {
+		switch order {
+		case "asc":
+			return field, true, true
+		case "desc":
+			return field, false, true
+		}
+	}
+	return "", false, false
+}
+
+// parseScript parses a script in its short form, e.g. "doc['n'].value",
+// or as an object with "source" or "id", "lang", and "params".
+func parseScript(v interface{}) (*Script, bool) {
+	if s, ok := v.(string); ok {
+		return NewScript(s), true
+	}
+	o, ok := newDSLObject(v)
+	if !ok {
+		return nil, false
+	}
+	var script *Script
+	if source, found := o.str("source"); found {
+		// Script serializes sources starting with { or " as raw JSON
+		if v := strings.TrimSpace(source); strings.HasPrefix(v, "{") || strings.HasPrefix(v, `"`) {
+			return nil, false
+		}
+		script = NewScriptInline(source)
+	} else if id, found := o.str("id"); found {
+		script = NewScriptStored(id)
+	} else {
+		return nil, false
+	}
+	if v, found := o.str("lang"); found {
+		script = script.Lang(v)
+	}
+	if v, found := o.object("params"); found {
+		script = script.Params(v)
+	}
+	return script, o.complete()
+}
+
+// parseGeoPoint parses a geo point given as an object with "lat" and
+// "lon", as an array of [lon, lat], or as a "lat,lon" string.
+func parseGeoPoint(v interface{}) (*GeoPoint, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		o, _ := newDSLObject(v)
+		lat, hasLat := o.float("lat")
+		lon, hasLon := o.float("lon")
+		if !hasLat || !hasLon || !o.complete() {
+			return nil, false
+		}
+		return GeoPointFromLatLon(lat, lon), true
+	case []interface{}:
+		if len(v) != 2 {
+			return nil, false
+		}
+		o, _ := newDSLObject(map[string]interface{}{"lon": v[0], "lat": v[1]})
+		lat, hasLat := o.float("lat")
+		lon, hasLon := o.float("lon")
+		if !hasLat || !hasLon || !o.complete() {
+			return nil, false
+		}
+		return GeoPointFromLatLon(lat, lon), true
+	case string:
+		point, err := GeoPointFromString(v)
+		if err != nil {
+			return nil, false
+		}
+		return point, true
+	}
+	return nil, false
+}
+
+// parseInnerHit parses the inner hits of a nested, has_child, has_parent,
+// or parent_id query. Apart from "name", inner hits are parsed like the
+// body of a search request.
+func parseInnerHit(v interface{}) (*InnerHit, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	hit := NewInnerHit()
+	body := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		if key == "name" {
+			name, ok := value.(string)
+			if !ok {
+				return nil, false
+			}
+			hit = hit.Name(name)
+			continue
+		}
+		body[key] = value
+	}
+	hit.source = parseSearchSource(body)
+	return hit, true
+}
+
+// parseFetchSourceContext parses the value of "_source", i.e. a boolean,
+// one or more include patterns, or an object with "includes" and
+// "excludes".
+func parseFetchSourceContext(v interface{}) (*FetchSourceContext, bool) {
+	switch v := v.(type) {
+	case bool:
+		return NewFetchSourceContext(v), true
+	case string, []interface{}:
+		includes, ok := dslStrings(v)
+		if !ok {
+			return nil, false
+		}
+		return NewFetchSourceContext(true).Include(includes...), true
+	case map[string]interface{}:
+		o, _ := newDSLObject(v)
+		includes, _ := o.strings("includes")
+		excludes, _ := o.strings("excludes")
+		if !o.complete() {
+			return nil, false
+		}
+		return NewFetchSourceContext(true).Include(includes...).Exclude(excludes...), true
+	}
+	return nil, false
+}
+
+// -- Queries --
+
+// queryParser parses the body of a query, i.e. the value below the
+// name of the query. It returns false if the body cannot be represented
+// with the query builder.
+type queryParser func(body interface{}) (Query, bool)
+
+var queryParsers map[string]queryParser
+
+func init() {
+	// Initialized here as parsers of compound queries use parseQuery
+	queryParsers = map[string]queryParser{
+		"bool":                parseBoolQuery,
+		"boosting":            parseBoostingQuery,
+		"common":              parseCommonTermsQuery,
+		"constant_score":      parseConstantScoreQuery,
+		"dis_max":             parseDisMaxQuery,
+		"distance_feature":    parseDistanceFeatureQuery,
+		"exists":              parseExistsQuery,
+		"field_masking_span":  parseFieldMaskingSpanQuery,
+		"function_score":      parseFunctionScoreQuery,
+		"fuzzy":               parseFuzzyQuery,
+		"geo_bounding_box":    parseGeoBoundingBoxQuery,
+		"geo_distance":        parseGeoDistanceQuery,
+		"geo_polygon":         parseGeoPolygonQuery,
+		"geo_shape":           parseGeoShapeQuery,
+		"has_child":           parseHasChildQuery,
+		"has_parent":          parseHasParentQuery,
+		"ids":                 parseIdsQuery,
+		"intervals":           parseIntervalsQuery,
+		"match":               parseMatchQuery,
+		"match_all":           parseMatchAllQuery,
+		"match_none":          parseMatchNoneQuery,
+		"match_phrase":        parseMatchPhraseQuery,
+		"match_phrase_prefix": parseMatchPhrasePrefixQuery,
+		"more_like_this":      parseMoreLikeThisQuery,
+		"multi_match":         parseMultiMatchQuery,
+		"nested":              parseNestedQuery,
+		"parent_id":           parseParentIdQuery,
+		"percolate":           parsePercolateQuery,
+		"pinned":              parsePinnedQuery,
+		"prefix":              parsePrefixQuery,
+		"query_string":        parseQueryStringQuery,
+		"range":               parseRangeQuery,
+		"rank_feature":        parseRankFeatureQuery,
+		"regexp":              parseRegexpQuery,
+		"script":              parseScriptQuery,
+		"script_score":        parseScriptScoreQuery,
+		"shape":               parseShapeQuery,
+		"simple_query_string": parseSimpleQueryStringQuery,
+		"span_containing":     parseSpanContainingQuery,
+		"span_first":          parseSpanFirstQuery,
+		"span_multi":          parseSpanMultiTermQuery,
+		"span_near":           parseSpanNearQuery,
+		"span_not":            parseSpanNotQuery,
+		"span_or":             parseSpanOrQuery,
+		"span_term":           parseSpanTermQuery,
+		"span_within":         parseSpanWithinQuery,
+		"term":                parseTermQuery,
+		"terms":               parseTermsQuery,
+		"terms_set":           parseTermsSetQuery,
+		"type":                parseTypeQuery,
+		"wildcard":            parseWildcardQuery,
+		"wrapper":             parseWrapperQuery,
+	}
+}
+
+// parseQuery parses v into a query builder, or into a RawStringQuery if
+// there is no builder that can represent it.
+func parseQuery(v interface{}) Query {
+	if m, ok := v.(map[string]interface{}); ok && len(m) == 1 {
+		for name, body := range m {
+			if parser, found := queryParsers[name]; found {
+				if q, ok := parser(body); ok {
+					return q
+				}
+			}
+		}
+	}
+	data, _ := json.Marshal(v)
+	return RawStringQuery(data)
+}
+
+func parseBoolQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	q := NewBoolQuery()
+	if v, found := o.queries("must"); found {
+		q = q.Must(v...)
+	}
+	if v, found := o.queries("must_not"); found {
+		q = q.MustNot(v...)
+	}
+	if v, found := o.queries("filter"); found {
+		q = q.Filter(v...)
+	}
+	if v, found := o.queries("should"); found {
+		q = q.Should(v...)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.strOrNumber("minimum_should_match"); found {
+		q = q.MinimumShouldMatch(v)
+	}
+	if v, found := o.boolean("adjust_pure_negative"); found {
+		q = q.AdjustPureNegative(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseBoostingQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	q := NewBoostingQuery()
+	if v, found := o.query("positive"); found {
+		q = q.Positive(v)
+	}
+	if v, found := o.query("negative"); found {
+		q = q.Negative(v)
+	}
+	if v, found := o.float("negative_boost"); found {
+		q = q.NegativeBoost(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	return q, o.complete()
+}
+
+func parseCommonTermsQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return nil, false
+	}
+	text, found := p.value("query")
+	if !found {
+		return nil, false
+	}
+	q := NewCommonTermsQuery(field, text)
+	if v, found := p.float("cutoff_frequency"); found {
+		q = q.CutoffFrequency(v)
+	}
+	if v, found := p.float("high_freq"); found {
+		q = q.HighFreq(v)
+	}
+	if v, found := p.str("high_freq_operator"); found {
+		q = q.HighFreqOperator(v)
+	}
+	if v, found := p.float("low_freq"); found {
+		q = q.LowFreq(v)
+	}
+	if v, found := p.str("low_freq_operator"); found {
+		q = q.LowFreqOperator(v)
+	}
+	if v, found := p.object("minimum_should_match"); found {
+		mm, _ := newDSLObject(v)
+		if v, found := mm.str("low_freq"); found {
+			q = q.LowFreqMinimumShouldMatch(v)
+		}
+		if v, found := mm.str("high_freq"); found {
+			q = q.HighFreqMinimumShouldMatch(v)
+		}
+		if !mm.complete() {
+			return nil, false
+		}
+	}
+	if v, found := p.str("analyzer"); found {
+		q = q.Analyzer(v)
+	}
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseConstantScoreQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	filter, found := o.query("filter")
+	if !found {
+		return nil, false
+	}
+	q := NewConstantScoreQuery(filter)
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	return q, o.complete()
+}
+
+func parseDisMaxQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	queries, found := o.array("queries")
+	if !found || len(queries) == 0 {
+		return nil, false
+	}
+	q := NewDisMaxQuery()
+	if v, found := o.queries("queries"); found {
+		q = q.Query(v...)
+	}
+	if v, found := o.float("tie_breaker"); found {
+		q = q.TieBreaker(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseDistanceFeatureQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, found := o.str("field")
+	if !found {
+		return nil, false
+	}
+	origin, found := o.value("origin")
+	if !found {
+		return nil, false
+	}
+	pivot, found := o.str("pivot")
+	if !found {
+		return nil, false
+	}
+	q := NewDistanceFeatureQuery(field, origin, pivot)
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseExistsQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, found := o.str("field")
+	if !found {
+		return nil, false
+	}
+	q := NewExistsQuery(field)
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseFieldMaskingSpanQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	query, found := o.query("query")
+	if !found {
+		return nil, false
+	}
+	field, found := o.str("field")
+	if !found {
+		return nil, false
+	}
+	q := NewFieldMaskingSpanQuery(query, field)
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseFunctionScoreQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	q := NewFunctionScoreQuery()
+	if v, found := o.query("query"); found {
+		q = q.Query(v)
+	}
+	if v, found := o.query("filter"); found {
+		q = q.Filter(v)
+	}
+	if list, found := o.array("functions"); found {
+		for _, v := range list {
+			filter, fn, ok := parseScoreFunctionEntry(v)
+			if !ok {
+				return nil, false
+			}
+			if filter != nil {
+				q = q.Add(filter, fn)
+			} else {
+				q = q.AddScoreFunc(fn)
+			}
+		}
+	}
+	if v, found := o.str("score_mode"); found {
+		q = q.ScoreMode(v)
+	}
+	if v, found := o.str("boost_mode"); found {
+		q = q.BoostMode(v)
+	}
+	if v, found := o.float("max_boost"); found {
+		q = q.MaxBoost(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.float("min_score"); found {
+		q = q.MinScore(v)
+	}
+	return q, o.complete()
+}
+
+// parseScoreFunctionEntry parses an element of the functions of a
+// function_score query, i.e. a score function with an optional filter
+// and weight. An element with a weight only is a WeightFactorFunction.
+func parseScoreFunctionEntry(v interface{}) (Query, ScoreFunction, bool) {
+	o, ok := newDSLObject(v)
+	if !ok {
+		return nil, nil, false
+	}
+	filter, _ := o.query("filter")
+	weight, hasWeight := o.float("weight")
+	if len(o.used) == len(o.m) {
+		if !hasWeight || !o.complete() {
+			return nil, nil, false
+		}
+		return filter, NewWeightFactorFunction(weight), true
+	}
+	name, body, ok := o.field()
+	if !ok {
+		return nil, nil, false
+	}
+	var fn ScoreFunction
+	switch name {
+	case "exp":
+		d, ok := parseDecayFunction(body)
+		if !ok {
+			return nil, nil, false
+		}
+		f := NewExponentialDecayFunction().FieldName(d.field).Origin(d.origin).Scale(d.scale).Offset(d.offset).MultiValueMode(d.multiValueMode)
+		if d.decay != nil {
+			f = f.Decay(*d.decay)
+		}
+		if hasWeight {
+			f = f.Weight(weight)
+		}
+		fn = f
+	case "gauss":
+		d, ok := parseDecayFunction(body)
+		if !ok {
+			return nil, nil, false
+		}
+		f := NewGaussDecayFunction().FieldName(d.field).Origin(d.origin).Scale(d.scale).Offset(d.offset).MultiValueMode(d.multiValueMode)
+		if d.decay != nil {
+			f = f.Decay(*d.decay)
+		}
+		if hasWeight {
+			f = f.Weight(weight)
+		}
+		fn = f
+	case "linear":
+		d, ok := parseDecayFunction(body)
+		if !ok {
+			return nil, nil, false
+		}
+		f := NewLinearDecayFunction().FieldName(d.field).Origin(d.origin).Scale(d.scale).Offset(d.offset).MultiValueMode(d.multiValueMode)
+		if d.decay != nil {
+			f = f.Decay(*d.decay)
+		}
+		if hasWeight {
+			f = f.Weight(weight)
+		}
+		fn = f
+	case "script_score":
+		p, ok := newDSLObject(body)
+		if !ok {
+			return nil, nil, false
+		}
+		script, found := p.script("script")
+		if !found || !p.complete() {
+			return nil, nil, false
+		}
+		f := NewScriptFunction(script)
+		if hasWeight {
+			f = f.Weight(weight)
+		}
+		fn = f
+	case "field_value_factor":
+		p, ok := newDSLObject(body)
+		if !ok {
+			return nil, nil, false
+		}
+		f := NewFieldValueFactorFunction()
+		if v, found := p.str("field"); found {
+			f = f.Field(v)
+		}
+		if v, found := p.float("factor"); found {
+			f = f.Factor(v)
+		}
+		if v, found := p.float("missing"); found {
+			f = f.Missing(v)
+		}
+		if v, found := p.str("modifier"); found {
+			f = f.Modifier(v)
+		}
+		if hasWeight {
+			f = f.Weight(weight)
+		}
+		if !p.complete() {
+			return nil, nil, false
+		}
+		fn = f
+	case "random_score":
+		p, ok := newDSLObject(body)
+		if !ok {
+			return nil, nil, false
+		}
+		f := NewRandomFunction()
+		if v, found := p.str("field"); found {
+			f = f.Field(v)
+		}
+		if v, found := p.value("seed"); found {
+			f = f.Seed(v)
+		}
+		if hasWeight {
+			f = f.Weight(weight)
+		}
+		if !p.complete() {
+			return nil, nil, false
+		}
+		fn = f
+	default:
+		return nil, nil, false
+	}
+	return filter, fn, o.complete()
+}
+
+// dslDecayFunction holds the parameters of a decay function, which are
+// the same for ExponentialDecayFunction, GaussDecayFunction, and
+// LinearDecayFunction.
+type dslDecayFunction struct {
+	field          string
+	origin         interface{}
+	scale          interface{}
+	decay          *float64
+	offset         interface{}
+	multiValueMode string
+}
+
+// parseDecayFunction parses the body of a decay function, e.g.
+// {"date":{"origin":"now","scale":"10d"},"multi_value_mode":"avg"}.
+func parseDecayFunction(v interface{}) (dslDecayFunction, bool) {
+	var d dslDecayFunction
+	o, ok := newDSLObject(v)
+	if !ok {
+		return d, false
+	}
+	d.multiValueMode, _ = o.str("multi_value_mode")
+	field, params, ok := o.field()
+	if !ok {
+		return d, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return d, false
+	}
+	d.field = field
+	if d.scale, ok = p.value("scale"); !ok {
+		return d, false
+	}
+	d.origin, _ = p.value("origin")
+	d.offset, _ = p.value("offset")
+	if v, found := p.float("decay"); found {
+		if v <= 0 {
+			return d, false // not serialized by the decay functions
+		}
+		d.decay = &v
+	}
+	return d, o.complete() && p.complete()
+}
+
+func parseFuzzyQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return NewFuzzyQuery(field, params), true
+	}
+	value, found := p.value("value")
+	if !found {
+		return nil, false
+	}
+	q := NewFuzzyQuery(field, value)
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.value("fuzziness"); found {
+		q = q.Fuzziness(v)
+	}
+	if v, found := p.int("prefix_length"); found {
+		q = q.PrefixLength(v)
+	}
+	if v, found := p.int("max_expansions"); found {
+		q = q.MaxExpansions(v)
+	}
+	if v, found := p.boolean("transpositions"); found {
+		q = q.Transpositions(v)
+	}
+	if v, found := p.str("rewrite"); found {
+		q = q.Rewrite(v)
+	}
+	if v, found := p.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseGeoBoundingBoxQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	typ, hasType := o.str("type")
+	queryName, hasQueryName := o.str("_name")
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return nil, false
+	}
+	q := NewGeoBoundingBoxQuery(field)
+	if v, found := p.value("top_left"); found {
+		point, ok := parseGeoPoint(v)
+		if !ok {
+			return nil, false
+		}
+		q = q.TopLeftFromGeoPoint(point)
+	}
+	if v, found := p.value("bottom_right"); found {
+		point, ok := parseGeoPoint(v)
+		if !ok {
+			return nil, false
+		}
+		q = q.BottomRightFromGeoPoint(point)
+	}
+	if v, found := p.value("top_right"); found {
+		point, ok := parseGeoPoint(v)
+		if !ok {
+			return nil, false
+		}
+		q = q.TopRightFromGeoPoint(point)
+	}
+	if v, found := p.value("bottom_left"); found {
+		point, ok := parseGeoPoint(v)
+		if !ok {
+			return nil, false
+		}
+		q = q.BottomLeftFromGeoPoint(point)
+	}
+	if q.top == nil || q.left == nil || q.bottom == nil || q.right == nil {
+		return nil, false
+	}
+	if hasType {
+		q = q.Type(typ)
+	}
+	if hasQueryName {
+		q = q.QueryName(queryName)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseGeoDistanceQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	distance, hasDistance := o.str("distance")
+	distanceType, hasDistanceType := o.str("distance_type")
+	queryName, hasQueryName := o.str("_name")
+	field, location, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	q := NewGeoDistanceQuery(field)
+	if s, ok := location.(string); ok {
+		// A geohash or a "lat,lon" string is serialized as it is
+		q = q.GeoHash(s)
+	} else {
+		point, ok := parseGeoPoint(location)
+		if !ok {
+			return nil, false
+		}
+		q = q.GeoPoint(point)
+	}
+	if hasDistance {
+		q = q.Distance(distance)
+	}
+	if hasDistanceType {
+		q = q.DistanceType(distanceType)
+	}
+	if hasQueryName {
+		q = q.QueryName(queryName)
+	}
+	return q, o.complete()
+}
+
+func parseGeoPolygonQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	queryName, hasQueryName := o.str("_name")
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return nil, false
+	}
+	points, found := p.array("points")
+	if !found {
+		return nil, false
+	}
+	q := NewGeoPolygonQuery(field)
+	for _, v := range points {
+		point, ok := parseGeoPoint(v)
+		if !ok {
+			return nil, false
+		}
+		q = q.AddGeoPoint(point)
+	}
+	if hasQueryName {
+		q = q.QueryName(queryName)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseGeoShapeQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	ignoreUnmapped, hasIgnoreUnmapped := o.boolean("ignore_unmapped")
+	boost, hasBoost := o.float("boost")
+	queryName, hasQueryName := o.str("_name")
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	shape, indexedShape, relation, ok := parseShapeQueryField(params)
+	if !ok {
+		return nil, false
+	}
+	q := NewGeoShapeQuery(field).Relation(relation)
+	if shape != nil {
+		q = q.Shape(shape)
+	}
+	if indexedShape != nil {
+		q = q.IndexedShape(indexedShape)
+	}
+	if hasIgnoreUnmapped {
+		q = q.IgnoreUnmapped(ignoreUnmapped)
+	}
+	if hasBoost {
+		q = q.Boost(boost)
+	}
+	if hasQueryName {
+		q = q.QueryName(queryName)
+	}
+	return q, o.complete()
+}
+
+// parseShapeQueryField parses the field of a geo_shape or shape query.
+// Shapes given as WKT are not supported.
+func parseShapeQueryField(v interface{}) (GeoShapeGeometry, *IndexedShape, string, bool) {
+	o, ok := newDSLObject(v)
+	if !ok {
+		return nil, nil, "", false
+	}
+	var shape GeoShapeGeometry
+	var indexedShape *IndexedShape
+	if v, found := o.value("shape"); found {
+		if shape, ok = parseGeoShapeGeometry(v); !ok {
+			return nil, nil, "", false
+		}
+	} else if v, found := o.object("indexed_shape"); found {
+		if indexedShape, ok = parseIndexedShape(v); !ok {
+			return nil, nil, "", false
+		}
+	} else {
+		return nil, nil, "", false
+	}
+	relation, _ := o.str("relation")
+	return shape, indexedShape, relation, o.complete()
+}
+
+// parseIndexedShape parses a reference to a shape indexed in another
+// document.
+func parseIndexedShape(v interface{}) (*IndexedShape, bool) {
+	o, ok := newDSLObject(v)
+	if !ok {
+		return nil, false
+	}
+	index, found := o.str("index")
+	if !found {
+		return nil, false
+	}
+	id, found := o.str("id")
+	if !found {
+		return nil, false
+	}
+	s := NewIndexedShape(index, id)
+	if v, found := o.str("path"); found {
+		s = s.Path(v)
+	}
+	if v, found := o.str("routing"); found {
+		s = s.Routing(v)
+	}
+	return s, o.complete()
+}
+
+// parseGeoShapeGeometry parses a shape in GeoJSON. Types are only
+// accepted in the spelling the geometry types serialize them with,
+// e.g. "Polygon" but not "polygon".
+func parseGeoShapeGeometry(v interface{}) (GeoShapeGeometry, bool) {
+	o, ok := newDSLObject(v)
+	if !ok {
+		return nil, false
+	}
+	typ, found := o.str("type")
+	if !found {
+		return nil, false
+	}
+	if typ == "GeometryCollection" {
+		list, found := o.array("geometries")
+		if !found {
+			return nil, false
+		}
+		gc := make(GeoShapeGeometryCollection, 0, len(list))
+		for _, v := range list {
+			g, ok := parseGeoShapeGeometry(v)
+			if !ok {
+				return nil, false
+			}
+			gc = append(gc, g)
+		}
+		return gc, o.complete()
+	}
+	coords, found := o.value("coordinates")
+	if !found {
+		return nil, false
+	}
+	var shape GeoShapeGeometry
+	switch typ {
+	case "Point":
+		shape, ok = parseGeoShapePoint(coords)
+	case "LineString":
+		var points []GeoShapePoint
+		points, ok = parseGeoShapePoints(coords)
+		shape = GeoShapeLineString(points)
+	case "Polygon":
+		shape, ok = parseGeoShapePolygon(coords)
+	case "MultiPolygon":
+		list, isList := coords.([]interface{})
+		if !isList {
+			return nil, false
+		}
+		mp := make(GeoShapeMultiPolygon, 0, len(list))
+		for _, v := range list {
+			p, ok := parseGeoShapePolygon(v)
+			if !ok {
+				return nil, false
+			}
+			mp = append(mp, p)
+		}
+		shape = mp
+	case "envelope":
+		var points []GeoShapePoint
+		points, ok = parseGeoShapePoints(coords)
+		if ok && len(points) != 2 {
+			return nil, false
+		}
+		if ok {
+			shape = NewGeoShapeEnvelope(points[0], points[1])
+		}
+	case "circle":
+		radius, found := o.str("radius")
+		if !found {
+			return nil, false
+		}
+		var center GeoShapePoint
+		center, ok = parseGeoShapePoint(coords)
+		shape = NewGeoShapeCircle(center, radius)
+	default:
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	return shape, o.complete()
+}
+
+// parseGeoShapePoint parses a GeoJSON position, i.e. [lon, lat].
+func parseGeoShapePoint(v interface{}) (GeoShapePoint, bool) {
+	list, ok := v.([]interface{})
+	if !ok || len(list) != 2 {
+		return GeoShapePoint{}, false
+	}
+	lon, ok := list[0].(json.Number)
+	if !ok {
+		return GeoShapePoint{}, false
+	}
+	lat, ok := list[1].(json.Number)
+	if !ok {
+		return GeoShapePoint{}, false
+	}
+	x, err := lon.Float64()
+	if err != nil {
+		return GeoShapePoint{}, false
+	}
+	y, err := lat.Float64()
+	if err != nil {
+		return GeoShapePoint{}, false
+	}
+	return NewGeoShapePoint(y, x), true
+}
+
+// parseGeoShapePoints parses a list of GeoJSON positions.
+func parseGeoShapePoints(v interface{}) ([]GeoShapePoint, bool) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	points := make([]GeoShapePoint, 0, len(list))
+	for _, v := range list {
+		p, ok := parseGeoShapePoint(v)
+		if !ok {
+			return nil, false
+		}
+		points = append(points, p)
+	}
+	return points, true
+}
+
+// parseGeoShapePolygon parses the linear rings of a GeoJSON polygon.
+func parseGeoShapePolygon(v interface{}) (GeoShapePolygon, bool) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	p := make(GeoShapePolygon, 0, len(list))
+	for _, v := range list {
+		ring, ok := parseGeoShapePoints(v)
+		if !ok {
+			return nil, false
+		}
+		p = append(p, ring)
+	}
+	return p, true
+}
+
+func parseHasChildQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	typ, found := o.str("type")
+	if !found {
+		return nil, false
+	}
+	query, found := o.query("query")
+	if !found {
+		return nil, false
+	}
+	q := NewHasChildQuery(typ, query)
+	if v, found := o.str("score_mode"); found {
+		q = q.ScoreMode(v)
+	}
+	if v, found := o.int("min_children"); found {
+		q = q.MinChildren(v)
+	}
+	if v, found := o.int("max_children"); found {
+		q = q.MaxChildren(v)
+	}
+	if v, found := o.int("short_circuit_cutoff"); found {
+		q = q.ShortCircuitCutoff(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	if v, found := o.value("inner_hits"); found {
+		hit, ok := parseInnerHit(v)
+		if !ok {
+			return nil, false
+		}
+		q = q.InnerHit(hit)
+	}
+	return q, o.complete()
+}
+
+func parseHasParentQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	parentType, found := o.str("parent_type")
+	if !found {
+		return nil, false
+	}
+	query, found := o.query("query")
+	if !found {
+		return nil, false
+	}
+	q := NewHasParentQuery(parentType, query)
+	if v, found := o.boolean("score"); found {
+		q = q.Score(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.boolean("ignore_unmapped"); found {
+		q = q.IgnoreUnmapped(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	if v, found := o.value("inner_hits"); found {
+		hit, ok := parseInnerHit(v)
+		if !ok {
+			return nil, false
+		}
+		q = q.InnerHit(hit)
+	}
+	return q, o.complete()
+}
+
+func parseIdsQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	values, found := o.strings("values")
+	if !found {
+		return nil, false
+	}
+	var types []string
+	if v, found := o.str("type"); found {
+		types = append(types, v)
+	}
+	if v, found := o.strings("types"); found {
+		types = append(types, v...)
+	}
+	q := NewIdsQuery(types...).Ids(values...)
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseIntervalsQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return nil, false
+	}
+	boost, hasBoost := p.float("boost")
+	queryName, hasQueryName := p.str("_name")
+	name, ruleBody, ok := p.field()
+	if !ok {
+		return nil, false
+	}
+	rule, ok := parseIntervalsRuleBody(name, ruleBody)
+	if !ok {
+		return nil, false
+	}
+	q := NewIntervalsQuery(field, rule)
+	if hasBoost {
+		q = q.Boost(boost)
+	}
+	if hasQueryName {
+		q = q.QueryName(queryName)
+	}
+	return q, o.complete() && p.complete()
+}
+
+// parseIntervalsRule parses a rule of an intervals query, e.g.
+// {"match":{"query":"hot water"}}.
+func parseIntervalsRule(v interface{}) (IntervalsRule, bool) {
+	o, ok := newDSLObject(v)
+	if !ok {
+		return nil, false
+	}
+	name, body, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	return parseIntervalsRuleBody(name, body)
+}
+
+// parseIntervalsRules parses a list of rules of an intervals query.
+func parseIntervalsRules(list []interface{}) ([]IntervalsRule, bool) {
+	rules := make([]IntervalsRule, 0, len(list))
+	for _, v := range list {
+		rule, ok := parseIntervalsRule(v)
+		if !ok {
+			return nil, false
+		}
+		rules = append(rules, rule)
+	}
+	return rules, true
+}
+
+// parseIntervalsRuleBody parses the body of the intervals rule with
+// the given name.
+func parseIntervalsRuleBody(name string, body interface{}) (IntervalsRule, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	switch name {
+	case "match":
+		query, found := o.str("query")
+		if !found {
+			return nil, false
+		}
+		r := NewIntervalsMatchRule(query)
+		if v, found := o.int("max_gaps"); found {
+			r = r.MaxGaps(v)
+		}
+		if v, found := o.boolean("ordered"); found {
+			r = r.Ordered(v)
+		}
+		if v, found := o.str("analyzer"); found {
+			r = r.Analyzer(v)
+		}
+		if v, found := o.str("use_field"); found {
+			r = r.UseField(v)
+		}
+		if v, found := o.value("filter"); found {
+			filter, ok := parseIntervalsFilter(v)
+			if !ok {
+				return nil, false
+			}
+			r = r.Filter(filter)
+		}
+		return r, o.complete()
+	case "prefix":
+		prefix, found := o.str("prefix")
+		if !found {
+			return nil, false
+		}
+		r := NewIntervalsPrefixRule(prefix)
+		if v, found := o.str("analyzer"); found {
+			r = r.Analyzer(v)
+		}
+		if v, found := o.str("use_field"); found {
+			r = r.UseField(v)
+		}
+		return r, o.complete()
+	case "wildcard":
+		pattern, found := o.str("pattern")
+		if !found {
+			return nil, false
+		}
+		r := NewIntervalsWildcardRule(pattern)
+		if v, found := o.str("analyzer"); found {
+			r = r.Analyzer(v)
+		}
+		if v, found := o.str("use_field"); found {
+			r = r.UseField(v)
+		}
+		return r, o.complete()
+	case "fuzzy":
+		term, found := o.str("term")
+		if !found {
+			return nil, false
+		}
+		r := NewIntervalsFuzzyRule(term)
+		if v, found := o.int("prefix_length"); found {
+			r = r.PrefixLength(v)
+		}
+		if v, found := o.boolean("transpositions"); found {
+			r = r.Transpositions(v)
+		}
+		if v, found := o.value("fuzziness"); found {
+			r = r.Fuzziness(v)
+		}
+		if v, found := o.str("analyzer"); found {
+			r = r.Analyzer(v)
+		}
+		if v, found := o.str("use_field"); found {
+			r = r.UseField(v)
+		}
+		return r, o.complete()
+	case "all_of":
+		list, found := o.array("intervals")
+		if !found {
+			return nil, false
+		}
+		rules, ok := parseIntervalsRules(list)
+		if !ok {
+			return nil, false
+		}
+		r := NewIntervalsAllOfRule(rules...)
+		if v, found := o.int("max_gaps"); found {
+			r = r.MaxGaps(v)
+		}
+		if v, found := o.boolean("ordered"); found {
+			r = r.Ordered(v)
+		}
+		if v, found := o.value("filter"); found {
+			filter, ok := parseIntervalsFilter(v)
+			if !ok {
+				return nil, false
+			}
+			r = r.Filter(filter)
+		}
+		return r, o.complete()
+	case "any_of":
+		list, found := o.array("intervals")
+		if !found {
+			return nil, false
+		}
+		rules, ok := parseIntervalsRules(list)
+		if !ok {
+			return nil, false
+		}
+		r := NewIntervalsAnyOfRule(rules...)
+		if v, found := o.value("filter"); found {
+			filter, ok := parseIntervalsFilter(v)
+			if !ok {
+				return nil, false
+			}
+			r = r.Filter(filter)
+		}
+		return r, o.complete()
+	}
+	return nil, false
+}
+
+// parseIntervalsFilter parses the filter of an intervals rule.
+func parseIntervalsFilter(v interface{}) (*IntervalsFilter, bool) {
+	o, ok := newDSLObject(v)
+	if !ok {
+		return nil, false
+	}
+	f := NewIntervalsFilter()
+	setters := []struct {
+		name string
+		set  func(IntervalsRule) *IntervalsFilter
+	}{
+		{"after", f.After},
+		{"before", f.Before},
+		{"contained_by", f.ContainedBy},
+		{"containing", f.Containing},
+		{"not_contained_by", f.NotContainedBy},
+		{"not_containing", f.NotContaining},
+		{"not_overlapping", f.NotOverlapping},
+		{"overlapping", f.Overlapping},
+	}
+	for _, s := range setters {
+		if v, found := o.value(s.name); found {
+			rule, ok := parseIntervalsRule(v)
+			if !ok {
+				return nil, false
+			}
+			s.set(rule)
+		}
+	}
+	if v, found := o.script("script"); found {
+		f = f.Script(v)
+	}
+	return f, o.complete()
+}
+
+func parseMatchQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return NewMatchQuery(field, params), true
+	}
+	text, found := p.value("query")
+	if !found {
+		return nil, false
+	}
+	q := NewMatchQuery(field, text)
+	if v, found := p.str("operator"); found {
+		q = q.Operator(v)
+	}
+	if v, found := p.str("analyzer"); found {
+		q = q.Analyzer(v)
+	}
+	if v, found := p.str("fuzziness"); found {
+		q = q.Fuzziness(v)
+	}
+	if v, found := p.int("prefix_length"); found {
+		q = q.PrefixLength(v)
+	}
+	if v, found := p.int("max_expansions"); found {
+		q = q.MaxExpansions(v)
+	}
+	if v, found := p.strOrNumber("minimum_should_match"); found {
+		q = q.MinimumShouldMatch(v)
+	}
+	if v, found := p.str("fuzzy_rewrite"); found {
+		q = q.FuzzyRewrite(v)
+	}
+	if v, found := p.boolean("fuzzy_transpositions"); found {
+		q = q.FuzzyTranspositions(v)
+	}
+	if v, found := p.boolean("lenient"); found {
+		q = q.Lenient(v)
+	}
+	if v, found := p.str("zero_terms_query"); found {
+		q = q.ZeroTermsQuery(v)
+	}
+	if v, found := p.float("cutoff_frequency"); found {
+		q = q.CutoffFrequency(v)
+	}
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseMatchAllQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	q := NewMatchAllQuery()
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseMatchNoneQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	q := NewMatchNoneQuery()
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseMatchPhraseQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return NewMatchPhraseQuery(field, params), true
+	}
+	value, found := p.value("query")
+	if !found {
+		return nil, false
+	}
+	q := NewMatchPhraseQuery(field, value)
+	if v, found := p.str("analyzer"); found {
+		q = q.Analyzer(v)
+	}
+	if v, found := p.int("slop"); found {
+		q = q.Slop(v)
+	}
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseMatchPhrasePrefixQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return NewMatchPhrasePrefixQuery(field, params), true
+	}
+	value, found := p.value("query")
+	if !found {
+		return nil, false
+	}
+	q := NewMatchPhrasePrefixQuery(field, value)
+	if v, found := p.str("analyzer"); found {
+		q = q.Analyzer(v)
+	}
+	if v, found := p.int("slop"); found {
+		q = q.Slop(v)
+	}
+	if v, found := p.int("max_expansions"); found {
+		q = q.MaxExpansions(v)
+	}
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseMoreLikeThisQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	q := NewMoreLikeThisQuery()
+	like, found := o.value("like")
+	if !found {
+		return nil, false
+	}
+	items, ok := parseMoreLikeThisItems(like)
+	if !ok || len(items) == 0 {
+		return nil, false
+	}
+	q = q.LikeItems(items...)
+	if v, found := o.value("unlike"); found {
+		items, ok := parseMoreLikeThisItems(v)
+		if !ok {
+			return nil, false
+		}
+		q = q.IgnoreLikeItems(items...)
+	}
+	if v, found := o.strings("fields"); found {
+		q = q.Field(v...)
+	}
+	if v, found := o.strings("stop_words"); found {
+		q = q.StopWord(v...)
+	}
+	if v, found := o.boolean("include"); found {
+		q = q.Include(v)
+	}
+	if v, found := o.strOrNumber("minimum_should_match"); found {
+		q = q.MinimumShouldMatch(v)
+	}
+	if v, found := o.int("min_term_freq"); found {
+		q = q.MinTermFreq(v)
+	}
+	if v, found := o.int("max_query_terms"); found {
+		q = q.MaxQueryTerms(v)
+	}
+	if v, found := o.int("min_doc_freq"); found {
+		q = q.MinDocFreq(v)
+	}
+	if v, found := o.int("max_doc_freq"); found {
+		q = q.MaxDocFreq(v)
+	}
+	if v, found := o.int("min_word_length"); found {
+		q = q.MinWordLength(v)
+	}
+	if v, found := o.int("max_word_length"); found {
+		q = q.MaxWordLength(v)
+	}
+	if v, found := o.float("boost_terms"); found {
+		q = q.BoostTerms(v)
+	}
+	if v, found := o.str("analyzer"); found {
+		q = q.Analyzer(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.boolean("fail_on_unsupported_field"); found {
+		q = q.FailOnUnsupportedField(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+// parseMoreLikeThisItems parses the value of "like" or "unlike" of a
+// more_like_this query, i.e. a text, a document, or a list of both.
+func parseMoreLikeThisItems(v interface{}) ([]*MoreLikeThisQueryItem, bool) {
+	list, ok := v.([]interface{})
+	if !ok {
+		list = []interface{}{v}
+	}
+	items := make([]*MoreLikeThisQueryItem, 0, len(list))
+	for _, v := range list {
+		switch v := v.(type) {
+		case string:
+			if v == "" {
+				return nil, false // not serialized by MoreLikeThisQueryItem
+			}
+			items = append(items, NewMoreLikeThisQueryItem().LikeText(v))
+		case map[string]interface{}:
+			item, ok := parseMoreLikeThisItem(v)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, item)
+		default:
+			return nil, false
+		}
+	}
+	return items, true
+}
+
+func parseMoreLikeThisItem(v interface{}) (*MoreLikeThisQueryItem, bool) {
+	o, ok := newDSLObject(v)
+	if !ok {
+		return nil, false
+	}
+	item := NewMoreLikeThisQueryItem()
+	if v, found := o.str("_index"); found {
+		item = item.Index(v)
+	}
+	if v, found := o.str("_type"); found {
+		item = item.Type(v)
+	}
+	if v, found := o.str("_id"); found {
+		item = item.Id(v)
+	}
+	if v, found := o.value("doc"); found {
+		item = item.Doc(v)
+	}
+	if v, found := o.strings("fields"); found {
+		item = item.Fields(v...)
+	}
+	if v, found := o.str("_routing"); found {
+		item = item.Routing(v)
+	}
+	if v, found := o.value("_source"); found {
+		fsc, ok := parseFetchSourceContext(v)
+		if !ok {
+			return nil, false
+		}
+		item = item.FetchSourceContext(fsc)
+	}
+	if v, found := o.int("_version"); found {
+		item = item.Version(int64(v))
+	}
+	if v, found := o.str("_version_type"); found {
+		item = item.VersionType(v)
+	}
+	return item, o.complete()
+}
+
+func parseMultiMatchQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	text, found := o.value("query")
+	if !found {
+		return nil, false
+	}
+	q := NewMultiMatchQuery(text)
+	if v, found := o.strings("fields"); found {
+		for _, field := range v {
+			q = q.Field(field)
+		}
+	}
+	if v, found := o.str("type"); found {
+		q = q.Type(v)
+		if q.typ != v {
+			return nil, false // not a type supported by MultiMatchQuery
+		}
+		// Type sets a default tie breaker that is not part of the input
+		q.tieBreaker = nil
+	}
+	if v, found := o.str("operator"); found {
+		q = q.Operator(v)
+	}
+	if v, found := o.str("analyzer"); found {
+		q = q.Analyzer(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.int("slop"); found {
+		q = q.Slop(v)
+	}
+	if v, found := o.str("fuzziness"); found {
+		q = q.Fuzziness(v)
+	}
+	if v, found := o.int("prefix_length"); found {
+		q = q.PrefixLength(v)
+	}
+	if v, found := o.int("max_expansions"); found {
+		q = q.MaxExpansions(v)
+	}
+	if v, found := o.strOrNumber("minimum_should_match"); found {
+		q = q.MinimumShouldMatch(v)
+	}
+	if v, found := o.str("rewrite"); found {
+		q = q.Rewrite(v)
+	}
+	if v, found := o.str("fuzzy_rewrite"); found {
+		q = q.FuzzyRewrite(v)
+	}
+	if v, found := o.float("tie_breaker"); found {
+		q = q.TieBreaker(v)
+	}
+	if v, found := o.boolean("lenient"); found {
+		q = q.Lenient(v)
+	}
+	if v, found := o.float("cutoff_frequency"); found {
+		q = q.CutoffFrequency(v)
+	}
+	if v, found := o.str("zero_terms_query"); found {
+		q = q.ZeroTermsQuery(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseNestedQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	path, found := o.str("path")
+	if !found {
+		return nil, false
+	}
+	query, found := o.query("query")
+	if !found {
+		return nil, false
+	}
+	q := NewNestedQuery(path, query)
+	if v, found := o.str("score_mode"); found {
+		q = q.ScoreMode(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.boolean("ignore_unmapped"); found {
+		q = q.IgnoreUnmapped(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	if v, found := o.value("inner_hits"); found {
+		hit, ok := parseInnerHit(v)
+		if !ok {
+			return nil, false
+		}
+		q = q.InnerHit(hit)
+	}
+	return q, o.complete()
+}
+
+func parseParentIdQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	typ, found := o.str("type")
+	if !found {
+		return nil, false
+	}
+	id, found := o.str("id")
+	if !found {
+		return nil, false
+	}
+	q := NewParentIdQuery(typ, id)
+	if v, found := o.boolean("ignore_unmapped"); found {
+		q = q.IgnoreUnmapped(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	if v, found := o.value("inner_hits"); found {
+		hit, ok := parseInnerHit(v)
+		if !ok {
+			return nil, false
+		}
+		q = q.InnerHit(hit)
+	}
+	return q, o.complete()
+}
+
+func parsePercolateQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, found := o.str("field")
+	if !found || field == "" {
+		return nil, false
+	}
+	q := NewPercolatorQuery().Field(field)
+	if v, found := o.str("document_type"); found {
+		q = q.DocumentType(v)
+	}
+	if v, found := o.str("name"); found {
+		q = q.Name(v)
+	}
+	doc, hasDocument := o.value("document")
+	docs, hasDocuments := o.array("documents")
+	switch {
+	case hasDocument && hasDocuments:
+		return nil, false
+	case hasDocument:
+		q = q.Document(doc)
+	case hasDocuments:
+		q = q.Document(docs...)
+	}
+	if v, found := o.str("index"); found {
+		q = q.IndexedDocumentIndex(v)
+	}
+	if v, found := o.str("type"); found {
+		q = q.IndexedDocumentType(v)
+	}
+	if v, found := o.str("id"); found {
+		q = q.IndexedDocumentId(v)
+	}
+	if v, found := o.str("routing"); found {
+		q = q.IndexedDocumentRouting(v)
+	}
+	if v, found := o.str("preference"); found {
+		q = q.IndexedDocumentPreference(v)
+	}
+	if v, found := o.int("version"); found {
+		q = q.IndexedDocumentVersion(int64(v))
+	}
+	return q, o.complete()
+}
+
+func parsePinnedQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	ids, found := o.strings("ids")
+	if !found {
+		return nil, false
+	}
+	organic, found := o.query("organic")
+	if !found {
+		return nil, false
+	}
+	q := NewPinnedQuery(organic, ids...)
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parsePrefixQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	if prefix, ok := params.(string); ok {
+		return NewPrefixQuery(field, prefix), true
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return nil, false
+	}
+	prefix, found := p.str("value")
+	if !found {
+		if prefix, found = p.str("prefix"); !found {
+			return nil, false
+		}
+	}
+	q := NewPrefixQuery(field, prefix)
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.str("rewrite"); found {
+		q = q.Rewrite(v)
+	}
+	if v, found := p.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseQueryStringQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	queryString, found := o.str("query")
+	if !found {
+		return nil, false
+	}
+	q := NewQueryStringQuery(queryString)
+	if v, found := o.str("default_field"); found {
+		q = q.DefaultField(v)
+	}
+	if v, found := o.strings("fields"); found {
+		for _, field := range v {
+			q = q.Field(field)
+		}
+	}
+	if v, found := o.str("type"); found {
+		q = q.Type(v)
+	}
+	if v, found := o.float("tie_breaker"); found {
+		q = q.TieBreaker(v)
+	}
+	if v, found := o.str("default_operator"); found {
+		q = q.DefaultOperator(v)
+	}
+	if v, found := o.str("analyzer"); found {
+		q = q.Analyzer(v)
+	}
+	if v, found := o.str("quote_analyzer"); found {
+		q = q.QuoteAnalyzer(v)
+	}
+	if v, found := o.int("max_determinized_states"); found {
+		q = q.MaxDeterminizedState(v)
+	}
+	if v, found := o.boolean("allow_leading_wildcard"); found {
+		q = q.AllowLeadingWildcard(v)
+	}
+	if v, found := o.boolean("enable_position_increments"); found {
+		q = q.EnablePositionIncrements(v)
+	}
+	if v, found := o.str("fuzziness"); found {
+		q = q.Fuzziness(v)
+	}
+	if v, found := o.int("fuzzy_prefix_length"); found {
+		q = q.FuzzyPrefixLength(v)
+	}
+	if v, found := o.int("fuzzy_max_expansions"); found {
+		q = q.FuzzyMaxExpansions(v)
+	}
+	if v, found := o.str("fuzzy_rewrite"); found {
+		q = q.FuzzyRewrite(v)
+	}
+	if v, found := o.int("phrase_slop"); found {
+		q = q.PhraseSlop(v)
+	}
+	if v, found := o.boolean("analyze_wildcard"); found {
+		q = q.AnalyzeWildcard(v)
+	}
+	if v, found := o.str("rewrite"); found {
+		q = q.Rewrite(v)
+	}
+	if v, found := o.strOrNumber("minimum_should_match"); found {
+		q = q.MinimumShouldMatch(v)
+	}
+	if v, found := o.str("quote_field_suffix"); found {
+		q = q.QuoteFieldSuffix(v)
+	}
+	if v, found := o.boolean("lenient"); found {
+		q = q.Lenient(v)
+	}
+	if v, found := o.str("time_zone"); found {
+		q = q.TimeZone(v)
+	}
+	if v, found := o.boolean("escape"); found {
+		q = q.Escape(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseRangeQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	queryName, hasQueryName := o.str("_name")
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return nil, false
+	}
+	q := NewRangeQuery(field)
+	if v, found := p.value("from"); found {
+		q = q.From(v)
+	}
+	if v, found := p.value("to"); found {
+		q = q.To(v)
+	}
+	if v, found := p.value("gt"); found {
+		q = q.Gt(v)
+	}
+	if v, found := p.value("gte"); found {
+		q = q.Gte(v)
+	}
+	if v, found := p.value("lt"); found {
+		q = q.Lt(v)
+	}
+	if v, found := p.value("lte"); found {
+		q = q.Lte(v)
+	}
+	if v, found := p.boolean("include_lower"); found {
+		q = q.IncludeLower(v)
+	}
+	if v, found := p.boolean("include_upper"); found {
+		q = q.IncludeUpper(v)
+	}
+	if v, found := p.str("time_zone"); found {
+		q = q.TimeZone(v)
+	}
+	if v, found := p.str("format"); found {
+		q = q.Format(v)
+	}
+	if v, found := p.str("relation"); found {
+		q = q.Relation(v)
+	}
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if hasQueryName {
+		q = q.QueryName(queryName)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseRankFeatureQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, found := o.str("field")
+	if !found {
+		return nil, false
+	}
+	q := NewRankFeatureQuery(field)
+	var funcs int
+	if v, found := o.object("saturation"); found {
+		p, _ := newDSLObject(v)
+		f := NewRankFeatureSaturationScoreFunction()
+		if v, found := p.float("pivot"); found {
+			f = f.Pivot(v)
+		}
+		if !p.complete() {
+			return nil, false
+		}
+		q = q.ScoreFunction(f)
+		funcs++
+	}
+	if v, found := o.object("log"); found {
+		p, _ := newDSLObject(v)
+		scalingFactor, found := p.float("scaling_factor")
+		if !found || !p.complete() {
+			return nil, false
+		}
+		q = q.ScoreFunction(NewRankFeatureLogScoreFunction(scalingFactor))
+		funcs++
+	}
+	if v, found := o.object("sigmoid"); found {
+		p, _ := newDSLObject(v)
+		pivot, found := p.float("pivot")
+		if !found {
+			return nil, false
+		}
+		exponent, found := p.float("exponent")
+		if !found || !p.complete() {
+			return nil, false
+		}
+		q = q.ScoreFunction(NewRankFeatureSigmoidScoreFunction(pivot, exponent))
+		funcs++
+	}
+	if v, found := o.object("linear"); found {
+		if len(v) > 0 {
+			return nil, false
+		}
+		q = q.ScoreFunction(NewRankFeatureLinearScoreFunction())
+		funcs++
+	}
+	if funcs > 1 {
+		return nil, false
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseRegexpQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	if regexp, ok := params.(string); ok {
+		return NewRegexpQuery(field, regexp), true
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return nil, false
+	}
+	regexp, found := p.str("value")
+	if !found {
+		return nil, false
+	}
+	q := NewRegexpQuery(field, regexp)
+	if v, found := p.str("flags"); found {
+		q = q.Flags(v)
+	}
+	if v, found := p.int("max_determinized_states"); found {
+		q = q.MaxDeterminizedStates(v)
+	}
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.str("rewrite"); found {
+		q = q.Rewrite(v)
+	}
+	// RegexpQuery serializes its query name as "name", so "_name" is
+	// left to a RawStringQuery
+	return q, o.complete() && p.complete()
+}
+
+func parseScriptQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	script, found := o.script("script")
+	if !found {
+		return nil, false
+	}
+	q := NewScriptQuery(script)
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseScriptScoreQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	query, found := o.query("query")
+	if !found {
+		return nil, false
+	}
+	script, found := o.script("script")
+	if !found {
+		return nil, false
+	}
+	q := NewScriptScoreQuery(query, script)
+	if v, found := o.float("min_score"); found {
+		q = q.MinScore(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseShapeQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	ignoreUnmapped, hasIgnoreUnmapped := o.boolean("ignore_unmapped")
+	boost, hasBoost := o.float("boost")
+	queryName, hasQueryName := o.str("_name")
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	shape, indexedShape, relation, ok := parseShapeQueryField(params)
+	if !ok {
+		return nil, false
+	}
+	q := NewShapeQuery(field).Relation(relation)
+	if shape != nil {
+		q = q.Shape(shape)
+	}
+	if indexedShape != nil {
+		q = q.IndexedShape(indexedShape)
+	}
+	if hasIgnoreUnmapped {
+		q = q.IgnoreUnmapped(ignoreUnmapped)
+	}
+	if hasBoost {
+		q = q.Boost(boost)
+	}
+	if hasQueryName {
+		q = q.QueryName(queryName)
+	}
+	return q, o.complete()
+}
+
+func parseSimpleQueryStringQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	text, found := o.str("query")
+	if !found {
+		return nil, false
+	}
+	q := NewSimpleQueryStringQuery(text)
+	if v, found := o.strings("fields"); found {
+		for _, field := range v {
+			q = q.Field(field)
+		}
+	}
+	if v, found := o.str("flags"); found {
+		q = q.Flags(v)
+	}
+	if v, found := o.str("analyzer"); found {
+		q = q.Analyzer(v)
+	}
+	if v, found := o.str("default_operator"); found {
+		q = q.DefaultOperator(v)
+	}
+	if v, found := o.boolean("lowercase_expanded_terms"); found {
+		q = q.LowercaseExpandedTerms(v)
+	}
+	if v, found := o.boolean("lenient"); found {
+		q = q.Lenient(v)
+	}
+	if v, found := o.boolean("analyze_wildcard"); found {
+		q = q.AnalyzeWildcard(v)
+	}
+	if v, found := o.str("locale"); found {
+		q = q.Locale(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	if v, found := o.strOrNumber("minimum_should_match"); found {
+		q = q.MinimumShouldMatch(v)
+	}
+	if v, found := o.str("quote_field_suffix"); found {
+		q = q.QuoteFieldSuffix(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.boolean("auto_generate_synonyms_phrase_query"); found {
+		q = q.AutoGenerateSynonymsPhraseQuery(v)
+	}
+	if v, found := o.int("fuzzy_prefix_length"); found {
+		q = q.FuzzyPrefixLength(v)
+	}
+	if v, found := o.int("fuzzy_max_expansions"); found {
+		q = q.FuzzyMaxExpansions(v)
+	}
+	if v, found := o.boolean("fuzzy_transpositions"); found {
+		q = q.FuzzyTranspositions(v)
+	}
+	return q, o.complete()
+}
+
+func parseSpanContainingQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	big, found := o.query("big")
+	if !found {
+		return nil, false
+	}
+	little, found := o.query("little")
+	if !found {
+		return nil, false
+	}
+	q := NewSpanContainingQuery(big, little)
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseSpanFirstQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	match, found := o.query("match")
+	if !found {
+		return nil, false
+	}
+	end, found := o.int("end")
+	if !found {
+		return nil, false
+	}
+	q := NewSpanFirstQuery(match, end)
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseSpanMultiTermQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	match, found := o.query("match")
+	if !found {
+		return nil, false
+	}
+	q := NewSpanMultiTermQuery(match)
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseSpanNearQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	clauses, found := o.array("clauses")
+	if !found || len(clauses) == 0 {
+		return nil, false
+	}
+	q := NewSpanNearQuery()
+	if v, found := o.queries("clauses"); found {
+		q = q.Add(v...)
+	}
+	if v, found := o.int("slop"); found {
+		q = q.Slop(v)
+	}
+	if v, found := o.boolean("in_order"); found {
+		q = q.InOrder(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseSpanNotQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	include, found := o.query("include")
+	if !found {
+		return nil, false
+	}
+	exclude, found := o.query("exclude")
+	if !found {
+		return nil, false
+	}
+	q := NewSpanNotQuery(include, exclude)
+	if v, found := o.int("pre"); found {
+		q = q.Pre(v)
+	}
+	if v, found := o.int("post"); found {
+		q = q.Post(v)
+	}
+	if v, found := o.int("dist"); found {
+		q = q.Dist(v)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseSpanOrQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	clauses, found := o.array("clauses")
+	if !found || len(clauses) == 0 {
+		return nil, false
+	}
+	q := NewSpanOrQuery()
+	if v, found := o.queries("clauses"); found {
+		q = q.Add(v...)
+	}
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseSpanTermQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return NewSpanTermQuery(field, params), true
+	}
+	value, found := p.value("value")
+	if !found {
+		return nil, false
+	}
+	q := NewSpanTermQuery(field, value)
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseSpanWithinQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	big, found := o.query("big")
+	if !found {
+		return nil, false
+	}
+	little, found := o.query("little")
+	if !found {
+		return nil, false
+	}
+	q := NewSpanWithinQuery(big, little)
+	if v, found := o.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := o.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete()
+}
+
+func parseTermQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return NewTermQuery(field, params), true
+	}
+	value, found := p.value("value")
+	if !found {
+		return nil, false
+	}
+	q := NewTermQuery(field, value)
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseTermsQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	boost, hasBoost := o.float("boost")
+	queryName, hasQueryName := o.str("_name")
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	// Terms lookups are left to a RawStringQuery
+	values, ok := params.([]interface{})
+	if !ok || len(values) == 0 {
+		return nil, false
+	}
+	q := NewTermsQuery(field, values...)
+	if hasBoost {
+		q = q.Boost(boost)
+	}
+	if hasQueryName {
+		q = q.QueryName(queryName)
+	}
+	return q, o.complete()
+}
+
+func parseTermsSetQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return nil, false
+	}
+	values, found := p.array("terms")
+	if !found {
+		return nil, false
+	}
+	q := NewTermsSetQuery(field, values...)
+	if v, found := p.str("minimum_should_match_field"); found {
+		q = q.MinimumShouldMatchField(v)
+	}
+	if v, found := p.script("minimum_should_match_script"); found {
+		q = q.MinimumShouldMatchScript(v)
+	}
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseTypeQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	typ, found := o.str("value")
+	if !found {
+		return nil, false
+	}
+	return NewTypeQuery(typ), o.complete()
+}
+
+func parseWildcardQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	field, params, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	if wildcard, ok := params.(string); ok {
+		return NewWildcardQuery(field, wildcard), true
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return nil, false
+	}
+	wildcard, found := p.str("wildcard")
+	if !found {
+		if wildcard, found = p.str("value"); !found {
+			return nil, false
+		}
+	}
+	q := NewWildcardQuery(field, wildcard)
+	if v, found := p.float("boost"); found {
+		q = q.Boost(v)
+	}
+	if v, found := p.str("rewrite"); found {
+		q = q.Rewrite(v)
+	}
+	if v, found := p.str("_name"); found {
+		q = q.QueryName(v)
+	}
+	return q, o.complete() && p.complete()
+}
+
+func parseWrapperQuery(body interface{}) (Query, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	source, found := o.str("query")
+	if !found {
+		return nil, false
+	}
+	return NewWrapperQuery(source), o.complete()
+}
+
+// -- Aggregations --
+
+// aggregationParser parses the body of an aggregation, i.e. the value
+// below the type of the aggregation, and adds the already parsed
+// sub-aggregations and meta data. It returns false if the body cannot be
+// represented with the aggregation builder.
+type aggregationParser func(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool)
+
+var aggregationParsers map[string]aggregationParser
+
+func init() {
+	// Initialized here as parsers of bucket aggregations use parseQuery
+	aggregationParsers = map[string]aggregationParser{
+		"adjacency_matrix":      parseAdjacencyMatrixAggregation,
+		"auto_date_histogram":   parseAutoDateHistogramAggregation,
+		"avg":                   parseAvgAggregation,
+		"avg_bucket":            parseAvgBucketAggregation,
+		"bucket_script":         parseBucketScriptAggregation,
+		"bucket_selector":       parseBucketSelectorAggregation,
+		"bucket_sort":           parseBucketSortAggregation,
+		"cardinality":           parseCardinalityAggregation,
+		"children":              parseChildrenAggregation,
+		"composite":             parseCompositeAggregation,
+		"cumulative_sum":        parseCumulativeSumAggregation,
+		"date_histogram":        parseDateHistogramAggregation,
+		"date_range":            parseDateRangeAggregation,
+		"derivative":            parseDerivativeAggregation,
+		"diversified_sampler":   parseDiversifiedSamplerAggregation,
+		"extended_stats":        parseExtendedStatsAggregation,
+		"extended_stats_bucket": parseExtendedStatsBucketAggregation,
+		"filter":                parseFilterAggregation,
+		"filters":               parseFiltersAggregation,
+		"geo_bounds":            parseGeoBoundsAggregation,
+		"geo_centroid":          parseGeoCentroidAggregation,
+		"geo_distance":          parseGeoDistanceAggregation,
+		"geohash_grid":          parseGeoHashGridAggregation,
+		"global":                parseGlobalAggregation,
+		"histogram":             parseHistogramAggregation,
+		"ip_range":              parseIPRangeAggregation,
+		"matrix_stats":          parseMatrixStatsAggregation,
+		"max":                   parseMaxAggregation,
+		"max_bucket":            parseMaxBucketAggregation,
+		"min":                   parseMinAggregation,
+		"min_bucket":            parseMinBucketAggregation,
+		"missing":               parseMissingAggregation,
+		"moving_avg":            parseMovAvgAggregation,
+		"moving_fn":             parseMovFnAggregation,
+		"nested":                parseNestedAggregation,
+		"percentile_ranks":      parsePercentileRanksAggregation,
+		"percentiles":           parsePercentilesAggregation,
+		"percentiles_bucket":    parsePercentilesBucketAggregation,
+		"range":                 parseRangeAggregation,
+		"reverse_nested":        parseReverseNestedAggregation,
+		"sampler":               parseSamplerAggregation,
+		"scripted_metric":       parseScriptedMetricAggregation,
+		"serial_diff":           parseSerialDiffAggregation,
+		"significant_terms":     parseSignificantTermsAggregation,
+		"significant_text":      parseSignificantTextAggregation,
+		"stats":                 parseStatsAggregation,
+		"stats_bucket":          parseStatsBucketAggregation,
+		"sum":                   parseSumAggregation,
+		"sum_bucket":            parseSumBucketAggregation,
+		"terms":                 parseTermsAggregation,
+		"top_hits":              parseTopHitsAggregation,
+		"value_count":           parseValueCountAggregation,
+		"weighted_avg":          parseWeightedAvgAggregation,
+	}
+}
+
+// parseAggregations parses an object of aggregations by name.
+func parseAggregations(m map[string]interface{}) map[string]Aggregation {
+	aggs := make(map[string]Aggregation, len(m))
+	for name, v := range m {
+		aggs[name] = parseAggregation(v)
+	}
+	return aggs
+}
+
+// parseAggregation parses v into an aggregation builder, or into a
+// RawAggregation if there is no builder that can represent it.
+func parseAggregation(v interface{}) Aggregation {
+	if m, ok := v.(map[string]interface{}); ok {
+		var (
+			typ     string
+			body    interface{}
+			n       int
+			subAggs map[string]Aggregation
+			meta    map[string]interface{}
+			valid   = true
+		)
+		for key, value := range m {
+			switch key {
+			case "aggs", "aggregations":
+				aggs, ok := value.(map[string]interface{})
+				if !ok || subAggs != nil {
+					valid = false
+					continue
+				}
+				subAggs = parseAggregations(aggs)
+			case "meta":
+				if meta, ok = value.(map[string]interface{}); !ok {
+					valid = false
+				}
+			default:
+				typ, body = key, value
+				n++
+			}
+		}
+		if parser, found := aggregationParsers[typ]; found && valid && n == 1 {
+			if agg, ok := parser(body, subAggs, meta); ok {
+				return agg
+			}
+		}
+	}
+	data, _ := json.Marshal(v)
+	return RawAggregation(data)
+}
+
+func parseAdjacencyMatrixAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	filters, found := o.object("filters")
+	if !found {
+		return nil, false
+	}
+	a := NewAdjacencyMatrixAggregation()
+	for name, v := range filters {
+		if _, ok := v.(map[string]interface{}); !ok {
+			return nil, false
+		}
+		a = a.Filters(name, parseQuery(v))
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseAutoDateHistogramAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewAutoDateHistogramAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	if v, found := o.int("buckets"); found {
+		if v <= 0 {
+			return nil, false // not serialized by AutoDateHistogramAggregation
+		}
+		a = a.Buckets(v)
+	}
+	if v, found := o.int("min_doc_count"); found {
+		a = a.MinDocCount(int64(v))
+	}
+	if v, found := o.str("time_zone"); found {
+		a = a.TimeZone(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("minimum_interval"); found {
+		a = a.MinimumInterval(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseAvgAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewAvgAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseAvgBucketAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewAvgBucketAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseBucketScriptAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewBucketScriptAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.object("buckets_path"); found {
+		for name, path := range v {
+			path, ok := path.(string)
+			if !ok {
+				return nil, false
+			}
+			a = a.AddBucketsPath(name, path)
+		}
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseBucketSelectorAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewBucketSelectorAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.object("buckets_path"); found {
+		for name, path := range v {
+			path, ok := path.(string)
+			if !ok {
+				return nil, false
+			}
+			a = a.AddBucketsPath(name, path)
+		}
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseBucketSortAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewBucketSortAggregation()
+	if v, found := o.value("sort"); found {
+		a.sorters = append(a.sorters, parseSorters(v)...)
+	}
+	if v, found := o.int("from"); found {
+		a = a.From(v)
+	}
+	if v, found := o.int("size"); found {
+		a = a.Size(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseCardinalityAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewCardinalityAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	if v, found := o.int("precision_threshold"); found {
+		a = a.PrecisionThreshold(int64(v))
+	}
+	if v, found := o.boolean("rehash"); found {
+		a = a.Rehash(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseChildrenAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	typ, found := o.str("type")
+	if !found {
+		return nil, false
+	}
+	a := NewChildrenAggregation().Type(typ)
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseCompositeAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	sources, found := o.array("sources")
+	if !found {
+		return nil, false
+	}
+	a := NewCompositeAggregation()
+	for _, v := range sources {
+		source, ok := parseCompositeValuesSource(v)
+		if !ok {
+			return nil, false
+		}
+		a = a.Sources(source)
+	}
+	if v, found := o.int("size"); found {
+		a = a.Size(v)
+	}
+	if v, found := o.object("after"); found {
+		a = a.AggregateAfter(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+// parseCompositeValuesSource parses a single source of a composite
+// aggregation, e.g. {"user":{"terms":{"field":"user"}}}.
+func parseCompositeValuesSource(v interface{}) (CompositeAggregationValuesSource, bool) {
+	o, ok := newDSLObject(v)
+	if !ok {
+		return nil, false
+	}
+	name, body, ok := o.field()
+	if !ok {
+		return nil, false
+	}
+	s, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	typ, params, ok := s.field()
+	if !ok {
+		return nil, false
+	}
+	p, ok := newDSLObject(params)
+	if !ok {
+		return nil, false
+	}
+	field, hasField := p.str("field")
+	script, hasScript := p.script("script")
+	missing, hasMissing := p.value("missing")
+	missingBucket, hasMissingBucket := p.boolean("missing_bucket")
+	valueType, hasValueType := p.str("value_type")
+	order, hasOrder := p.str("order")
+	switch typ {
+	case "terms":
+		source := NewCompositeAggregationTermsValuesSource(name)
+		if hasField {
+			source = source.Field(field)
+		}
+		if hasScript {
+			source = source.Script(script)
+		}
+		if hasMissing {
+			source = source.Missing(missing)
+		}
+		if hasMissingBucket {
+			source = source.MissingBucket(missingBucket)
+		}
+		if hasValueType {
+			source = source.ValueType(valueType)
+		}
+		if hasOrder {
+			source = source.Order(order)
+		}
+		return source, p.complete()
+	case "histogram":
+		interval, found := p.float("interval")
+		if !found {
+			return nil, false
+		}
+		source := NewCompositeAggregationHistogramValuesSource(name, interval)
+		if hasField {
+			source = source.Field(field)
+		}
+		if hasScript {
+			source = source.Script(script)
+		}
+		if hasMissing {
+			source = source.Missing(missing)
+		}
+		if hasMissingBucket {
+			source = source.MissingBucket(missingBucket)
+		}
+		if hasValueType {
+			source = source.ValueType(valueType)
+		}
+		if hasOrder {
+			source = source.Order(order)
+		}
+		return source, p.complete()
+	case "date_histogram":
+		interval, found := p.value("interval")
+		if !found {
+			return nil, false
+		}
+		source := NewCompositeAggregationDateHistogramValuesSource(name, interval)
+		if hasField {
+			source = source.Field(field)
+		}
+		if hasScript {
+			source = source.Script(script)
+		}
+		if hasMissing {
+			source = source.Missing(missing)
+		}
+		if hasMissingBucket {
+			source = source.MissingBucket(missingBucket)
+		}
+		if hasValueType {
+			source = source.ValueType(valueType)
+		}
+		if hasOrder {
+			source = source.Order(order)
+		}
+		if v, found := p.str("format"); found {
+			source = source.Format(v)
+		}
+		if v, found := p.str("time_zone"); found {
+			source = source.TimeZone(v)
+		}
+		return source, p.complete()
+	}
+	return nil, false
+}
+
+func parseCumulativeSumAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewCumulativeSumAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseDateHistogramAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	interval, found := o.str("interval")
+	if !found {
+		return nil, false
+	}
+	a := NewDateHistogramAggregation().Interval(interval)
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	if v, found := o.int("min_doc_count"); found {
+		a = a.MinDocCount(int64(v))
+	}
+	if v, found := o.value("order"); found {
+		field, asc, ok := dslOrder(v)
+		if !ok {
+			return nil, false
+		}
+		a = a.Order(field, asc)
+	}
+	if v, found := o.str("time_zone"); found {
+		a = a.TimeZone(v)
+	}
+	if v, found := o.str("offset"); found {
+		a = a.Offset(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.object("extended_bounds"); found {
+		bounds, _ := newDSLObject(v)
+		if min, found := bounds.value("min"); found {
+			a = a.ExtendedBoundsMin(min)
+		}
+		if max, found := bounds.value("max"); found {
+			a = a.ExtendedBoundsMax(max)
+		}
+		if !bounds.complete() {
+			return nil, false
+		}
+	}
+	if v, found := o.boolean("keyed"); found {
+		a = a.Keyed(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseDateRangeAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewDateRangeAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.boolean("keyed"); found {
+		a = a.Keyed(v)
+	}
+	if v, found := o.boolean("unmapped"); found {
+		a = a.Unmapped(v)
+	}
+	if v, found := o.str("time_zone"); found {
+		a = a.TimeZone(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	ranges, found := o.array("ranges")
+	if !found {
+		return nil, false
+	}
+	for _, v := range ranges {
+		r, ok := newDSLObject(v)
+		if !ok {
+			return nil, false
+		}
+		key, _ := r.str("key")
+		from, _ := r.value("from")
+		to, _ := r.value("to")
+		if !r.complete() {
+			return nil, false
+		}
+		a = a.AddRangeWithKey(key, dslNumber(from), dslNumber(to))
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseDerivativeAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewDerivativeAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.str("unit"); found {
+		a = a.Unit(v)
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseDiversifiedSamplerAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewDiversifiedSamplerAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.int("shard_size"); found {
+		a = a.ShardSize(v)
+	}
+	if v, found := o.int("max_docs_per_value"); found {
+		a = a.MaxDocsPerValue(v)
+	}
+	if v, found := o.str("execution_hint"); found {
+		a = a.ExecutionHint(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseExtendedStatsAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewExtendedStatsAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseExtendedStatsBucketAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewExtendedStatsBucketAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.float("sigma"); found {
+		if v < 0 {
+			return nil, false // not serialized by ExtendedStatsBucketAggregation
+		}
+		a = a.Sigma(float32(v))
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseFilterAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	if _, ok := body.(map[string]interface{}); !ok {
+		return nil, false
+	}
+	a := NewFilterAggregation().Filter(parseQuery(body))
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, true
+}
+
+func parseFiltersAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewFiltersAggregation()
+	filters, found := o.value("filters")
+	if !found {
+		return nil, false
+	}
+	switch filters := filters.(type) {
+	case map[string]interface{}:
+		for name, filter := range filters {
+			if _, ok := filter.(map[string]interface{}); !ok {
+				return nil, false
+			}
+			a = a.FilterWithName(name, parseQuery(filter))
+		}
+	case []interface{}:
+		for _, filter := range filters {
+			if _, ok := filter.(map[string]interface{}); !ok {
+				return nil, false
+			}
+			a = a.Filter(parseQuery(filter))
+		}
+	default:
+		return nil, false
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseGeoBoundsAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewGeoBoundsAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.boolean("wrap_longitude"); found {
+		a = a.WrapLongitude(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseGeoCentroidAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewGeoCentroidAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseGeoDistanceAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewGeoDistanceAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.str("unit"); found {
+		a = a.Unit(v)
+	}
+	if v, found := o.str("distance_type"); found {
+		a = a.DistanceType(v)
+	}
+	// GeoDistanceAggregation supports an origin given as "lat,lon" only
+	if v, found := o.str("origin"); found {
+		a = a.Point(v)
+	}
+	ranges, found := o.array("ranges")
+	if !found {
+		return nil, false
+	}
+	for _, v := range ranges {
+		r, ok := newDSLObject(v)
+		if !ok {
+			return nil, false
+		}
+		key, _ := r.str("key")
+		from, _ := r.value("from")
+		to, _ := r.value("to")
+		if !r.complete() {
+			return nil, false
+		}
+		a = a.AddRangeWithKey(key, dslNumber(from), dslNumber(to))
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseGeoHashGridAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewGeoHashGridAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.value("precision"); found {
+		a = a.Precision(v)
+	}
+	if v, found := o.int("size"); found {
+		a = a.Size(v)
+	}
+	if v, found := o.int("shard_size"); found {
+		a = a.ShardSize(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseGlobalAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewGlobalAggregation()
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseHistogramAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	interval, found := o.float("interval")
+	if !found {
+		return nil, false
+	}
+	a := NewHistogramAggregation().Interval(interval)
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	if v, found := o.int("min_doc_count"); found {
+		a = a.MinDocCount(int64(v))
+	}
+	if v, found := o.value("order"); found {
+		field, asc, ok := dslOrder(v)
+		if !ok {
+			return nil, false
+		}
+		a = a.Order(field, asc)
+	}
+	if v, found := o.float("offset"); found {
+		a = a.Offset(v)
+	}
+	if v, found := o.object("extended_bounds"); found {
+		bounds, _ := newDSLObject(v)
+		if min, found := bounds.float("min"); found {
+			a = a.ExtendedBoundsMin(min)
+		}
+		if max, found := bounds.float("max"); found {
+			a = a.ExtendedBoundsMax(max)
+		}
+		if !bounds.complete() {
+			return nil, false
+		}
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseIPRangeAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewIPRangeAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.boolean("keyed"); found {
+		a = a.Keyed(v)
+	}
+	ranges, found := o.array("ranges")
+	if !found {
+		return nil, false
+	}
+	for _, v := range ranges {
+		r, ok := newDSLObject(v)
+		if !ok {
+			return nil, false
+		}
+		key, _ := r.str("key")
+		mask, hasMask := r.str("mask")
+		from, hasFrom := r.str("from")
+		to, hasTo := r.str("to")
+		if !r.complete() || hasMask && (hasFrom || hasTo) {
+			return nil, false
+		}
+		if hasMask {
+			a = a.AddMaskRangeWithKey(key, mask)
+		} else {
+			a = a.AddRangeWithKey(key, from, to)
+		}
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseMatrixStatsAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	fields, found := o.strings("fields")
+	if !found {
+		return nil, false
+	}
+	a := NewMatrixStatsAggregation().Fields(fields...)
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("value_type"); found {
+		a = a.ValueType(v)
+	}
+	if v, found := o.str("mode"); found {
+		a = a.Mode(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseMaxAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewMaxAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseMaxBucketAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewMaxBucketAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseMinAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewMinAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseMinBucketAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewMinBucketAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseMissingAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewMissingAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseMovAvgAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewMovAvgAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	settings, hasSettings := o.object("settings")
+	if v, found := o.str("model"); found {
+		model, ok := parseMovAvgModel(v, settings)
+		if !ok {
+			return nil, false
+		}
+		a = a.Model(model)
+	} else if hasSettings {
+		return nil, false
+	}
+	if v, found := o.int("window"); found {
+		a = a.Window(v)
+	}
+	if v, found := o.int("predict"); found {
+		a = a.Predict(v)
+	}
+	if v, found := o.boolean("minimize"); found {
+		a = a.Minimize(v)
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+// parseMovAvgModel parses the model of a moving_avg aggregation together
+// with its settings, which may be nil.
+func parseMovAvgModel(name string, settings map[string]interface{}) (MovAvgModel, bool) {
+	if settings == nil {
+		settings = make(map[string]interface{})
+	}
+	p, _ := newDSLObject(settings)
+	var model MovAvgModel
+	switch name {
+	case "ewma":
+		m := NewEWMAMovAvgModel()
+		if v, found := p.float("alpha"); found {
+			m = m.Alpha(v)
+		}
+		model = m
+	case "holt":
+		m := NewHoltLinearMovAvgModel()
+		if v, found := p.float("alpha"); found {
+			m = m.Alpha(v)
+		}
+		if v, found := p.float("beta"); found {
+			m = m.Beta(v)
+		}
+		model = m
+	case "holt_winters":
+		m := NewHoltWintersMovAvgModel()
+		if v, found := p.float("alpha"); found {
+			m = m.Alpha(v)
+		}
+		if v, found := p.float("beta"); found {
+			m = m.Beta(v)
+		}
+		if v, found := p.float("gamma"); found {
+			m = m.Gamma(v)
+		}
+		if v, found := p.int("period"); found {
+			m = m.Period(v)
+		}
+		if v, found := p.str("type"); found {
+			m = m.SeasonalityType(v)
+		}
+		if v, found := p.boolean("pad"); found {
+			m = m.Pad(v)
+		}
+		model = m
+	case "linear":
+		model = NewLinearMovAvgModel()
+	case "simple":
+		model = NewSimpleMovAvgModel()
+	default:
+		return nil, false
+	}
+	return model, p.complete()
+}
+
+func parseMovFnAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	bucketsPaths, found := o.strings("buckets_path")
+	if !found || len(bucketsPaths) == 0 {
+		return nil, false
+	}
+	script, found := o.script("script")
+	if !found {
+		return nil, false
+	}
+	window, found := o.int("window")
+	if !found {
+		return nil, false
+	}
+	a := NewMovFnAggregation(bucketsPaths[0], script, window).BucketsPath(bucketsPaths[1:]...)
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseNestedAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	path, found := o.str("path")
+	if !found {
+		return nil, false
+	}
+	a := NewNestedAggregation().Path(path)
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parsePercentileRanksAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewPercentileRanksAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	if v, found := o.floats("values"); found {
+		a = a.Values(v...)
+	}
+	if v, found := o.float("compression"); found {
+		a = a.Compression(v)
+	}
+	if v, found := o.str("estimator"); found {
+		a = a.Estimator(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parsePercentilesAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewPercentilesAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	if v, found := o.floats("percents"); found {
+		a = a.Percentiles(v...)
+	}
+	tdigest, hasTDigest := o.object("tdigest")
+	hdr, hasHDR := o.object("hdr")
+	switch {
+	case hasTDigest && hasHDR:
+		return nil, false
+	case hasTDigest:
+		p, _ := newDSLObject(tdigest)
+		compression, found := p.float("compression")
+		if !found || !p.complete() {
+			return nil, false
+		}
+		a = a.Method("tdigest").Compression(compression)
+	case hasHDR:
+		p, _ := newDSLObject(hdr)
+		digits, found := p.int("number_of_significant_value_digits")
+		if !found || !p.complete() {
+			return nil, false
+		}
+		a = a.Method("hdr").NumberOfSignificantValueDigits(digits)
+	}
+	if v, found := o.str("estimator"); found {
+		a = a.Estimator(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parsePercentilesBucketAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewPercentilesBucketAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.floats("percents"); found {
+		a = a.Percents(v...)
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseRangeAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewRangeAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	if v, found := o.boolean("keyed"); found {
+		a = a.Keyed(v)
+	}
+	if v, found := o.boolean("unmapped"); found {
+		a = a.Unmapped(v)
+	}
+	ranges, found := o.array("ranges")
+	if !found {
+		return nil, false
+	}
+	for _, v := range ranges {
+		r, ok := newDSLObject(v)
+		if !ok {
+			return nil, false
+		}
+		key, _ := r.str("key")
+		from, _ := r.value("from")
+		to, _ := r.value("to")
+		if !r.complete() {
+			return nil, false
+		}
+		a = a.AddRangeWithKey(key, dslNumber(from), dslNumber(to))
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseReverseNestedAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewReverseNestedAggregation()
+	if v, found := o.str("path"); found {
+		a = a.Path(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseSamplerAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewSamplerAggregation()
+	if v, found := o.int("shard_size"); found {
+		a = a.ShardSize(v)
+	}
+	if v, found := o.int("max_docs_per_value"); found {
+		a = a.MaxDocsPerValue(v)
+	}
+	if v, found := o.str("execution_hint"); found {
+		a = a.ExecutionHint(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseScriptedMetricAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewScriptedMetricAggregation()
+	if v, found := o.script("init_script"); found {
+		a = a.InitScript(v)
+	}
+	if v, found := o.script("map_script"); found {
+		a = a.MapScript(v)
+	}
+	if v, found := o.script("combine_script"); found {
+		a = a.CombineScript(v)
+	}
+	if v, found := o.script("reduce_script"); found {
+		a = a.ReduceScript(v)
+	}
+	if v, found := o.object("params"); found {
+		a = a.Params(v)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseSerialDiffAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewSerialDiffAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.int("lag"); found {
+		a = a.Lag(v)
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseSignificantTermsAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewSignificantTermsAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.int("size"); found {
+		a = a.RequiredSize(v)
+	}
+	if v, found := o.int("shard_size"); found {
+		a = a.ShardSize(v)
+	}
+	if v, found := o.int("min_doc_count"); found {
+		a = a.MinDocCount(v)
+	}
+	if v, found := o.int("shard_min_doc_count"); found {
+		a = a.ShardMinDocCount(v)
+	}
+	if v, found := o.str("execution_hint"); found {
+		a = a.ExecutionHint(v)
+	}
+	if v, found := o.query("background_filter"); found {
+		a = a.BackgroundFilter(v)
+	}
+	heuristic, ok := parseSignificanceHeuristic(o)
+	if !ok {
+		return nil, false
+	}
+	if heuristic != nil {
+		a = a.SignificanceHeuristic(heuristic)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseSignificantTextAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewSignificantTextAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.int("size"); found {
+		a = a.Size(v)
+	}
+	if v, found := o.int("shard_size"); found {
+		a = a.ShardSize(v)
+	}
+	if v, found := o.int("min_doc_count"); found {
+		a = a.MinDocCount(int64(v))
+	}
+	if v, found := o.int("shard_min_doc_count"); found {
+		a = a.ShardMinDocCount(int64(v))
+	}
+	if v, found := o.query("background_filter"); found {
+		a = a.BackgroundFilter(v)
+	}
+	heuristic, ok := parseSignificanceHeuristic(o)
+	if !ok {
+		return nil, false
+	}
+	if heuristic != nil {
+		a = a.SignificanceHeuristic(heuristic)
+	}
+	if v, found := o.value("include"); found {
+		switch v := v.(type) {
+		case string:
+			a = a.Include(v)
+		case []interface{}:
+			a = a.IncludeValues(v...)
+		case map[string]interface{}:
+			p, _ := newDSLObject(v)
+			partition, _ := p.int("partition")
+			numPartitions, found := p.int("num_partitions")
+			if !found || !p.complete() {
+				return nil, false
+			}
+			a = a.Partition(partition).NumPartitions(numPartitions)
+		default:
+			return nil, false
+		}
+	}
+	if v, found := o.value("exclude"); found {
+		switch v := v.(type) {
+		case string:
+			a = a.Exclude(v)
+		case []interface{}:
+			a = a.ExcludeValues(v...)
+		default:
+			return nil, false
+		}
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+// parseSignificanceHeuristic reads the significance heuristic of a
+// significant_terms or significant_text aggregation from o. It returns
+// nil if o has no heuristic.
+func parseSignificanceHeuristic(o *dslObject) (SignificanceHeuristic, bool) {
+	var heuristic SignificanceHeuristic
+	for _, name := range []string{"chi_square", "gnd", "jlh", "mutual_information", "percentage", "script_heuristic"} {
+		v, found := o.object(name)
+		if !found {
+			continue
+		}
+		if heuristic != nil {
+			return nil, false
+		}
+		p, _ := newDSLObject(v)
+		switch name {
+		case "chi_square":
+			h := NewChiSquareSignificanceHeuristic()
+			if v, found := p.boolean("background_is_superset"); found {
+				h = h.BackgroundIsSuperset(v)
+			}
+			if v, found := p.boolean("include_negatives"); found {
+				h = h.IncludeNegatives(v)
+			}
+			heuristic = h
+		case "gnd":
+			h := NewGNDSignificanceHeuristic()
+			if v, found := p.boolean("background_is_superset"); found {
+				h = h.BackgroundIsSuperset(v)
+			}
+			heuristic = h
+		case "jlh":
+			heuristic = NewJLHScoreSignificanceHeuristic()
+		case "mutual_information":
+			h := NewMutualInformationSignificanceHeuristic()
+			if v, found := p.boolean("background_is_superset"); found {
+				h = h.BackgroundIsSuperset(v)
+			}
+			if v, found := p.boolean("include_negatives"); found {
+				h = h.IncludeNegatives(v)
+			}
+			heuristic = h
+		case "percentage":
+			heuristic = NewPercentageScoreSignificanceHeuristic()
+		case "script_heuristic":
+			h := NewScriptSignificanceHeuristic()
+			if v, found := p.script("script"); found {
+				h = h.Script(v)
+			}
+			heuristic = h
+		}
+		if !p.complete() {
+			return nil, false
+		}
+	}
+	return heuristic, true
+}
+
+func parseStatsAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewStatsAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseStatsBucketAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewStatsBucketAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseSumAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewSumAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseSumBucketAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok || len(subAggs) > 0 {
+		return nil, false
+	}
+	a := NewSumBucketAggregation()
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.str("gap_policy"); found {
+		a = a.GapPolicy(v)
+	}
+	if v, found := o.strings("buckets_path"); found {
+		a = a.BucketsPath(v...)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseTermsAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewTermsAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.value("missing"); found {
+		a = a.Missing(v)
+	}
+	if v, found := o.int("size"); found {
+		a = a.Size(v)
+	}
+	if v, found := o.int("shard_size"); found {
+		a = a.ShardSize(v)
+	}
+	if v, found := o.int("min_doc_count"); found {
+		a = a.MinDocCount(v)
+	}
+	if v, found := o.int("shard_min_doc_count"); found {
+		a = a.ShardMinDocCount(v)
+	}
+	if v, found := o.boolean("show_term_doc_count_error"); found {
+		a = a.ShowTermDocCountError(v)
+	}
+	if v, found := o.str("collect_mode"); found {
+		a = a.CollectionMode(v)
+	}
+	if v, found := o.str("value_type"); found {
+		a = a.ValueType(v)
+	}
+	if v, found := o.str("execution_hint"); found {
+		a = a.ExecutionHint(v)
+	}
+	if v, found := o.value("order"); found {
+		orders, ok := v.([]interface{})
+		if !ok {
+			orders = []interface{}{v}
+		}
+		for _, order := range orders {
+			field, asc, ok := dslOrder(order)
+			if !ok {
+				return nil, false
+			}
+			a = a.Order(field, asc)
+		}
+	}
+	if v, found := o.value("include"); found {
+		switch v := v.(type) {
+		case string:
+			a = a.Include(v)
+		case []interface{}:
+			a = a.IncludeValues(v...)
+		case map[string]interface{}:
+			p, _ := newDSLObject(v)
+			partition, _ := p.int("partition")
+			numPartitions, found := p.int("num_partitions")
+			if !found || !p.complete() {
+				return nil, false
+			}
+			a = a.Partition(partition).NumPartitions(numPartitions)
+		default:
+			return nil, false
+		}
+	}
+	if v, found := o.value("exclude"); found {
+		switch v := v.(type) {
+		case string:
+			a = a.Exclude(v)
+		case []interface{}:
+			a = a.ExcludeValues(v...)
+		default:
+			return nil, false
+		}
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseTopHitsAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	// TopHitsAggregation supports neither sub-aggregations nor meta data
+	m, ok := body.(map[string]interface{})
+	if !ok || len(subAggs) > 0 || meta != nil {
+		return nil, false
+	}
+	return NewTopHitsAggregation().SearchSource(parseSearchSource(m)), true
+}
+
+func parseValueCountAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewValueCountAggregation()
+	if v, found := o.str("field"); found {
+		a = a.Field(v)
+	}
+	if v, found := o.script("script"); found {
+		a = a.Script(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+func parseWeightedAvgAggregation(body interface{}, subAggs map[string]Aggregation, meta map[string]interface{}) (Aggregation, bool) {
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	a := NewWeightedAvgAggregation()
+	if v, found := o.object("fields"); found {
+		for name, v := range v {
+			config, ok := parseMultiValuesSourceFieldConfig(v)
+			if !ok {
+				return nil, false
+			}
+			a = a.Field(name, config)
+		}
+	}
+	if v, found := o.str("value_type"); found {
+		a = a.ValueType(v)
+	}
+	if v, found := o.str("format"); found {
+		a = a.Format(v)
+	}
+	if v, found := o.value("value"); found {
+		config, ok := parseMultiValuesSourceFieldConfig(v)
+		if !ok {
+			return nil, false
+		}
+		a = a.Value(config)
+	}
+	if v, found := o.value("weight"); found {
+		config, ok := parseMultiValuesSourceFieldConfig(v)
+		if !ok {
+			return nil, false
+		}
+		a = a.Weight(config)
+	}
+	for name, subAgg := range subAggs {
+		a = a.SubAggregation(name, subAgg)
+	}
+	if meta != nil {
+		a = a.Meta(meta)
+	}
+	return a, o.complete()
+}
+
+// parseMultiValuesSourceFieldConfig parses the value or weight of a
+// weighted_avg aggregation, e.g. {"field":"grade","missing":1}.
+func parseMultiValuesSourceFieldConfig(v interface{}) (*MultiValuesSourceFieldConfig, bool) {
+	o, ok := newDSLObject(v)
+	if !ok {
+		return nil, false
+	}
+	config := &MultiValuesSourceFieldConfig{}
+	config.FieldName, _ = o.str("field")
+	config.Missing, _ = o.value("missing")
+	config.Script, _ = o.script("script")
+	config.TimeZone, _ = o.str("time_zone")
+	return config, o.complete()
+}
+
+// -- Sorters --
+
+// parseSorters parses a single sort clause or a list of sort clauses.
+func parseSorters(v interface{}) []Sorter {
+	list, ok := v.([]interface{})
+	if !ok {
+		list = []interface{}{v}
+	}
+	sorters := make([]Sorter, 0, len(list))
+	for _, sorter := range list {
+		sorters = append(sorters, parseSorter(sorter))
+	}
+	return sorters
+}
+
+// parseSorter parses v into a sorter, or into a RawSorter if there is
+// no sorter that can represent it.
+func parseSorter(v interface{}) Sorter {
+	switch v := v.(type) {
+	case string:
+		switch v {
+		case "_doc":
+			return SortByDoc{}
+		case "_shard_doc":
+			return SortByShardDoc{}
+		case "_score":
+			return NewScoreSort()
+		default:
+			return NewFieldSort(v)
+		}
+	case map[string]interface{}:
+		if len(v) == 1 {
+			for field, body := range v {
+				if sorter, ok := parseFieldSort(field, body); ok {
+					return sorter
+				}
+			}
+		}
+	}
+	data, _ := json.Marshal(v)
+	return RawSorter(data)
+}
+
+// parseFieldSort parses sort clauses like {"user":"asc"} or
+// {"user":{"order":"asc","missing":"_last"}}.
+func parseFieldSort(field string, body interface{}) (Sorter, bool) {
+	switch field {
+	case "_geo_distance", "_script":
+		return nil, false
+	}
+	ascending := field != "_score"
+	if order, ok := body.(string); ok {
+		body = map[string]interface{}{"order": order}
+	}
+	o, ok := newDSLObject(body)
+	if !ok {
+		return nil, false
+	}
+	if v, found := o.str("order"); found {
+		switch v {
+		case "asc":
+			ascending = true
+		case "desc":
+			ascending = false
+		default:
+			return nil, false
+		}
+	}
+	if field == "_score" {
+		return NewScoreSort().Order(ascending), o.complete()
+	}
+	s := NewFieldSort(field).Order(ascending)
+	if v, found := o.value("missing"); found {
+		s = s.Missing(v)
+	}
+	if v, found := o.str("unmapped_type"); found {
+		s = s.UnmappedType(v)
+	}
+	if v, found := o.str("mode"); found {
+		s = s.SortMode(v)
+	}
+	if v, found := o.query("filter"); found {
+		s = s.Filter(v)
+	}
+	if v, found := o.str("path"); found {
+		s = s.Path(v)
+	}
+	return s, o.complete()
+}
+
+// -- SearchSource --
+
+// searchSourceParser parses the value of a top-level key of a search
+// request into ss. It must not modify ss if it returns false.
+type searchSourceParser func(ss *SearchSource, v interface{}) bool
+
+var searchSourceParsers map[string]searchSourceParser
+
+func init() {
+	// Initialized here as parsers use parseQuery
+	searchSourceParsers = map[string]searchSourceParser{
+		"query": func(ss *SearchSource, v interface{}) bool {
+			if _, ok := v.(map[string]interface{}); !ok {
+				return false
+			}
+			ss.Query(parseQuery(v))
+			return true
+		},
+		"post_filter": func(ss *SearchSource, v interface{}) bool {
+			if _, ok := v.(map[string]interface{}); !ok {
+				return false
+			}
+			ss.PostFilter(parseQuery(v))
+			return true
+		},
+		"aggs":         parseSearchSourceAggregations,
+		"aggregations": parseSearchSourceAggregations,
+		"sort": func(ss *SearchSource, v interface{}) bool {
+			ss.SortBy(parseSorters(v)...)
+			return true
+		},
+		"from": func(ss *SearchSource, v interface{}) bool {
+			n, ok := dslInt(v)
+			if ok {
+				ss.From(n)
+			}
+			return ok
+		},
+		"size": func(ss *SearchSource, v interface{}) bool {
+			n, ok := dslInt(v)
+			if ok {
+				ss.Size(n)
+			}
+			return ok
+		},
+		"terminate_after": func(ss *SearchSource, v interface{}) bool {
+			n, ok := dslInt(v)
+			if ok {
+				ss.TerminateAfter(n)
+			}
+			return ok
+		},
+		"timeout": func(ss *SearchSource, v interface{}) bool {
+			s, ok := v.(string)
+			if ok {
+				ss.Timeout(s)
+			}
+			return ok
+		},
+		"min_score": func(ss *SearchSource, v interface{}) bool {
+			n, ok := v.(json.Number)
+			if !ok {
+				return false
+			}
+			f, err := n.Float64()
+			if err != nil {
+				return false
+			}
+			ss.MinScore(f)
+			return true
+		},
+		"version": func(ss *SearchSource, v interface{}) bool {
+			b, ok := v.(bool)
+			if ok {
+				ss.Version(b)
+			}
+			return ok
+		},
+		"explain": func(ss *SearchSource, v interface{}) bool {
+			b, ok := v.(bool)
+			if ok {
+				ss.Explain(b)
+			}
+			return ok
+		},
+		"track_scores": func(ss *SearchSource, v interface{}) bool {
+			b, ok := v.(bool)
+			if ok {
+				ss.TrackScores(b)
+			}
+			return ok
+		},
+		"profile": func(ss *SearchSource, v interface{}) bool {
+			b, ok := v.(bool)
+			if ok {
+				ss.Profile(b)
+			}
+			return ok
+		},
+		"track_total_hits": func(ss *SearchSource, v interface{}) bool {
+			switch v.(type) {
+			case bool, json.Number:
+				ss.TrackTotalHits(v)
+				return true
+			}
+			return false
+		},
+		"search_after": func(ss *SearchSource, v interface{}) bool {
+			values, ok := v.([]interface{})
+			if ok {
+				ss.SearchAfter(values...)
+			}
+			return ok
+		},
+		"_source": parseSearchSourceFetchSource,
+		"stored_fields": func(ss *SearchSource, v interface{}) bool {
+			fields, ok := dslStrings(v)
+			if ok {
+				ss.StoredFields(fields...)
+			}
+			return ok
+		},
+		"docvalue_fields":  parseSearchSourceDocvalueFields,
+		"fields":           parseSearchSourceFetchFields,
+		"runtime_mappings": parseSearchSourceRuntimeMappings,
+		"stats": func(ss *SearchSource, v interface{}) bool {
+			groups, ok := dslStrings(v)
+			if ok {
+				ss.Stats(groups...)
+			}
+			return ok
+		},
+		"pit": func(ss *SearchSource, v interface{}) bool {
+			o, ok := newDSLObject(v)
+			if !ok {
+				return false
+			}
+			id, found := o.str("id")
+			if !found {
+				return false
+			}
+			pit := NewPointInTime(id)
+			if keepAlive, found := o.str("keep_alive"); found {
+				pit = NewPointInTimeWithKeepAlive(id, keepAlive)
+			}
+			if !o.complete() {
+				return false
+			}
+			ss.PointInTime(pit)
+			return true
+		},
+	}
+}
+
+// dslInt returns v as an int if it is an integral number.
+func dslInt(v interface{}) (int, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	if err != nil {
+		return 0, false
+	}
+	return int(i), true
+}
+
+func parseSearchSourceAggregations(ss *SearchSource, v interface{}) bool {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for name, agg := range parseAggregations(m) {
+		ss.Aggregation(name, agg)
+	}
+	return true
+}
+
+func parseSearchSourceFetchSource(ss *SearchSource, v interface{}) bool {
+	fsc, ok := parseFetchSourceContext(v)
+	if ok {
+		ss.FetchSourceContext(fsc)
+	}
+	return ok
+}
+
+func parseSearchSourceDocvalueFields(ss *SearchSource, v interface{}) bool {
+	list, ok := v.([]interface{})
+	if !ok {
+		return false
+	}
+	fields := make(DocvalueFields, 0, len(list))
+	for _, field := range list {
+		switch field := field.(type) {
+		case string:
+			fields = append(fields, DocvalueField{Field: field})
+		case map[string]interface{}:
+			o, _ := newDSLObject(field)
+			name, found := o.str("field")
+			format, _ := o.str("format")
+			if !found || !o.complete() {
+				return false
+			}
+			fields = append(fields, DocvalueField{Field: name, Format: format})
+		default:
+			return false
+		}
+	}
+	ss.DocvalueFieldsWithFormat(fields...)
+	return true
+}
+
+func parseSearchSourceFetchFields(ss *SearchSource, v interface{}) bool {
+	list, ok := v.([]interface{})
+	if !ok {
+		return false
+	}
+	fields := make(FieldsAndFormats, 0, len(list))
+	for _, field := range list {
+		switch field := field.(type) {
+		case string:
+			fields = append(fields, FieldAndFormat{Field: field})
+		case map[string]interface{}:
+			o, _ := newDSLObject(field)
+			name, found := o.str("field")
+			f := FieldAndFormat{Field: name}
+			f.Format, _ = o.str("format")
+			if includeUnmapped, ok := o.boolean("include_unmapped"); ok {
+				f.IncludeUnmapped = &includeUnmapped
+			}
+			if !found || !o.complete() {
+				return false
+			}
+			fields = append(fields, f)
+		default:
+			return false
+		}
+	}
+	ss.FetchFieldsWithFormat(fields...)
+	return true
+}
+
+func parseSearchSourceRuntimeMappings(ss *SearchSource, v interface{}) bool {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	mappings := make(RuntimeMappings, len(m))
+	for name, field := range m {
+		o, ok := newDSLObject(field)
+		if !ok {
+			return false
+		}
+		typ, found := o.str("type")
+		mapping := NewRuntimeMapping(typ)
+		if script, ok := o.script("script"); ok {
+			mapping = mapping.Script(script)
+		}
+		if format, ok := o.str("format"); ok {
+			mapping = mapping.Format(format)
+		}
+		if !found || !o.complete() {
+			return false
+		}
+		mappings[name] = mapping
+	}
+	for name, mapping := range mappings {
+		ss.RuntimeMapping(name, mapping)
+	}
+	return true
+}
@@ -0,0 +1,387 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// normalizeJSON returns data with sorted keys and without whitespace.
+func normalizeJSON(t *testing.T, data []byte) string {
+	t.Helper()
+	v, err := decodeDSL(data)
+	if err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func sourceJSON(t *testing.T, src interface{ Source() (interface{}, error) }) string {
+	t.Helper()
+	v, err := src.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return normalizeJSON(t, data)
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		Input    string
+		Expected string // defaults to Input
+		Raw      bool   // expect a RawStringQuery
+	}{
+		{Input: `{"match_all":{}}`},
+		{Input: `{"match_all":{"boost":1.5,"_name":"all"}}`},
+		{Input: `{"match_none":{}}`},
+		{Input: `{"term":{"user":"olivere"}}`},
+		{Input: `{"term":{"id":12345678901234567890}}`},
+		{Input: `{"term":{"user":{"value":"olivere","boost":2,"_name":"u"}}}`},
+		{Input: `{"terms":{"user":["olivere","sandrae"],"boost":1.2}}`},
+		{Input: `{"prefix":{"user":"oli"}}`},
+		{Input: `{"prefix":{"user":{"value":"oli","rewrite":"constant_score"}}}`},
+		{Input: `{"wildcard":{"user":{"wildcard":"oli*"}}}`},
+		{Input: `{"wildcard":{"user":"oli*"}}`, Expected: `{"wildcard":{"user":{"wildcard":"oli*"}}}`},
+		{Input: `{"regexp":{"user":{"value":"oli.*","flags":"ALL"}}}`},
+		{Input: `{"fuzzy":{"user":{"value":"oliver","fuzziness":"AUTO","transpositions":true}}}`},
+		{Input: `{"exists":{"field":"user"}}`},
+		{Input: `{"ids":{"type":"doc","values":["1","2"]}}`},
+		{Input: `{"match":{"message":{"query":"hello world","operator":"and","minimum_should_match":"75%"}}}`},
+		{Input: `{"match":{"message":"hello"}}`, Expected: `{"match":{"message":{"query":"hello"}}}`},
+		{Input: `{"match_phrase":{"message":{"query":"hello world","slop":2}}}`},
+		{Input: `{"match_phrase_prefix":{"message":{"query":"hello wo","max_expansions":10}}}`},
+		{Input: `{"range":{"age":{"from":10,"to":20,"include_lower":true,"include_upper":false,"boost":2}}}`},
+		{
+			Input:    `{"range":{"created":{"gte":"now-1d","format":"date_math"}}}`,
+			Expected: `{"range":{"created":{"from":"now-1d","to":null,"include_lower":true,"include_upper":true,"format":"date_math"}}}`,
+		},
+		{Input: `{"query_string":{"query":"user:olivere","default_field":"message","fields":["user","message^2"],"default_operator":"AND"}}`},
+		{Input: `{"bool":{"must":{"term":{"user":"olivere"}},"must_not":[{"term":{"tag":"a"}},{"term":{"tag":"b"}}],"filter":{"exists":{"field":"message"}},"should":{"match_all":{}},"minimum_should_match":"1","boost":1.1,"_name":"b"}}`},
+		{Input: `{"boosting":{"positive":{"term":{"tag":"a"}},"negative":{"term":{"tag":"b"}},"negative_boost":0.5}}`},
+		{Input: `{"constant_score":{"filter":{"term":{"user":"olivere"}},"boost":1.2}}`},
+		{Input: `{"dis_max":{"queries":[{"term":{"age":34}},{"term":{"age":35}}],"tie_breaker":0.7}}`},
+		{Input: `{"nested":{"path":"obj","query":{"match":{"obj.name":{"query":"blue"}}},"score_mode":"avg"}}`},
+		{Input: `{"geo_shape":{"location":{"shape":{"type":"envelope","coordinates":[[13,53],[14,52]]},"relation":"within"}}}`},
+		{Input: `{"geo_shape":{"location":{"shape":{"type":"Polygon","coordinates":[[[13.3,52.5],[13.5,52.5],[13.5,52.4],[13.3,52.5]]]}},"ignore_unmapped":true,"_name":"g"}}`},
+		{Input: `{"geo_shape":{"location":{"shape":{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[13.4,52.5]},{"type":"circle","coordinates":[13.4,52.5],"radius":"100m"}]}}}}`},
+		{Input: `{"geo_shape":{"location":{"indexed_shape":{"index":"shapes","id":"deu","path":"location","routing":"eu"},"relation":"intersects"}}}`},
+		{Input: `{"shape":{"geometry":{"shape":{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]]]}},"boost":2}}`},
+		{Input: `{"shape":{"geometry":{"shape":{"type":"LineString","coordinates":[[1355,5355],[1400,5200]]}}}}`},
+		{Input: `{"script_score":{"query":{"match":{"message":{"query":"elasticsearch"}}},"script":{"source":"doc['likes'].value / 10"},"min_score":1,"boost":2}}`},
+		{Input: `{"distance_feature":{"field":"production_date","origin":"now","pivot":"7d"}}`},
+		{Input: `{"distance_feature":{"field":"location","origin":[-71.3,41.15],"pivot":"1000m","_name":"d"}}`},
+		{Input: `{"rank_feature":{"field":"pagerank"}}`},
+		{Input: `{"rank_feature":{"field":"pagerank","saturation":{"pivot":8},"boost":0.1}}`},
+		{Input: `{"rank_feature":{"field":"pagerank","log":{"scaling_factor":4}}}`},
+		{Input: `{"rank_feature":{"field":"pagerank","sigmoid":{"pivot":7,"exponent":0.6}}}`},
+		{Input: `{"rank_feature":{"field":"url_length","linear":{}}}`},
+		{Input: `{"pinned":{"ids":["1","4","100"],"organic":{"match":{"description":{"query":"iphone"}}}}}`},
+		{Input: `{"span_near":{"clauses":[{"span_term":{"body":"quick"}},{"span_multi":{"match":{"prefix":{"body":"bro"}}}},{"field_masking_span":{"query":{"span_term":{"body.stems":"fox"}},"field":"body"}}],"slop":5,"in_order":true}}`},
+		{Input: `{"span_not":{"include":{"span_or":{"clauses":[{"span_term":{"body":{"value":"a","boost":2}}}]}},"exclude":{"span_first":{"match":{"span_term":{"body":"b"}},"end":3}},"dist":1}}`},
+		{Input: `{"intervals":{"my_text":{"all_of":{"ordered":true,"intervals":[{"match":{"query":"my favorite food","max_gaps":0,"ordered":true}},{"any_of":{"intervals":[{"match":{"query":"hot water"}},{"match":{"query":"cold porridge"}}]}}]},"boost":1.5}}}`},
+		{Input: `{"intervals":{"my_text":{"match":{"query":"hot porridge","filter":{"not_containing":{"match":{"query":"salty"}}}},"_name":"i"}}}`},
+		{Input: `{"intervals":{"my_text":{"any_of":{"intervals":[{"prefix":{"prefix":"out"}},{"wildcard":{"pattern":"*ing","use_field":"my_text.raw"}},{"fuzzy":{"term":"porridge","fuzziness":2,"transpositions":false}}],"filter":{"script":{"source":"interval.start > 10"}}}}}}`},
+		{Input: `{"span_containing":{"big":{"span_term":{"body":"a"}},"little":{"span_term":{"body":"b"}}}}`},
+		{Input: `{"span_within":{"big":{"span_term":{"body":"a"}},"little":{"span_term":{"body":"b"}},"_name":"w"}}`},
+		{Input: `{"common":{"body":{"query":"this is bonsai cool","cutoff_frequency":0.001,"low_freq_operator":"and","minimum_should_match":{"low_freq":"2","high_freq":"3"}}}}`},
+		{Input: `{"function_score":{"query":{"match_all":{}},"functions":[{"filter":{"term":{"tag":"a"}},"weight":2},{"gauss":{"date":{"origin":"2013-09-17","scale":"10d","offset":"5d","decay":0.5},"multi_value_mode":"avg"}},{"field_value_factor":{"field":"likes","factor":1.2,"modifier":"sqrt","missing":1}},{"random_score":{"seed":10,"field":"_seq_no"},"weight":3},{"script_score":{"script":{"source":"_score * doc['n'].value"}}}],"score_mode":"sum","boost_mode":"multiply","max_boost":42,"min_score":1}}`},
+		{Input: `{"function_score":{"filter":{"term":{"tag":"a"}},"functions":[{"exp":{"price":{"scale":10}},"weight":0.5},{"linear":{"location":{"origin":"11,12","scale":"2km"}}}],"boost":2}}`},
+		{Input: `{"geo_bounding_box":{"pin.location":{"top_left":[-74.1,40.73],"bottom_right":[-71.12,40.01]},"type":"indexed","_name":"box"}}`},
+		{
+			Input:    `{"geo_bounding_box":{"pin.location":{"top_left":{"lat":40.73,"lon":-74.1},"bottom_right":"40.01,-71.12"}}}`,
+			Expected: `{"geo_bounding_box":{"pin.location":{"top_left":[-74.1,40.73],"bottom_right":[-71.12,40.01]}}}`,
+		},
+		{Input: `{"geo_distance":{"pin.location":{"lat":40,"lon":-70},"distance":"200km","distance_type":"arc"}}`},
+		{Input: `{"geo_distance":{"pin.location":"drm3btev3e86","distance":"12km","_name":"d"}}`},
+		{Input: `{"geo_distance":{"pin.location":[-70,40],"distance":"12km"}}`, Expected: `{"geo_distance":{"pin.location":{"lat":40,"lon":-70},"distance":"12km"}}`},
+		{Input: `{"geo_polygon":{"person.location":{"points":[{"lat":40,"lon":-70},{"lat":30,"lon":-80},{"lat":20,"lon":-90}]},"_name":"p"}}`},
+		{Input: `{"has_child":{"type":"comment","query":{"term":{"user":"olivere"}},"score_mode":"max","min_children":2,"max_children":10,"inner_hits":{"name":"comments","size":3,"_source":false}}}`},
+		{Input: `{"has_parent":{"parent_type":"blog","query":{"term":{"tag":"something"}},"score":true,"ignore_unmapped":true,"_name":"hp"}}`},
+		{Input: `{"parent_id":{"type":"my_child","id":"1","ignore_unmapped":true,"inner_hits":{}}}`},
+		{Input: `{"nested":{"path":"obj","query":{"match_all":{}},"inner_hits":{"highlight":{"fields":{"obj.name":{}}},"from":1}}}`},
+		{Input: `{"more_like_this":{"fields":["title","description"],"like":[{"_index":"imdb","_id":"1"},"a text",{"doc":{"name":"x"},"_source":{"includes":["name"]}}],"unlike":["b"],"min_term_freq":1,"max_query_terms":12,"minimum_should_match":"30%","stop_words":["the"],"include":true}}`},
+		{Input: `{"more_like_this":{"fields":["message"],"like":"hello"}}`, Expected: `{"more_like_this":{"fields":["message"],"like":["hello"]}}`},
+		{Input: `{"multi_match":{"query":"brown fox","fields":["subject^3","message"],"type":"most_fields","tie_breaker":0.3,"operator":"and"}}`},
+		{Input: `{"multi_match":{"query":"brown fox","fields":["subject"],"type":"phrase","slop":2,"_name":"m"}}`},
+		{Input: `{"percolate":{"field":"query","documents":[{"message":"a"},{"message":"b"}],"name":"p"}}`},
+		{Input: `{"percolate":{"field":"query","index":"my-index","id":"2","version":1}}`},
+		{Input: `{"script":{"script":{"source":"doc['num1'].value > params.param1","params":{"param1":5}},"_name":"s"}}`},
+		{Input: `{"simple_query_string":{"query":"\"fried eggs\" +(eggplant | potato)","fields":["title^5","body"],"default_operator":"and","flags":"OR|AND","fuzzy_max_expansions":20,"auto_generate_synonyms_phrase_query":false}}`},
+		{Input: `{"terms_set":{"codes":{"terms":["abc","def"],"minimum_should_match_script":{"source":"Math.min(params.num_terms, doc['required_matches'].value)"},"boost":2}}}`},
+		{Input: `{"type":{"value":"doc"}}`},
+		{Input: `{"wrapper":{"query":"eyJ0ZXJtIiA6IHsgInVzZXIiIDogIktpbWNoeSIgfX0="}}`},
+
+		// Fallbacks
+		{Input: `{"more_like_this":{"fields":["message"],"like":""}}`, Raw: true},
+		{Input: `{"terms":{"user":{"index":"users","id":"2","path":"followers"}}}`, Raw: true},
+		{Input: `{"term":{"user":{"value":"olivere","case_insensitive":true}}}`, Raw: true},
+		{Input: `{"match_all":{"boost":"high"}}`, Raw: true},
+		{Input: `{"match_all":{},"match_none":{}}`, Raw: true},
+		{Input: `{"nested":{"path":"obj","query":{"match_all":{}},"inner_hits":{"name":1}}}`, Raw: true},
+		{Input: `{"intervals":{"my_text":{"match":{"query":"hot"},"prefix":{"prefix":"out"}}}}`, Raw: true},
+		{Input: `{"rank_feature":{"field":"pagerank","saturation":{},"log":{"scaling_factor":4}}}`, Raw: true},
+		{Input: `{"pinned":{"docs":[{"_index":"a","_id":"1"}],"organic":{"match_all":{}}}}`, Raw: true},
+		{Input: `{"geo_shape":{"location":{"shape":"POINT (13.4 52.5)"}}}`, Raw: true},
+		{Input: `{"geo_shape":{"location":{"shape":{"type":"point","coordinates":[13.4,52.5]}}}}`, Raw: true},
+		{Input: `{"intervals":{"my_text":{"regexp":{"pattern":"out.*"}}}}`, Raw: true},
+		{Input: `{"multi_match":{"query":"fox","type":"boolean"}}`, Raw: true},
+		{Input: `{"geo_distance":{"pin.location":{"lat":40,"lon":-70},"distance":"12km","validation_method":"STRICT"}}`, Raw: true},
+		{Input: `{"geo_bounding_box":{"pin.location":{"wkt":"BBOX (-74.1, -71.12, 40.73, 40.01)"}}}`, Raw: true},
+		{Input: `{"function_score":{"functions":[{"gauss":{"date":{"origin":"now","scale":"10d","decay":0}}}]}}`, Raw: true},
+		{Input: `{"percolate":{"field":"query","document":{"a":1},"documents":[{"a":2}]}}`, Raw: true},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuery([]byte(tt.Input))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.Input, err)
+		}
+		if _, isRaw := q.(RawStringQuery); isRaw != tt.Raw {
+			t.Errorf("%s: expected raw query = %v; got: %T", tt.Input, tt.Raw, q)
+		}
+		expected := tt.Expected
+		if expected == "" {
+			expected = tt.Input
+		}
+		if want, have := normalizeJSON(t, []byte(expected)), sourceJSON(t, q); want != have {
+			t.Errorf("%s:\nexpected %s\n     got %s", tt.Input, want, have)
+		}
+	}
+}
+
+func TestParseQueryBuilders(t *testing.T) {
+	q, err := ParseQuery([]byte(`{"bool":{"must":[{"term":{"user":"olivere"}},{"combined_fields":{"query":"database systems","fields":["title","abstract"]}}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	boolQuery, ok := q.(*BoolQuery)
+	if !ok {
+		t.Fatalf("expected *BoolQuery; got: %T", q)
+	}
+	if len(boolQuery.mustClauses) != 2 {
+		t.Fatalf("expected %d must clauses; got: %d", 2, len(boolQuery.mustClauses))
+	}
+	if _, ok := boolQuery.mustClauses[0].(*TermQuery); !ok {
+		t.Errorf("expected *TermQuery; got: %T", boolQuery.mustClauses[0])
+	}
+	if _, ok := boolQuery.mustClauses[1].(RawStringQuery); !ok {
+		t.Errorf("expected RawStringQuery; got: %T", boolQuery.mustClauses[1])
+	}
+
+	// Modify the parsed query
+	boolQuery = boolQuery.Filter(NewTermQuery("retweets", 0))
+	want := `{"bool":{"filter":{"term":{"retweets":0}},"must":[{"term":{"user":"olivere"}},{"combined_fields":{"fields":["title","abstract"],"query":"database systems"}}]}}`
+	if have := sourceJSON(t, boolQuery); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for _, input := range []string{``, `[]`, `"match_all"`, `{"match_all":{}`, `{"match_all":{}} {}`} {
+		if _, err := ParseQuery([]byte(input)); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+}
+
+func TestParseAggregation(t *testing.T) {
+	tests := []struct {
+		Input string
+		Raw   bool // expect a RawAggregation
+	}{
+		{Input: `{"avg":{"field":"grade","missing":10}}`},
+		{Input: `{"sum":{"script":{"source":"doc['price'].value * params.f","lang":"painless","params":{"f":1.2}}}}`},
+		{Input: `{"min":{"field":"price","format":"0.00"}}`},
+		{Input: `{"max":{"script":{"source":"doc['price'].value"}}}`},
+		{Input: `{"stats":{"field":"grade"}}`},
+		{Input: `{"extended_stats":{"field":"grade"}}`},
+		{Input: `{"value_count":{"field":"grade"}}`},
+		{Input: `{"cardinality":{"field":"user","precision_threshold":100}}`},
+		{Input: `{"terms":{"field":"user","size":10,"min_doc_count":1,"order":[{"_count":"desc"},{"_key":"asc"}],"include":"oli.*","exclude":["sandrae"]},"aggregations":{"avg_retweets":{"avg":{"field":"retweets"}}},"meta":{"color":"blue"}}`},
+		{Input: `{"terms":{"field":"user","include":{"partition":1,"num_partitions":10}}}`},
+		{Input: `{"filter":{"term":{"user":"olivere"}},"aggregations":{"users":{"terms":{"field":"user"}}}}`},
+		{Input: `{"filters":{"filters":{"errors":{"match":{"body":{"query":"error"}}},"warnings":{"match":{"body":{"query":"warning"}}}}}}`},
+		{Input: `{"filters":{"filters":[{"match":{"body":{"query":"error"}}}]}}`},
+		{Input: `{"date_histogram":{"field":"created","interval":"1d","time_zone":"Europe/Berlin","min_doc_count":0,"extended_bounds":{"min":"now-1M","max":"now"},"order":{"_key":"desc"}}}`},
+		{Input: `{"histogram":{"field":"price","interval":50,"offset":5,"extended_bounds":{"min":0,"max":500}}}`},
+		{Input: `{"range":{"field":"price","keyed":true,"ranges":[{"to":100},{"key":"mid","from":100,"to":200},{"from":200.5}]}}`},
+		{Input: `{"nested":{"path":"resellers"},"aggregations":{"min_price":{"min":{"field":"resellers.price"}}}}`},
+		{Input: `{"reverse_nested":{}}`},
+		{Input: `{"global":{}}`},
+		{Input: `{"missing":{"field":"price"}}`},
+		{Input: `{"percentiles":{"field":"load_time","percents":[95,99],"tdigest":{"compression":200}}}`},
+		{Input: `{"percentiles":{"field":"load_time","hdr":{"number_of_significant_value_digits":3}}}`},
+		{Input: `{"percentile_ranks":{"field":"load_time","values":[500,600]}}`},
+		{Input: `{"weighted_avg":{"value":{"field":"grade","missing":2},"weight":{"field":"weight"},"format":"0.0"}}`},
+		{Input: `{"geo_bounds":{"field":"location","wrap_longitude":true}}`},
+		{Input: `{"geo_centroid":{"field":"location"}}`},
+		{Input: `{"matrix_stats":{"fields":["poverty","income"],"mode":"avg"}}`},
+		{Input: `{"scripted_metric":{"init_script":{"source":"state.transactions = []"},"map_script":{"source":"state.transactions.add(doc.amount.value)"},"combine_script":{"source":"return state.transactions"},"reduce_script":{"source":"return states"},"params":{"field":"amount"}}}`},
+		{Input: `{"top_hits":{"size":1,"sort":[{"date":{"order":"desc"}}],"_source":{"includes":["title"]}}}`},
+		{Input: `{"date_range":{"field":"date","format":"MM-yyyy","ranges":[{"to":"now-10M/M"},{"key":"recent","from":"now-10M/M"}]}}`},
+		{Input: `{"ip_range":{"field":"ip","ranges":[{"to":"10.0.0.5"},{"from":"10.0.0.5"},{"mask":"10.0.0.0/25"}]}}`},
+		{Input: `{"geo_distance":{"field":"location","origin":"52.3760, 4.894","unit":"km","ranges":[{"to":100},{"from":100,"to":300},{"from":300}]}}`},
+		{Input: `{"geohash_grid":{"field":"location","precision":5,"size":100}}`},
+		{Input: `{"children":{"type":"answer"},"aggregations":{"top_names":{"terms":{"field":"owner.display_name"}}}}`},
+		{Input: `{"adjacency_matrix":{"filters":{"grpA":{"terms":{"accounts":["hillary","sidney"]}},"grpB":{"terms":{"accounts":["donald","mitt"]}}}}}`},
+		{Input: `{"auto_date_histogram":{"field":"date","buckets":10,"format":"yyyy-MM-dd"}}`},
+		{Input: `{"composite":{"size":2,"sources":[{"date":{"date_histogram":{"field":"timestamp","interval":"1d","format":"yyyy-MM-dd"}}},{"product":{"terms":{"field":"product","order":"desc"}}},{"price":{"histogram":{"field":"price","interval":5}}}],"after":{"date":1494288000000,"product":"mad max"}}}`},
+		{Input: `{"sampler":{"shard_size":200},"aggregations":{"keywords":{"significant_terms":{"field":"tags"}}}}`},
+		{Input: `{"diversified_sampler":{"shard_size":200,"field":"author","max_docs_per_value":3}}`},
+		{Input: `{"significant_terms":{"field":"crime_type","min_doc_count":10,"background_filter":{"term":{"text":"spain"}},"gnd":{"background_is_superset":false}}}`},
+		{Input: `{"significant_terms":{"field":"crime_type","script_heuristic":{"script":{"source":"params._subset_freq"}}}}`},
+		{Input: `{"significant_text":{"field":"content","size":20,"include":"oli.*","exclude":["sandrae"]}}`},
+		{Input: `{"date_histogram":{"field":"date","interval":"month"},"aggregations":{"sales":{"sum":{"field":"price"}},"sales_deriv":{"derivative":{"buckets_path":"sales","unit":"day"}},"cumulative_sales":{"cumulative_sum":{"buckets_path":"sales"}},"sales_diff":{"serial_diff":{"buckets_path":"sales","lag":7}},"sales_avg":{"moving_avg":{"buckets_path":"sales","window":30,"model":"holt_winters","settings":{"alpha":0.5,"beta":0.5,"gamma":0.5,"period":7,"type":"mult","pad":true},"minimize":true}},"sales_fn":{"moving_fn":{"buckets_path":"sales","window":10,"script":{"source":"MovingFunctions.unweightedAvg(values)"}}},"t":{"bucket_script":{"buckets_path":{"s":"sales"},"script":{"source":"params.s / 2"}}},"s":{"bucket_selector":{"buckets_path":{"s":"sales"},"script":{"source":"params.s > 200"},"gap_policy":"skip"}},"sort":{"bucket_sort":{"sort":[{"sales":{"order":"desc"}}],"size":3}}}}`},
+		{Input: `{"avg_bucket":{"buckets_path":"sales_per_month>sales","format":"0.0"}}`},
+		{Input: `{"max_bucket":{"buckets_path":"sales_per_month>sales","gap_policy":"skip"}}`},
+		{Input: `{"min_bucket":{"buckets_path":["a>b","c>d"]}}`},
+		{Input: `{"sum_bucket":{"buckets_path":"sales_per_month>sales"}}`},
+		{Input: `{"stats_bucket":{"buckets_path":"sales_per_month>sales"}}`},
+		{Input: `{"extended_stats_bucket":{"buckets_path":"sales_per_month>sales","sigma":3}}`},
+		{Input: `{"percentiles_bucket":{"buckets_path":"sales_per_month>sales","percents":[25,50,75]}}`},
+		{Input: `{"moving_avg":{"buckets_path":"sales","model":"ewma","settings":{"alpha":0.5}}}`},
+
+		// Fallbacks
+		{Input: `{"percentiles":{"field":"load_time","tdigest":{"compression":200},"hdr":{"number_of_significant_value_digits":3}}}`, Raw: true},
+		{Input: `{"top_hits":{"size":1},"meta":{"color":"blue"}}`, Raw: true},
+		{Input: `{"avg_bucket":{"buckets_path":"sales"},"aggregations":{"a":{"avg":{"field":"grade"}}}}`, Raw: true},
+		{Input: `{"moving_fn":{"buckets_path":"sales","script":{"source":"MovingFunctions.max(values)"}}}`, Raw: true},
+		{Input: `{"moving_avg":{"buckets_path":"sales","model":"linear","settings":{"alpha":0.5}}}`, Raw: true},
+		{Input: `{"extended_stats_bucket":{"buckets_path":"sales","sigma":-1}}`, Raw: true},
+		{Input: `{"composite":{"sources":[{"price":{"histogram":{"field":"price"}}}]}}`, Raw: true},
+		{Input: `{"geo_distance":{"field":"location","origin":{"lat":52.376,"lon":4.894},"ranges":[{"to":100}]}}`, Raw: true},
+		{Input: `{"ip_range":{"field":"ip","ranges":[{"mask":"10.0.0.0/25","from":"10.0.0.5"}]}}`, Raw: true},
+		{Input: `{"significant_text":{"field":"content","filter_duplicate_text":true}}`, Raw: true},
+		{Input: `{"date_histogram":{"field":"created","calendar_interval":"1d"}}`, Raw: true},
+		{Input: `{"terms":{"field":"user","order":{"_count":"desc","_key":"asc"}}}`, Raw: true},
+		{Input: `{"avg":{"field":"grade"},"sum":{"field":"grade"}}`, Raw: true},
+		{Input: `{"avg":{"field":"grade"},"aggs":{},"aggregations":{}}`, Raw: true},
+		{Input: `{"avg":{"script":{"source":"{\"a\":1}"}}}`, Raw: true},
+	}
+
+	for _, tt := range tests {
+		agg, err := ParseAggregation([]byte(tt.Input))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.Input, err)
+		}
+		if _, isRaw := agg.(RawAggregation); isRaw != tt.Raw {
+			t.Errorf("%s: expected raw aggregation = %v; got: %T", tt.Input, tt.Raw, agg)
+		}
+		if want, have := normalizeJSON(t, []byte(tt.Input)), sourceJSON(t, agg); want != have {
+			t.Errorf("%s:\nexpected %s\n     got %s", tt.Input, want, have)
+		}
+	}
+}
+
+func TestParseAggregations(t *testing.T) {
+	aggs, err := ParseAggregations([]byte(`{"users":{"terms":{"field":"user"},"aggs":{"p":{"rare_terms":{"field":"retweets"}}}},"avg":{"avg":{"field":"retweets"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(aggs); want != have {
+		t.Fatalf("expected %d aggregations; got: %d", want, have)
+	}
+	terms, ok := aggs["users"].(*TermsAggregation)
+	if !ok {
+		t.Fatalf("expected *TermsAggregation; got: %T", aggs["users"])
+	}
+	if _, ok := terms.subAggregations["p"].(RawAggregation); !ok {
+		t.Errorf("expected RawAggregation; got: %T", terms.subAggregations["p"])
+	}
+	if _, ok := aggs["avg"].(*AvgAggregation); !ok {
+		t.Errorf("expected *AvgAggregation; got: %T", aggs["avg"])
+	}
+}
+
+func TestParseSearchSource(t *testing.T) {
+	tests := []struct {
+		Input    string
+		Expected string // defaults to Input
+	}{
+		{Input: `{}`},
+		{Input: `{"query":{"term":{"user":"olivere"}},"post_filter":{"exists":{"field":"message"}},"from":10,"size":20}`},
+		{Input: `{"timeout":"1s","terminate_after":100,"min_score":0.5,"version":true,"explain":false,"track_scores":true,"profile":true}`},
+		{Input: `{"track_total_hits":10000,"search_after":[1463538857,"654323"]}`},
+		{Input: `{"track_total_hits":false}`},
+		{Input: `{"sort":[{"user":{"order":"asc"}},{"_score":{"order":"desc"}},"_doc"]}`},
+		{
+			Input:    `{"sort":"user"}`,
+			Expected: `{"sort":[{"user":{"order":"asc"}}]}`,
+		},
+		{
+			Input:    `{"sort":[{"created":"desc"},"_score",{"price":{"missing":"_last","mode":"avg","unmapped_type":"long"}}]}`,
+			Expected: `{"sort":[{"created":{"order":"desc"}},{"_score":{"order":"desc"}},{"price":{"order":"asc","missing":"_last","mode":"avg","unmapped_type":"long"}}]}`,
+		},
+		{Input: `{"sort":[{"_geo_distance":{"location":[13.4,52.5],"order":"asc"}},{"price":{"order":"asc","numeric_type":"double"}}]}`},
+		{Input: `{"_source":false}`},
+		{Input: `{"_source":{"includes":["user","message"],"excludes":["secret"]}}`},
+		{
+			Input:    `{"_source":"user"}`,
+			Expected: `{"_source":{"includes":["user"]}}`,
+		},
+		{Input: `{"stored_fields":["user","message"]}`},
+		{Input: `{"docvalue_fields":["user",{"field":"created","format":"epoch_millis"}]}`},
+		{Input: `{"aggregations":{"users":{"terms":{"field":"user"}}}}`},
+		{
+			Input:    `{"aggs":{"users":{"terms":{"field":"user"}}}}`,
+			Expected: `{"aggregations":{"users":{"terms":{"field":"user"}}}}`,
+		},
+		{Input: `{"pit":{"id":"abc","keep_alive":"1m"}}`},
+		{Input: `{"stats":["group1"]}`},
+		{Input: `{"fields":["user.*",{"field":"created","format":"epoch_millis"},{"field":"tags","include_unmapped":true}]}`},
+		{Input: `{"runtime_mappings":{"day":{"script":{"params":{"n":1},"source":"emit(params.n)"},"type":"long"},"created":{"format":"yyyy-MM-dd","type":"date"}}}`},
+
+		// Keys kept as they are
+		{Input: `{"highlight":{"fields":{"message":{}}},"suggest":{"s":{"text":"olivere","term":{"field":"user"}}}}`},
+		{Input: `{"from":"10","size":12345678901234567890}`},
+	}
+
+	for _, tt := range tests {
+		ss, err := ParseSearchSource([]byte(tt.Input))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.Input, err)
+		}
+		expected := tt.Expected
+		if expected == "" {
+			expected = tt.Input
+		}
+		if want, have := normalizeJSON(t, []byte(expected)), sourceJSON(t, ss); want != have {
+			t.Errorf("%s:\nexpected %s\n     got %s", tt.Input, want, have)
+		}
+	}
+}
+
+func TestParseSearchSourceModify(t *testing.T) {
+	ss, err := ParseSearchSource([]byte(`{"query":{"match_all":{}},"size":10,"highlight":{"fields":{"message":{}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss = ss.Size(20).Highlight(NewHighlight().Field("user"))
+	want := `{"highlight":{"fields":{"user":{}}},"query":{"match_all":{}},"size":20}`
+	if have := sourceJSON(t, ss); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+}
+
+func TestParseSearchSourceRuntimeMappingsModify(t *testing.T) {
+	ss, err := ParseSearchSource([]byte(`{"runtime_mappings":{"day":{"type":"keyword"}},"fields":["day"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss = ss.RuntimeMapping("day", NewRuntimeMapping("long")).FetchField("user")
+	want := `{"fields":["day","user"],"runtime_mappings":{"day":{"type":"long"}}}`
+	if have := sourceJSON(t, ss); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+}
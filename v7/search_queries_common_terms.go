@@ -80,6 +80,11 @@ func (q *CommonTermsQuery) QueryName(queryName string) *CommonTermsQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *CommonTermsQuery) FieldName() string {
+	return q.name
+}
+
 // Creates the query source for the common query.
 func (q *CommonTermsQuery) Source() (interface{}, error) {
 	//  {
@@ -0,0 +1,82 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// DistanceFeatureQuery boosts the relevance score of documents closer to
+// an origin date or point. It works on date, date_nanos, and geo_point
+// fields.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-distance-feature-query.html
+type DistanceFeatureQuery struct {
+	field     string
+	origin    interface{}
+	pivot     string
+	boost     *float64
+	queryName string
+}
+
+// NewDistanceFeatureQuery creates and initializes a new DistanceFeatureQuery.
+//
+// For date fields, origin is a date or date math expression, e.g. "now",
+// and pivot is a time unit, e.g. "7d". For geo_point fields, origin is
+// a *GeoPoint or a point in any format that Elasticsearch accepts, e.g.
+// "52.5,13.4", and pivot is a distance unit, e.g. "1km". Documents with
+// a distance of pivot from the origin get half of the boost.
+func NewDistanceFeatureQuery(field string, origin interface{}, pivot string) *DistanceFeatureQuery {
+	return &DistanceFeatureQuery{
+		field:  field,
+		origin: origin,
+		pivot:  pivot,
+	}
+}
+
+// Boost sets the boost for this query.
+func (q *DistanceFeatureQuery) Boost(boost float64) *DistanceFeatureQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *DistanceFeatureQuery) QueryName(queryName string) *DistanceFeatureQuery {
+	q.queryName = queryName
+	return q
+}
+
+// FieldName returns the name of the field the query runs on.
+func (q *DistanceFeatureQuery) FieldName() string {
+	return q.field
+}
+
+// Source returns JSON for the query.
+func (q *DistanceFeatureQuery) Source() (interface{}, error) {
+	// {
+	//   "distance_feature" : {
+	//     "field" : "production_date",
+	//     "pivot" : "7d",
+	//     "origin" : "now"
+	//   }
+	// }
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["distance_feature"] = params
+
+	params["field"] = q.field
+	if pt, ok := q.origin.(*GeoPoint); ok {
+		params["origin"] = pt.Source()
+	} else {
+		params["origin"] = q.origin
+	}
+	params["pivot"] = q.pivot
+
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDistanceFeatureQuery(t *testing.T) {
+	tests := []struct {
+		Query    *DistanceFeatureQuery
+		Expected string
+	}{
+		{
+			NewDistanceFeatureQuery("production_date", "now", "7d"),
+			`{"distance_feature":{"field":"production_date","origin":"now","pivot":"7d"}}`,
+		},
+		{
+			NewDistanceFeatureQuery("location", GeoPointFromLatLon(52.5, 13.4), "1km").Boost(2).QueryName("my_query_name"),
+			`{"distance_feature":{"_name":"my_query_name","boost":2,"field":"location","origin":{"lat":52.5,"lon":13.4},"pivot":"1km"}}`,
+		},
+		{
+			NewDistanceFeatureQuery("location", []float64{13.4, 52.5}, "1km"),
+			`{"distance_feature":{"field":"location","origin":[13.4,52.5],"pivot":"1km"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		src, err := tt.Query.Source()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("marshaling to JSON failed: %v", err)
+		}
+		if got := string(data); got != tt.Expected {
+			t.Errorf("expected\n%s\n,got:\n%s", tt.Expected, got)
+		}
+	}
+}
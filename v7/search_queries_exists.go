@@ -28,6 +28,11 @@ func (q *ExistsQuery) QueryName(queryName string) *ExistsQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *ExistsQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns the JSON serializable content for this query.
 func (q *ExistsQuery) Source() (interface{}, error) {
 	// {
@@ -0,0 +1,71 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// FieldMaskingSpanQuery allows span queries like SpanNearQuery or
+// SpanOrQuery to work across different fields by pretending that the
+// wrapped span query runs on another field, e.g. to combine a field
+// with its differently analyzed sub-fields.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-span-field-masking-query.html
+type FieldMaskingSpanQuery struct {
+	query     Query
+	field     string
+	boost     *float64
+	queryName string
+}
+
+// NewFieldMaskingSpanQuery creates and initializes a new
+// FieldMaskingSpanQuery. The query must be a span query.
+func NewFieldMaskingSpanQuery(query Query, field string) *FieldMaskingSpanQuery {
+	return &FieldMaskingSpanQuery{query: query, field: field}
+}
+
+// Boost sets the boost for this query.
+func (q *FieldMaskingSpanQuery) Boost(boost float64) *FieldMaskingSpanQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *FieldMaskingSpanQuery) QueryName(queryName string) *FieldMaskingSpanQuery {
+	q.queryName = queryName
+	return q
+}
+
+// FieldName returns the name of the field the query pretends to run on.
+func (q *FieldMaskingSpanQuery) FieldName() string {
+	return q.field
+}
+
+// Source returns JSON for the query.
+func (q *FieldMaskingSpanQuery) Source() (interface{}, error) {
+	// {"field_masking_span":{"query":{"span_term":{"text.stems":"fox"}},"field":"text"}}
+	if q.query == nil {
+		return nil, errors.New("FieldMaskingSpanQuery expected a query")
+	}
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["field_masking_span"] = params
+
+	src, err := q.query.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["query"] = src
+	params["field"] = q.field
+
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
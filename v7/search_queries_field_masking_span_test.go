@@ -0,0 +1,33 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFieldMaskingSpanQuery(t *testing.T) {
+	q := NewSpanNearQuery(NewSpanTermQuery("text", "quick brown"), NewFieldMaskingSpanQuery(NewSpanTermQuery("text.stems", "fox"), "text")).Slop(5).InOrder(false)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"span_near":{"clauses":[{"span_term":{"text":"quick brown"}},{"field_masking_span":{"field":"text","query":{"span_term":{"text.stems":"fox"}}}}],"in_order":false,"slop":5}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestFieldMaskingSpanQueryWithoutQuery(t *testing.T) {
+	if _, err := NewFieldMaskingSpanQuery(nil, "text").Source(); err == nil {
+		t.Fatal("expected error without query")
+	}
+}
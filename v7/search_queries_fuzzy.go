@@ -72,6 +72,11 @@ func (q *FuzzyQuery) QueryName(queryName string) *FuzzyQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *FuzzyQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns JSON for the function score query.
 func (q *FuzzyQuery) Source() (interface{}, error) {
 	// {
@@ -80,6 +80,11 @@ func (q *GeoBoundingBoxQuery) QueryName(queryName string) *GeoBoundingBoxQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *GeoBoundingBoxQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns JSON for the function score query.
 func (q *GeoBoundingBoxQuery) Source() (interface{}, error) {
 	// {
@@ -66,6 +66,11 @@ func (q *GeoDistanceQuery) QueryName(queryName string) *GeoDistanceQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *GeoDistanceQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns JSON for the function score query.
 func (q *GeoDistanceQuery) Source() (interface{}, error) {
 	// {
@@ -39,6 +39,11 @@ func (q *GeoPolygonQuery) QueryName(queryName string) *GeoPolygonQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *GeoPolygonQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns JSON for the function score query.
 func (q *GeoPolygonQuery) Source() (interface{}, error) {
 	// "geo_polygon" : {
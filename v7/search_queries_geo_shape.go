@@ -0,0 +1,195 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// GeoShapeQuery finds documents with a geo_shape or geo_point field that
+// relates to a shape, e.g. all documents within a GeoShapePolygon. The
+// shape is either given inline as a GeoShapeGeometry or as a reference to
+// a shape indexed in another document.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-geo-shape-query.html
+type GeoShapeQuery struct {
+	name           string
+	shape          GeoShapeGeometry
+	wkt            bool
+	indexedShape   *IndexedShape
+	relation       string
+	ignoreUnmapped *bool
+	boost          *float64
+	queryName      string
+}
+
+// NewGeoShapeQuery creates and initializes a new GeoShapeQuery.
+func NewGeoShapeQuery(name string) *GeoShapeQuery {
+	return &GeoShapeQuery{name: name}
+}
+
+// Shape sets the shape to match against.
+func (q *GeoShapeQuery) Shape(shape GeoShapeGeometry) *GeoShapeQuery {
+	q.shape = shape
+	return q
+}
+
+// WKT specifies whether to send the shape as Well-Known Text instead of
+// GeoJSON. It defaults to false.
+func (q *GeoShapeQuery) WKT(wkt bool) *GeoShapeQuery {
+	q.wkt = wkt
+	return q
+}
+
+// IndexedShape sets a reference to a shape indexed in another document
+// to match against, instead of an inline shape.
+func (q *GeoShapeQuery) IndexedShape(indexedShape *IndexedShape) *GeoShapeQuery {
+	q.indexedShape = indexedShape
+	return q
+}
+
+// Relation sets the spatial relation between the field and the shape,
+// i.e. "intersects" (the default), "disjoint", "within", or "contains".
+func (q *GeoShapeQuery) Relation(relation string) *GeoShapeQuery {
+	q.relation = relation
+	return q
+}
+
+// IgnoreUnmapped indicates whether to ignore an unmapped field and not
+// match any documents, instead of failing.
+func (q *GeoShapeQuery) IgnoreUnmapped(ignoreUnmapped bool) *GeoShapeQuery {
+	q.ignoreUnmapped = &ignoreUnmapped
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *GeoShapeQuery) Boost(boost float64) *GeoShapeQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *GeoShapeQuery) QueryName(queryName string) *GeoShapeQuery {
+	q.queryName = queryName
+	return q
+}
+
+// FieldName returns the name of the field the query runs on.
+func (q *GeoShapeQuery) FieldName() string {
+	return q.name
+}
+
+// Source returns JSON for the query.
+func (q *GeoShapeQuery) Source() (interface{}, error) {
+	// {
+	//   "geo_shape": {
+	//     "location": {
+	//       "shape": {
+	//         "type": "envelope",
+	//         "coordinates": [[13.0, 53.0], [14.0, 52.0]]
+	//       },
+	//       "relation": "within"
+	//     }
+	//   }
+	// }
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["geo_shape"] = params
+
+	field, err := shapeQueryField(q.shape, q.wkt, q.indexedShape, q.relation)
+	if err != nil {
+		return nil, err
+	}
+	params[q.name] = field
+
+	if q.ignoreUnmapped != nil {
+		params["ignore_unmapped"] = *q.ignoreUnmapped
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
+
+// -- Indexed shape --
+
+// IndexedShape is a reference to a shape indexed in a document, used in
+// GeoShapeQuery and ShapeQuery.
+type IndexedShape struct {
+	index   string
+	id      string
+	path    string
+	routing string
+}
+
+// NewIndexedShape creates a reference to the shape of the document with
+// the given index and id.
+func NewIndexedShape(index, id string) *IndexedShape {
+	return &IndexedShape{index: index, id: id}
+}
+
+// Path sets the field of the document that contains the shape.
+// It defaults to "shape".
+func (s *IndexedShape) Path(path string) *IndexedShape {
+	s.path = path
+	return s
+}
+
+// Routing sets the routing of the document.
+func (s *IndexedShape) Routing(routing string) *IndexedShape {
+	s.routing = routing
+	return s
+}
+
+// Source returns JSON for the indexed shape.
+func (s *IndexedShape) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	source["index"] = s.index
+	source["id"] = s.id
+	if s.path != "" {
+		source["path"] = s.path
+	}
+	if s.routing != "" {
+		source["routing"] = s.routing
+	}
+	return source, nil
+}
+
+// shapeQueryField returns the JSON for the field of a geo_shape or
+// shape query.
+func shapeQueryField(shape GeoShapeGeometry, wkt bool, indexedShape *IndexedShape, relation string) (map[string]interface{}, error) {
+	field := make(map[string]interface{})
+	switch {
+	case shape != nil && indexedShape != nil:
+		return nil, errors.New("elastic: specify either a shape or an indexed shape, not both")
+	case shape != nil && wkt:
+		src, err := shape.WKT()
+		if err != nil {
+			return nil, err
+		}
+		field["shape"] = src
+	case shape != nil:
+		src, err := shape.Source()
+		if err != nil {
+			return nil, err
+		}
+		field["shape"] = src
+	case indexedShape != nil:
+		src, err := indexedShape.Source()
+		if err != nil {
+			return nil, err
+		}
+		field["indexed_shape"] = src
+	default:
+		return nil, errors.New("elastic: missing shape or indexed shape")
+	}
+	if relation != "" {
+		field["relation"] = relation
+	}
+	return field, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeoShapeQuery(t *testing.T) {
+	q := NewGeoShapeQuery("location").
+		Shape(NewGeoShapeEnvelope(NewGeoShapePoint(53, 13), NewGeoShapePoint(52, 14))).
+		Relation("within")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_shape":{"location":{"relation":"within","shape":{"coordinates":[[13,53],[14,52]],"type":"envelope"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoShapeQueryWithWKT(t *testing.T) {
+	q := NewGeoShapeQuery("location").
+		Shape(NewGeoShapePoint(52.5, 13.4)).
+		WKT(true).
+		IgnoreUnmapped(true).
+		Boost(2).
+		QueryName("my_query_name")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_shape":{"_name":"my_query_name","boost":2,"ignore_unmapped":true,"location":{"shape":"POINT (13.4 52.5)"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoShapeQueryWithIndexedShape(t *testing.T) {
+	q := NewGeoShapeQuery("location").
+		IndexedShape(NewIndexedShape("zones", "center").Path("area").Routing("berlin")).
+		Relation("intersects")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_shape":{"location":{"indexed_shape":{"id":"center","index":"zones","path":"area","routing":"berlin"},"relation":"intersects"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoShapeQueryValidate(t *testing.T) {
+	if _, err := NewGeoShapeQuery("location").Source(); err == nil {
+		t.Fatal("expected error without shape")
+	}
+	q := NewGeoShapeQuery("location").
+		Shape(NewGeoShapePoint(52.5, 13.4)).
+		IndexedShape(NewIndexedShape("zones", "center"))
+	if _, err := q.Source(); err == nil {
+		t.Fatal("expected error with both shape and indexed shape")
+	}
+}
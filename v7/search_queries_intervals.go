@@ -0,0 +1,93 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// IntervalsQuery returns documents based on the order and proximity of
+// matching terms, described by a rule like IntervalsMatchRule or
+// IntervalsAllOfRule. It is available since Elasticsearch 7.0.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-intervals-query.html
+type IntervalsQuery struct {
+	name      string
+	rule      IntervalsRule
+	boost     *float64
+	queryName string
+}
+
+// NewIntervalsQuery creates and initializes a new IntervalsQuery.
+func NewIntervalsQuery(name string, rule IntervalsRule) *IntervalsQuery {
+	return &IntervalsQuery{name: name, rule: rule}
+}
+
+// Boost sets the boost for this query.
+func (q *IntervalsQuery) Boost(boost float64) *IntervalsQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *IntervalsQuery) QueryName(queryName string) *IntervalsQuery {
+	q.queryName = queryName
+	return q
+}
+
+// FieldName returns the name of the field the query runs on.
+func (q *IntervalsQuery) FieldName() string {
+	return q.name
+}
+
+// Source returns JSON for the query.
+func (q *IntervalsQuery) Source() (interface{}, error) {
+	// {
+	//   "intervals":{
+	//     "my_text":{
+	//       "all_of":{
+	//         "ordered":true,
+	//         "intervals":[
+	//           {"match":{"query":"my favorite food","max_gaps":0,"ordered":true}},
+	//           {"any_of":{"intervals":[{"match":{"query":"hot water"}},{"match":{"query":"cold porridge"}}]}}
+	//         ]
+	//       }
+	//     }
+	//   }
+	// }
+	if q.rule == nil {
+		return nil, errors.New("elastic: missing intervals rule")
+	}
+
+	source := make(map[string]interface{})
+	fields := make(map[string]interface{})
+	source["intervals"] = fields
+
+	params := make(map[string]interface{})
+	fields[q.name] = params
+
+	src, err := q.rule.Source()
+	if err != nil {
+		return nil, err
+	}
+	rule, ok := src.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("elastic: intervals rule must serialize into an object, got %T", src)
+	}
+	for k, v := range rule {
+		params[k] = v
+	}
+
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// IntervalsFilter restricts the intervals of a rule, e.g. to intervals
+// that do not overlap with the intervals of another rule, or with a
+// script. It is used with IntervalsMatchRule, IntervalsAllOfRule, and
+// IntervalsAnyOfRule.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-intervals-query.html#interval_filter
+type IntervalsFilter struct {
+	after          IntervalsRule
+	before         IntervalsRule
+	containedBy    IntervalsRule
+	containing     IntervalsRule
+	notContainedBy IntervalsRule
+	notContaining  IntervalsRule
+	notOverlapping IntervalsRule
+	overlapping    IntervalsRule
+	script         *Script
+}
+
+// NewIntervalsFilter creates and initializes a new IntervalsFilter.
+func NewIntervalsFilter() *IntervalsFilter {
+	return &IntervalsFilter{}
+}
+
+// After keeps intervals that follow an interval of rule.
+func (f *IntervalsFilter) After(rule IntervalsRule) *IntervalsFilter {
+	f.after = rule
+	return f
+}
+
+// Before keeps intervals that occur before an interval of rule.
+func (f *IntervalsFilter) Before(rule IntervalsRule) *IntervalsFilter {
+	f.before = rule
+	return f
+}
+
+// ContainedBy keeps intervals contained by an interval of rule.
+func (f *IntervalsFilter) ContainedBy(rule IntervalsRule) *IntervalsFilter {
+	f.containedBy = rule
+	return f
+}
+
+// Containing keeps intervals that contain an interval of rule.
+func (f *IntervalsFilter) Containing(rule IntervalsRule) *IntervalsFilter {
+	f.containing = rule
+	return f
+}
+
+// NotContainedBy keeps intervals that are not contained by an interval
+// of rule.
+func (f *IntervalsFilter) NotContainedBy(rule IntervalsRule) *IntervalsFilter {
+	f.notContainedBy = rule
+	return f
+}
+
+// NotContaining keeps intervals that do not contain an interval of rule.
+func (f *IntervalsFilter) NotContaining(rule IntervalsRule) *IntervalsFilter {
+	f.notContaining = rule
+	return f
+}
+
+// NotOverlapping keeps intervals that do not overlap with an interval
+// of rule.
+func (f *IntervalsFilter) NotOverlapping(rule IntervalsRule) *IntervalsFilter {
+	f.notOverlapping = rule
+	return f
+}
+
+// Overlapping keeps intervals that overlap with an interval of rule.
+func (f *IntervalsFilter) Overlapping(rule IntervalsRule) *IntervalsFilter {
+	f.overlapping = rule
+	return f
+}
+
+// Script keeps intervals for which the script returns true. The script
+// can access the interval via the variables interval.start,
+// interval.end, and interval.gaps.
+func (f *IntervalsFilter) Script(script *Script) *IntervalsFilter {
+	f.script = script
+	return f
+}
+
+// Source returns JSON for the filter.
+func (f *IntervalsFilter) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+
+	rules := []struct {
+		name string
+		rule IntervalsRule
+	}{
+		{"after", f.after},
+		{"before", f.before},
+		{"contained_by", f.containedBy},
+		{"containing", f.containing},
+		{"not_contained_by", f.notContainedBy},
+		{"not_containing", f.notContaining},
+		{"not_overlapping", f.notOverlapping},
+		{"overlapping", f.overlapping},
+	}
+	for _, r := range rules {
+		if r.rule == nil {
+			continue
+		}
+		src, err := r.rule.Source()
+		if err != nil {
+			return nil, err
+		}
+		source[r.name] = src
+	}
+	if f.script != nil {
+		src, err := f.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["script"] = src
+	}
+	return source, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIntervalsFilter(t *testing.T) {
+	f := NewIntervalsFilter().
+		ContainedBy(NewIntervalsMatchRule("a")).
+		Containing(NewIntervalsMatchRule("b")).
+		NotContainedBy(NewIntervalsMatchRule("c")).
+		NotOverlapping(NewIntervalsMatchRule("d")).
+		Overlapping(NewIntervalsMatchRule("e"))
+	src, err := f.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"contained_by":{"match":{"query":"a"}},"containing":{"match":{"query":"b"}},"not_contained_by":{"match":{"query":"c"}},"not_overlapping":{"match":{"query":"d"}},"overlapping":{"match":{"query":"e"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestIntervalsFilterWithScript(t *testing.T) {
+	f := NewIntervalsFilter().Script(NewScript("interval.gaps == 0"))
+	src, err := f.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"script":{"source":"interval.gaps == 0"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// IntervalsRule is a rule of an IntervalsQuery, e.g. IntervalsMatchRule.
+// Its Source method must return an object with the name of the rule as
+// its only key, e.g. {"match":{"query":"hot water"}}.
+type IntervalsRule interface {
+	Source() (interface{}, error)
+}
+
+// intervalsRulesSource returns the JSON of a list of rules.
+func intervalsRulesSource(rules []IntervalsRule) ([]interface{}, error) {
+	list := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		src, err := rule.Source()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, src)
+	}
+	return list, nil
+}
+
+// -- match --
+
+// IntervalsMatchRule matches analyzed text.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-intervals-query.html#intervals-match
+type IntervalsMatchRule struct {
+	query    string
+	maxGaps  *int
+	ordered  *bool
+	analyzer string
+	useField string
+	filter   *IntervalsFilter
+}
+
+// NewIntervalsMatchRule creates and initializes a new IntervalsMatchRule.
+func NewIntervalsMatchRule(query string) *IntervalsMatchRule {
+	return &IntervalsMatchRule{query: query}
+}
+
+// MaxGaps sets the maximum number of positions between the matching
+// terms. Terms further apart are not considered matches. It defaults
+// to -1, i.e. no restriction.
+func (r *IntervalsMatchRule) MaxGaps(maxGaps int) *IntervalsMatchRule {
+	r.maxGaps = &maxGaps
+	return r
+}
+
+// Ordered specifies whether the matching terms must appear in the order
+// given in the query.
+func (r *IntervalsMatchRule) Ordered(ordered bool) *IntervalsMatchRule {
+	r.ordered = &ordered
+	return r
+}
+
+// Analyzer sets the analyzer for the query.
+func (r *IntervalsMatchRule) Analyzer(analyzer string) *IntervalsMatchRule {
+	r.analyzer = analyzer
+	return r
+}
+
+// UseField matches intervals of this field instead of the field of
+// the query.
+func (r *IntervalsMatchRule) UseField(useField string) *IntervalsMatchRule {
+	r.useField = useField
+	return r
+}
+
+// Filter restricts the intervals with an IntervalsFilter.
+func (r *IntervalsMatchRule) Filter(filter *IntervalsFilter) *IntervalsMatchRule {
+	r.filter = filter
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsMatchRule) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["match"] = params
+
+	params["query"] = r.query
+	if r.maxGaps != nil {
+		params["max_gaps"] = *r.maxGaps
+	}
+	if r.ordered != nil {
+		params["ordered"] = *r.ordered
+	}
+	if r.analyzer != "" {
+		params["analyzer"] = r.analyzer
+	}
+	if r.useField != "" {
+		params["use_field"] = r.useField
+	}
+	if r.filter != nil {
+		src, err := r.filter.Source()
+		if err != nil {
+			return nil, err
+		}
+		params["filter"] = src
+	}
+	return source, nil
+}
+
+// -- prefix --
+
+// IntervalsPrefixRule matches terms that start with a prefix.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-intervals-query.html#intervals-prefix
+type IntervalsPrefixRule struct {
+	prefix   string
+	analyzer string
+	useField string
+}
+
+// NewIntervalsPrefixRule creates and initializes a new IntervalsPrefixRule.
+func NewIntervalsPrefixRule(prefix string) *IntervalsPrefixRule {
+	return &IntervalsPrefixRule{prefix: prefix}
+}
+
+// Analyzer sets the analyzer to normalize the prefix with.
+func (r *IntervalsPrefixRule) Analyzer(analyzer string) *IntervalsPrefixRule {
+	r.analyzer = analyzer
+	return r
+}
+
+// UseField matches intervals of this field instead of the field of
+// the query.
+func (r *IntervalsPrefixRule) UseField(useField string) *IntervalsPrefixRule {
+	r.useField = useField
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsPrefixRule) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["prefix"] = params
+
+	params["prefix"] = r.prefix
+	if r.analyzer != "" {
+		params["analyzer"] = r.analyzer
+	}
+	if r.useField != "" {
+		params["use_field"] = r.useField
+	}
+	return source, nil
+}
+
+// -- wildcard --
+
+// IntervalsWildcardRule matches terms using a wildcard pattern.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-intervals-query.html#intervals-wildcard
+type IntervalsWildcardRule struct {
+	pattern  string
+	analyzer string
+	useField string
+}
+
+// NewIntervalsWildcardRule creates and initializes a new IntervalsWildcardRule.
+func NewIntervalsWildcardRule(pattern string) *IntervalsWildcardRule {
+	return &IntervalsWildcardRule{pattern: pattern}
+}
+
+// Analyzer sets the analyzer to normalize the pattern with.
+func (r *IntervalsWildcardRule) Analyzer(analyzer string) *IntervalsWildcardRule {
+	r.analyzer = analyzer
+	return r
+}
+
+// UseField matches intervals of this field instead of the field of
+// the query.
+func (r *IntervalsWildcardRule) UseField(useField string) *IntervalsWildcardRule {
+	r.useField = useField
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsWildcardRule) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["wildcard"] = params
+
+	params["pattern"] = r.pattern
+	if r.analyzer != "" {
+		params["analyzer"] = r.analyzer
+	}
+	if r.useField != "" {
+		params["use_field"] = r.useField
+	}
+	return source, nil
+}
+
+// -- fuzzy --
+
+// IntervalsFuzzyRule matches terms that are similar to a given term,
+// within an edit distance. It requires Elasticsearch 7.6 or later.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-intervals-query.html#intervals-fuzzy
+type IntervalsFuzzyRule struct {
+	term           string
+	prefixLength   *int
+	transpositions *bool
+	fuzziness      interface{}
+	analyzer       string
+	useField       string
+}
+
+// NewIntervalsFuzzyRule creates and initializes a new IntervalsFuzzyRule.
+func NewIntervalsFuzzyRule(term string) *IntervalsFuzzyRule {
+	return &IntervalsFuzzyRule{term: term}
+}
+
+// PrefixLength sets the number of beginning characters left unchanged
+// when creating expansions.
+func (r *IntervalsFuzzyRule) PrefixLength(prefixLength int) *IntervalsFuzzyRule {
+	r.prefixLength = &prefixLength
+	return r
+}
+
+// Transpositions specifies whether edits include transpositions of two
+// adjacent characters.
+func (r *IntervalsFuzzyRule) Transpositions(transpositions bool) *IntervalsFuzzyRule {
+	r.transpositions = &transpositions
+	return r
+}
+
+// Fuzziness sets the maximum edit distance, e.g. 2 or "AUTO".
+func (r *IntervalsFuzzyRule) Fuzziness(fuzziness interface{}) *IntervalsFuzzyRule {
+	r.fuzziness = fuzziness
+	return r
+}
+
+// Analyzer sets the analyzer to normalize the term with.
+func (r *IntervalsFuzzyRule) Analyzer(analyzer string) *IntervalsFuzzyRule {
+	r.analyzer = analyzer
+	return r
+}
+
+// UseField matches intervals of this field instead of the field of
+// the query.
+func (r *IntervalsFuzzyRule) UseField(useField string) *IntervalsFuzzyRule {
+	r.useField = useField
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsFuzzyRule) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["fuzzy"] = params
+
+	params["term"] = r.term
+	if r.prefixLength != nil {
+		params["prefix_length"] = *r.prefixLength
+	}
+	if r.transpositions != nil {
+		params["transpositions"] = *r.transpositions
+	}
+	if r.fuzziness != nil {
+		params["fuzziness"] = r.fuzziness
+	}
+	if r.analyzer != "" {
+		params["analyzer"] = r.analyzer
+	}
+	if r.useField != "" {
+		params["use_field"] = r.useField
+	}
+	return source, nil
+}
+
+// -- all_of --
+
+// IntervalsAllOfRule returns intervals that span a combination of the
+// intervals of all of its rules.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-intervals-query.html#intervals-all_of
+type IntervalsAllOfRule struct {
+	rules   []IntervalsRule
+	maxGaps *int
+	ordered *bool
+	filter  *IntervalsFilter
+}
+
+// NewIntervalsAllOfRule creates and initializes a new IntervalsAllOfRule.
+func NewIntervalsAllOfRule(rules ...IntervalsRule) *IntervalsAllOfRule {
+	return &IntervalsAllOfRule{rules: rules}
+}
+
+// Add adds rules.
+func (r *IntervalsAllOfRule) Add(rules ...IntervalsRule) *IntervalsAllOfRule {
+	r.rules = append(r.rules, rules...)
+	return r
+}
+
+// MaxGaps sets the maximum number of positions between the intervals
+// of the rules. It defaults to -1, i.e. no restriction.
+func (r *IntervalsAllOfRule) MaxGaps(maxGaps int) *IntervalsAllOfRule {
+	r.maxGaps = &maxGaps
+	return r
+}
+
+// Ordered specifies whether the intervals must appear in the order
+// of the rules.
+func (r *IntervalsAllOfRule) Ordered(ordered bool) *IntervalsAllOfRule {
+	r.ordered = &ordered
+	return r
+}
+
+// Filter restricts the intervals with an IntervalsFilter.
+func (r *IntervalsAllOfRule) Filter(filter *IntervalsFilter) *IntervalsAllOfRule {
+	r.filter = filter
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsAllOfRule) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["all_of"] = params
+
+	rules, err := intervalsRulesSource(r.rules)
+	if err != nil {
+		return nil, err
+	}
+	params["intervals"] = rules
+	if r.maxGaps != nil {
+		params["max_gaps"] = *r.maxGaps
+	}
+	if r.ordered != nil {
+		params["ordered"] = *r.ordered
+	}
+	if r.filter != nil {
+		src, err := r.filter.Source()
+		if err != nil {
+			return nil, err
+		}
+		params["filter"] = src
+	}
+	return source, nil
+}
+
+// -- any_of --
+
+// IntervalsAnyOfRule returns intervals produced by any of its rules.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-intervals-query.html#intervals-any_of
+type IntervalsAnyOfRule struct {
+	rules  []IntervalsRule
+	filter *IntervalsFilter
+}
+
+// NewIntervalsAnyOfRule creates and initializes a new IntervalsAnyOfRule.
+func NewIntervalsAnyOfRule(rules ...IntervalsRule) *IntervalsAnyOfRule {
+	return &IntervalsAnyOfRule{rules: rules}
+}
+
+// Add adds rules.
+func (r *IntervalsAnyOfRule) Add(rules ...IntervalsRule) *IntervalsAnyOfRule {
+	r.rules = append(r.rules, rules...)
+	return r
+}
+
+// Filter restricts the intervals with an IntervalsFilter.
+func (r *IntervalsAnyOfRule) Filter(filter *IntervalsFilter) *IntervalsAnyOfRule {
+	r.filter = filter
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsAnyOfRule) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["any_of"] = params
+
+	rules, err := intervalsRulesSource(r.rules)
+	if err != nil {
+		return nil, err
+	}
+	params["intervals"] = rules
+	if r.filter != nil {
+		src, err := r.filter.Source()
+		if err != nil {
+			return nil, err
+		}
+		params["filter"] = src
+	}
+	return source, nil
+}
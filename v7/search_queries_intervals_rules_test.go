@@ -0,0 +1,63 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIntervalsRules(t *testing.T) {
+	tests := []struct {
+		Rule     IntervalsRule
+		Expected string
+	}{
+		{
+			NewIntervalsMatchRule("hot porridge"),
+			`{"match":{"query":"hot porridge"}}`,
+		},
+		{
+			NewIntervalsMatchRule("hot porridge").MaxGaps(10).Ordered(false).Analyzer("standard").UseField("my_text.stems").
+				Filter(NewIntervalsFilter().NotContaining(NewIntervalsMatchRule("salty"))),
+			`{"match":{"analyzer":"standard","filter":{"not_containing":{"match":{"query":"salty"}}},"max_gaps":10,"ordered":false,"query":"hot porridge","use_field":"my_text.stems"}}`,
+		},
+		{
+			NewIntervalsPrefixRule("hot").Analyzer("standard").UseField("my_text.stems"),
+			`{"prefix":{"analyzer":"standard","prefix":"hot","use_field":"my_text.stems"}}`,
+		},
+		{
+			NewIntervalsWildcardRule("ho?t*").Analyzer("standard").UseField("my_text.stems"),
+			`{"wildcard":{"analyzer":"standard","pattern":"ho?t*","use_field":"my_text.stems"}}`,
+		},
+		{
+			NewIntervalsFuzzyRule("porridge").PrefixLength(1).Transpositions(true).Fuzziness("AUTO").Analyzer("standard").UseField("my_text.stems"),
+			`{"fuzzy":{"analyzer":"standard","fuzziness":"AUTO","prefix_length":1,"term":"porridge","transpositions":true,"use_field":"my_text.stems"}}`,
+		},
+		{
+			NewIntervalsAllOfRule(NewIntervalsMatchRule("hot")).Add(NewIntervalsMatchRule("porridge")).MaxGaps(3).
+				Filter(NewIntervalsFilter().Before(NewIntervalsMatchRule("cold"))),
+			`{"all_of":{"filter":{"before":{"match":{"query":"cold"}}},"intervals":[{"match":{"query":"hot"}},{"match":{"query":"porridge"}}],"max_gaps":3}}`,
+		},
+		{
+			NewIntervalsAnyOfRule(NewIntervalsPrefixRule("hot")).Add(NewIntervalsWildcardRule("cold*")).
+				Filter(NewIntervalsFilter().After(NewIntervalsMatchRule("very"))),
+			`{"any_of":{"filter":{"after":{"match":{"query":"very"}}},"intervals":[{"prefix":{"prefix":"hot"}},{"wildcard":{"pattern":"cold*"}}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		src, err := tt.Rule.Source()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("marshaling to JSON failed: %v", err)
+		}
+		if got := string(data); got != tt.Expected {
+			t.Errorf("expected\n%s\n,got:\n%s", tt.Expected, got)
+		}
+	}
+}
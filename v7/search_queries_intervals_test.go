@@ -0,0 +1,57 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIntervalsQuery(t *testing.T) {
+	q := NewIntervalsQuery("my_text", NewIntervalsAllOfRule(
+		NewIntervalsMatchRule("my favorite food").MaxGaps(0).Ordered(true),
+		NewIntervalsAnyOfRule(
+			NewIntervalsMatchRule("hot water"),
+			NewIntervalsMatchRule("cold porridge"),
+		),
+	).Ordered(true))
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"intervals":{"my_text":{"all_of":{"intervals":[{"match":{"max_gaps":0,"ordered":true,"query":"my favorite food"}},{"any_of":{"intervals":[{"match":{"query":"hot water"}},{"match":{"query":"cold porridge"}}]}}],"ordered":true}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestIntervalsQueryWithOptions(t *testing.T) {
+	q := NewIntervalsQuery("my_text", NewIntervalsPrefixRule("out")).Boost(1.5).QueryName("my_query_name")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"intervals":{"my_text":{"_name":"my_query_name","boost":1.5,"prefix":{"prefix":"out"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestIntervalsQueryWithoutRule(t *testing.T) {
+	_, err := NewIntervalsQuery("my_text", nil).Source()
+	if err == nil {
+		t.Fatal("expected an error for a missing rule")
+	}
+}
@@ -132,6 +132,16 @@ func (q *MatchQuery) QueryName(queryName string) *MatchQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *MatchQuery) FieldName() string {
+	return q.name
+}
+
+// Text returns the text to match.
+func (q *MatchQuery) Text() interface{} {
+	return q.text
+}
+
 // Source returns JSON for the function score query.
 func (q *MatchQuery) Source() (interface{}, error) {
 	// {"match":{"name":{"query":"value","type":"boolean/phrase"}}}
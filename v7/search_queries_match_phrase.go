@@ -49,6 +49,11 @@ func (q *MatchPhraseQuery) QueryName(queryName string) *MatchPhraseQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *MatchPhraseQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns JSON for the function score query.
 func (q *MatchPhraseQuery) Source() (interface{}, error) {
 	// {"match_phrase":{"name":{"query":"value","analyzer":"my_analyzer"}}}
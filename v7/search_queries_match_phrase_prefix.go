@@ -56,6 +56,11 @@ func (q *MatchPhrasePrefixQuery) QueryName(queryName string) *MatchPhrasePrefixQ
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *MatchPhrasePrefixQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns JSON for the function score query.
 func (q *MatchPhrasePrefixQuery) Source() (interface{}, error) {
 	// {"match_phrase_prefix":{"name":{"query":"value","max_expansions":10}}}
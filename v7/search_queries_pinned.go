@@ -0,0 +1,91 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// PinnedQuery promotes selected documents to rank higher than those
+// matching a given "organic" query. The promoted documents are ranked
+// in the order of their ids. It requires X-Pack.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-pinned-query.html
+type PinnedQuery struct {
+	ids       []string
+	organic   Query
+	boost     *float64
+	queryName string
+}
+
+// NewPinnedQuery creates and initializes a new PinnedQuery.
+func NewPinnedQuery(organic Query, ids ...string) *PinnedQuery {
+	return &PinnedQuery{
+		organic: organic,
+		ids:     ids,
+	}
+}
+
+// Ids adds the ids of documents to promote.
+func (q *PinnedQuery) Ids(ids ...string) *PinnedQuery {
+	q.ids = append(q.ids, ids...)
+	return q
+}
+
+// Organic sets the query that ranks the documents below the promoted ones.
+func (q *PinnedQuery) Organic(organic Query) *PinnedQuery {
+	q.organic = organic
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *PinnedQuery) Boost(boost float64) *PinnedQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *PinnedQuery) QueryName(queryName string) *PinnedQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *PinnedQuery) Source() (interface{}, error) {
+	// {
+	//   "pinned" : {
+	//     "ids" : ["1", "4", "100"],
+	//     "organic" : {
+	//       "match" : { "description": "iphone" }
+	//     }
+	//   }
+	// }
+	if q.organic == nil {
+		return nil, errors.New("PinnedQuery expected an organic query")
+	}
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["pinned"] = params
+
+	ids := q.ids
+	if ids == nil {
+		ids = []string{}
+	}
+	params["ids"] = ids
+
+	src, err := q.organic.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["organic"] = src
+
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
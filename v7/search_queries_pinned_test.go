@@ -0,0 +1,33 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPinnedQuery(t *testing.T) {
+	q := NewPinnedQuery(NewMatchQuery("description", "iphone"), "1", "4").Ids("100").QueryName("my_query_name")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"pinned":{"_name":"my_query_name","ids":["1","4","100"],"organic":{"match":{"description":{"query":"iphone"}}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestPinnedQueryWithoutOrganic(t *testing.T) {
+	if _, err := NewPinnedQuery(nil, "1").Source(); err == nil {
+		t.Fatal("expected error without organic query")
+	}
+}
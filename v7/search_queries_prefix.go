@@ -40,6 +40,11 @@ func (q *PrefixQuery) QueryName(queryName string) *PrefixQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *PrefixQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns JSON for the query.
 func (q *PrefixQuery) Source() (interface{}, error) {
 	source := make(map[string]interface{})
@@ -120,6 +120,11 @@ func (q *RangeQuery) Relation(relation string) *RangeQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *RangeQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns JSON for the query.
 func (q *RangeQuery) Source() (interface{}, error) {
 	source := make(map[string]interface{})
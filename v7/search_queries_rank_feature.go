@@ -0,0 +1,189 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// RankFeatureQuery boosts the relevance score of documents based on the
+// numeric value of a rank_feature or rank_features field. The score is
+// computed by a RankFeatureScoreFunction, which defaults to saturation.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-rank-feature-query.html
+type RankFeatureQuery struct {
+	field     string
+	scoreFunc RankFeatureScoreFunction
+	boost     *float64
+	queryName string
+}
+
+// NewRankFeatureQuery creates and initializes a new RankFeatureQuery.
+func NewRankFeatureQuery(field string) *RankFeatureQuery {
+	return &RankFeatureQuery{field: field}
+}
+
+// ScoreFunction sets the function to compute the score with.
+func (q *RankFeatureQuery) ScoreFunction(f RankFeatureScoreFunction) *RankFeatureQuery {
+	q.scoreFunc = f
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *RankFeatureQuery) Boost(boost float64) *RankFeatureQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *RankFeatureQuery) QueryName(queryName string) *RankFeatureQuery {
+	q.queryName = queryName
+	return q
+}
+
+// FieldName returns the name of the field the query runs on.
+func (q *RankFeatureQuery) FieldName() string {
+	return q.field
+}
+
+// Source returns JSON for the query.
+func (q *RankFeatureQuery) Source() (interface{}, error) {
+	// {
+	//   "rank_feature": {
+	//     "field": "pagerank",
+	//     "saturation": {
+	//       "pivot": 8
+	//     }
+	//   }
+	// }
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["rank_feature"] = params
+
+	params["field"] = q.field
+	if q.scoreFunc != nil {
+		src, err := q.scoreFunc.Source()
+		if err != nil {
+			return nil, err
+		}
+		params[q.scoreFunc.Name()] = src
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
+
+// -- Score functions --
+
+// RankFeatureScoreFunction is used in combination with RankFeatureQuery.
+type RankFeatureScoreFunction interface {
+	Name() string
+	Source() (interface{}, error)
+}
+
+// RankFeatureSaturationScoreFunction computes the score as
+// S / (S + pivot), where S is the value of the rank feature.
+type RankFeatureSaturationScoreFunction struct {
+	pivot *float64
+}
+
+// NewRankFeatureSaturationScoreFunction creates a new saturation function.
+func NewRankFeatureSaturationScoreFunction() *RankFeatureSaturationScoreFunction {
+	return &RankFeatureSaturationScoreFunction{}
+}
+
+// Pivot sets the pivot value. It defaults to the approximate geometric
+// mean of all values of the rank feature in the index.
+func (f *RankFeatureSaturationScoreFunction) Pivot(pivot float64) *RankFeatureSaturationScoreFunction {
+	f.pivot = &pivot
+	return f
+}
+
+// Name represents the JSON field name under which the output of Source
+// needs to be serialized by RankFeatureQuery.
+func (f *RankFeatureSaturationScoreFunction) Name() string {
+	return "saturation"
+}
+
+// Source returns JSON for the function.
+func (f *RankFeatureSaturationScoreFunction) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	if f.pivot != nil {
+		source["pivot"] = *f.pivot
+	}
+	return source, nil
+}
+
+// RankFeatureLogScoreFunction computes the score as
+// log(scaling_factor + S), where S is the value of the rank feature.
+type RankFeatureLogScoreFunction struct {
+	scalingFactor float64
+}
+
+// NewRankFeatureLogScoreFunction creates a new logarithmic function.
+func NewRankFeatureLogScoreFunction(scalingFactor float64) *RankFeatureLogScoreFunction {
+	return &RankFeatureLogScoreFunction{scalingFactor: scalingFactor}
+}
+
+// Name represents the JSON field name under which the output of Source
+// needs to be serialized by RankFeatureQuery.
+func (f *RankFeatureLogScoreFunction) Name() string {
+	return "log"
+}
+
+// Source returns JSON for the function.
+func (f *RankFeatureLogScoreFunction) Source() (interface{}, error) {
+	return map[string]interface{}{
+		"scaling_factor": f.scalingFactor,
+	}, nil
+}
+
+// RankFeatureSigmoidScoreFunction computes the score as
+// S^exp / (S^exp + pivot^exp), where S is the value of the rank feature.
+type RankFeatureSigmoidScoreFunction struct {
+	pivot    float64
+	exponent float64
+}
+
+// NewRankFeatureSigmoidScoreFunction creates a new sigmoid function.
+func NewRankFeatureSigmoidScoreFunction(pivot, exponent float64) *RankFeatureSigmoidScoreFunction {
+	return &RankFeatureSigmoidScoreFunction{pivot: pivot, exponent: exponent}
+}
+
+// Name represents the JSON field name under which the output of Source
+// needs to be serialized by RankFeatureQuery.
+func (f *RankFeatureSigmoidScoreFunction) Name() string {
+	return "sigmoid"
+}
+
+// Source returns JSON for the function.
+func (f *RankFeatureSigmoidScoreFunction) Source() (interface{}, error) {
+	return map[string]interface{}{
+		"pivot":    f.pivot,
+		"exponent": f.exponent,
+	}, nil
+}
+
+// RankFeatureLinearScoreFunction uses the value of the rank feature as
+// the score. It requires Elasticsearch 7.6 or later.
+type RankFeatureLinearScoreFunction struct{}
+
+// NewRankFeatureLinearScoreFunction creates a new linear function.
+func NewRankFeatureLinearScoreFunction() *RankFeatureLinearScoreFunction {
+	return &RankFeatureLinearScoreFunction{}
+}
+
+// Name represents the JSON field name under which the output of Source
+// needs to be serialized by RankFeatureQuery.
+func (f *RankFeatureLinearScoreFunction) Name() string {
+	return "linear"
+}
+
+// Source returns JSON for the function.
+func (f *RankFeatureLinearScoreFunction) Source() (interface{}, error) {
+	return map[string]interface{}{}, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRankFeatureQuery(t *testing.T) {
+	tests := []struct {
+		Query    *RankFeatureQuery
+		Expected string
+	}{
+		{
+			NewRankFeatureQuery("pagerank"),
+			`{"rank_feature":{"field":"pagerank"}}`,
+		},
+		{
+			NewRankFeatureQuery("pagerank").ScoreFunction(NewRankFeatureSaturationScoreFunction()),
+			`{"rank_feature":{"field":"pagerank","saturation":{}}}`,
+		},
+		{
+			NewRankFeatureQuery("pagerank").ScoreFunction(NewRankFeatureSaturationScoreFunction().Pivot(8)).Boost(0.5),
+			`{"rank_feature":{"boost":0.5,"field":"pagerank","saturation":{"pivot":8}}}`,
+		},
+		{
+			NewRankFeatureQuery("pagerank").ScoreFunction(NewRankFeatureLogScoreFunction(4)),
+			`{"rank_feature":{"field":"pagerank","log":{"scaling_factor":4}}}`,
+		},
+		{
+			NewRankFeatureQuery("pagerank").ScoreFunction(NewRankFeatureSigmoidScoreFunction(7, 0.6)),
+			`{"rank_feature":{"field":"pagerank","sigmoid":{"exponent":0.6,"pivot":7}}}`,
+		},
+		{
+			NewRankFeatureQuery("topics.sports").ScoreFunction(NewRankFeatureLinearScoreFunction()).QueryName("my_query_name"),
+			`{"rank_feature":{"_name":"my_query_name","field":"topics.sports","linear":{}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		src, err := tt.Query.Source()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("marshaling to JSON failed: %v", err)
+		}
+		if got := string(data); got != tt.Expected {
+			t.Errorf("expected\n%s\n,got:\n%s", tt.Expected, got)
+		}
+	}
+}
@@ -4,7 +4,10 @@
 
 package elastic
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 // RawStringQuery can be used to treat a string representation of an ES query
 // as a Query.  Example usage:
@@ -18,9 +21,12 @@ func NewRawStringQuery(q string) RawStringQuery {
 	return RawStringQuery(q)
 }
 
-// Source returns the JSON encoded body
+// Source returns the JSON encoded body. Numbers keep their exact
+// representation.
 func (q RawStringQuery) Source() (interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(string(q)))
+	dec.UseNumber()
 	var f interface{}
-	err := json.Unmarshal([]byte(q), &f)
+	err := dec.Decode(&f)
 	return f, err
 }
@@ -53,6 +53,11 @@ func (q *RegexpQuery) QueryName(queryName string) *RegexpQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *RegexpQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns the JSON-serializable query data.
 func (q *RegexpQuery) Source() (interface{}, error) {
 	source := make(map[string]interface{})
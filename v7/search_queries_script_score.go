@@ -0,0 +1,106 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// ScriptScoreQuery uses a script to provide a custom score for the
+// documents returned by a query. It is a simpler alternative to a
+// FunctionScoreQuery with a ScriptFunction.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-script-score-query.html
+type ScriptScoreQuery struct {
+	query     Query
+	script    *Script
+	minScore  *float64
+	boost     *float64
+	queryName string
+}
+
+// NewScriptScoreQuery creates and initializes a new ScriptScoreQuery.
+func NewScriptScoreQuery(query Query, script *Script) *ScriptScoreQuery {
+	return &ScriptScoreQuery{
+		query:  query,
+		script: script,
+	}
+}
+
+// Query sets the query whose documents are scored by the script.
+func (q *ScriptScoreQuery) Query(query Query) *ScriptScoreQuery {
+	q.query = query
+	return q
+}
+
+// Script sets the script used to compute the score of each document.
+func (q *ScriptScoreQuery) Script(script *Script) *ScriptScoreQuery {
+	q.script = script
+	return q
+}
+
+// MinScore excludes documents with a score lower than minScore.
+func (q *ScriptScoreQuery) MinScore(minScore float64) *ScriptScoreQuery {
+	q.minScore = &minScore
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *ScriptScoreQuery) Boost(boost float64) *ScriptScoreQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *ScriptScoreQuery) QueryName(queryName string) *ScriptScoreQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *ScriptScoreQuery) Source() (interface{}, error) {
+	// {
+	//   "script_score" : {
+	//     "query" : {
+	//       "match" : { "message": "elasticsearch" }
+	//     },
+	//     "script" : {
+	//       "source" : "doc['likes'].value / 10 "
+	//     }
+	//   }
+	// }
+	if q.query == nil {
+		return nil, errors.New("ScriptScoreQuery expected a query")
+	}
+	if q.script == nil {
+		return nil, errors.New("ScriptScoreQuery expected a script")
+	}
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["script_score"] = params
+
+	src, err := q.query.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["query"] = src
+
+	src, err = q.script.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["script"] = src
+
+	if q.minScore != nil {
+		params["min_score"] = *q.minScore
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
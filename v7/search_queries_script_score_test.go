@@ -0,0 +1,37 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestScriptScoreQuery(t *testing.T) {
+	q := NewScriptScoreQuery(
+		NewMatchQuery("message", "elasticsearch"),
+		NewScript("doc['likes'].value / params.divisor").Param("divisor", 10),
+	).MinScore(1.5).Boost(2).QueryName("my_query_name")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"script_score":{"_name":"my_query_name","boost":2,"min_score":1.5,"query":{"match":{"message":{"query":"elasticsearch"}}},"script":{"params":{"divisor":10},"source":"doc['likes'].value / params.divisor"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestScriptScoreQueryWithoutScript(t *testing.T) {
+	q := NewScriptScoreQuery(NewMatchAllQuery(), nil)
+	if _, err := q.Source(); err == nil {
+		t.Fatal("expected error without script")
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// ShapeQuery finds documents with a shape field, i.e. with arbitrary
+// cartesian geometries, that relates to a shape. It is the cartesian
+// counterpart of GeoShapeQuery and requires X-Pack.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-shape-query.html
+type ShapeQuery struct {
+	name           string
+	shape          GeoShapeGeometry
+	wkt            bool
+	indexedShape   *IndexedShape
+	relation       string
+	ignoreUnmapped *bool
+	boost          *float64
+	queryName      string
+}
+
+// NewShapeQuery creates and initializes a new ShapeQuery.
+func NewShapeQuery(name string) *ShapeQuery {
+	return &ShapeQuery{name: name}
+}
+
+// Shape sets the shape to match against. Notice that the shape field
+// uses cartesian coordinates, i.e. Lon is x and Lat is y.
+func (q *ShapeQuery) Shape(shape GeoShapeGeometry) *ShapeQuery {
+	q.shape = shape
+	return q
+}
+
+// WKT specifies whether to send the shape as Well-Known Text instead of
+// GeoJSON. It defaults to false.
+func (q *ShapeQuery) WKT(wkt bool) *ShapeQuery {
+	q.wkt = wkt
+	return q
+}
+
+// IndexedShape sets a reference to a shape indexed in another document
+// to match against, instead of an inline shape.
+func (q *ShapeQuery) IndexedShape(indexedShape *IndexedShape) *ShapeQuery {
+	q.indexedShape = indexedShape
+	return q
+}
+
+// Relation sets the spatial relation between the field and the shape,
+// i.e. "intersects" (the default), "disjoint", "within", or "contains".
+func (q *ShapeQuery) Relation(relation string) *ShapeQuery {
+	q.relation = relation
+	return q
+}
+
+// IgnoreUnmapped indicates whether to ignore an unmapped field and not
+// match any documents, instead of failing.
+func (q *ShapeQuery) IgnoreUnmapped(ignoreUnmapped bool) *ShapeQuery {
+	q.ignoreUnmapped = &ignoreUnmapped
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *ShapeQuery) Boost(boost float64) *ShapeQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *ShapeQuery) QueryName(queryName string) *ShapeQuery {
+	q.queryName = queryName
+	return q
+}
+
+// FieldName returns the name of the field the query runs on.
+func (q *ShapeQuery) FieldName() string {
+	return q.name
+}
+
+// Source returns JSON for the query.
+func (q *ShapeQuery) Source() (interface{}, error) {
+	// {
+	//   "shape": {
+	//     "geometry": {
+	//       "shape": {
+	//         "type": "envelope",
+	//         "coordinates": [[1355.0, 5355.0], [1400.0, 5200.0]]
+	//       },
+	//       "relation": "within"
+	//     }
+	//   }
+	// }
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["shape"] = params
+
+	field, err := shapeQueryField(q.shape, q.wkt, q.indexedShape, q.relation)
+	if err != nil {
+		return nil, err
+	}
+	params[q.name] = field
+
+	if q.ignoreUnmapped != nil {
+		params["ignore_unmapped"] = *q.ignoreUnmapped
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
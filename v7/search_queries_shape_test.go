@@ -0,0 +1,30 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestShapeQuery(t *testing.T) {
+	q := NewShapeQuery("geometry").
+		Shape(NewGeoShapeEnvelope(NewGeoShapePoint(5355, 1355), NewGeoShapePoint(5200, 1400))).
+		Relation("within").
+		QueryName("my_query_name")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"shape":{"_name":"my_query_name","geometry":{"relation":"within","shape":{"coordinates":[[1355,5355],[1400,5200]],"type":"envelope"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
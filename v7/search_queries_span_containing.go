@@ -0,0 +1,77 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// SpanContainingQuery returns the spans of the big query that contain a
+// match of the little query.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-span-containing-query.html
+type SpanContainingQuery struct {
+	big       Query
+	little    Query
+	boost     *float64
+	queryName string
+}
+
+// NewSpanContainingQuery creates and initializes a new SpanContainingQuery.
+// Both queries must be span queries.
+func NewSpanContainingQuery(big, little Query) *SpanContainingQuery {
+	return &SpanContainingQuery{big: big, little: little}
+}
+
+// Boost sets the boost for this query.
+func (q *SpanContainingQuery) Boost(boost float64) *SpanContainingQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *SpanContainingQuery) QueryName(queryName string) *SpanContainingQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *SpanContainingQuery) Source() (interface{}, error) {
+	// {
+	//   "span_containing":{
+	//     "little":{"span_term":{"field1":"foo"}},
+	//     "big":{"span_near":{...}}
+	//   }
+	// }
+	if q.big == nil {
+		return nil, errors.New("SpanContainingQuery expected a big query")
+	}
+	if q.little == nil {
+		return nil, errors.New("SpanContainingQuery expected a little query")
+	}
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["span_containing"] = params
+
+	src, err := q.big.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["big"] = src
+
+	src, err = q.little.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["little"] = src
+
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSpanContainingQuery(t *testing.T) {
+	q := NewSpanContainingQuery(NewSpanNearQuery(NewSpanTermQuery("field1", "bar"), NewSpanTermQuery("field1", "baz")).Slop(5).InOrder(true), NewSpanTermQuery("field1", "foo"))
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"span_containing":{"big":{"span_near":{"clauses":[{"span_term":{"field1":"bar"}},{"span_term":{"field1":"baz"}}],"in_order":true,"slop":5}},"little":{"span_term":{"field1":"foo"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSpanContainingQueryWithoutClause(t *testing.T) {
+	for _, q := range []*SpanContainingQuery{
+		NewSpanContainingQuery(nil, NewSpanTermQuery("field1", "foo")),
+		NewSpanContainingQuery(NewSpanTermQuery("field1", "foo"), nil),
+	} {
+		if _, err := q.Source(); err == nil {
+			t.Fatal("expected error without big or little query")
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// SpanFirstQuery matches spans near the beginning of a field, i.e.
+// spans of the match query that end at a position of at most end.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-span-first-query.html
+type SpanFirstQuery struct {
+	match     Query
+	end       int
+	boost     *float64
+	queryName string
+}
+
+// NewSpanFirstQuery creates and initializes a new SpanFirstQuery.
+// The match query must be a span query.
+func NewSpanFirstQuery(match Query, end int) *SpanFirstQuery {
+	return &SpanFirstQuery{match: match, end: end}
+}
+
+// Boost sets the boost for this query.
+func (q *SpanFirstQuery) Boost(boost float64) *SpanFirstQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *SpanFirstQuery) QueryName(queryName string) *SpanFirstQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *SpanFirstQuery) Source() (interface{}, error) {
+	// {"span_first":{"match":{"span_term":{"user":"kimchy"}},"end":3}}
+	if q.match == nil {
+		return nil, errors.New("SpanFirstQuery expected a match query")
+	}
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["span_first"] = params
+
+	src, err := q.match.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["match"] = src
+	params["end"] = q.end
+
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSpanFirstQuery(t *testing.T) {
+	q := NewSpanFirstQuery(NewSpanTermQuery("user", "kimchy"), 3).QueryName("first")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"span_first":{"_name":"first","end":3,"match":{"span_term":{"user":"kimchy"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSpanFirstQueryWithoutQuery(t *testing.T) {
+	if _, err := NewSpanFirstQuery(nil, 3).Source(); err == nil {
+		t.Fatal("expected error without match query")
+	}
+}
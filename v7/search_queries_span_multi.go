@@ -0,0 +1,62 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// SpanMultiTermQuery wraps a multi term query, i.e. a WildcardQuery,
+// FuzzyQuery, PrefixQuery, RangeQuery, or RegexpQuery, so it can be
+// used as a span query, e.g. in a SpanNearQuery.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-span-multi-term-query.html
+type SpanMultiTermQuery struct {
+	match     Query
+	boost     *float64
+	queryName string
+}
+
+// NewSpanMultiTermQuery creates and initializes a new SpanMultiTermQuery.
+func NewSpanMultiTermQuery(match Query) *SpanMultiTermQuery {
+	return &SpanMultiTermQuery{match: match}
+}
+
+// Boost sets the boost for this query.
+func (q *SpanMultiTermQuery) Boost(boost float64) *SpanMultiTermQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *SpanMultiTermQuery) QueryName(queryName string) *SpanMultiTermQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *SpanMultiTermQuery) Source() (interface{}, error) {
+	// {"span_multi":{"match":{"prefix":{"user":{"value":"ki"}}}}}
+	if q.match == nil {
+		return nil, errors.New("SpanMultiTermQuery expected a match query")
+	}
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["span_multi"] = params
+
+	src, err := q.match.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["match"] = src
+
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSpanMultiTermQuery(t *testing.T) {
+	q := NewSpanMultiTermQuery(NewPrefixQuery("user", "ki")).Boost(1.1)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"span_multi":{"boost":1.1,"match":{"prefix":{"user":"ki"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSpanMultiTermQueryWithoutQuery(t *testing.T) {
+	if _, err := NewSpanMultiTermQuery(nil).Source(); err == nil {
+		t.Fatal("expected error without match query")
+	}
+}
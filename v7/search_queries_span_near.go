@@ -0,0 +1,96 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SpanNearQuery matches spans which are near one another. The maximum
+// number of intervening unmatched positions is specified with slop.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-span-near-query.html
+type SpanNearQuery struct {
+	clauses   []Query
+	slop      *int
+	inOrder   *bool
+	boost     *float64
+	queryName string
+}
+
+// NewSpanNearQuery creates and initializes a new SpanNearQuery.
+// The clauses must be span queries.
+func NewSpanNearQuery(clauses ...Query) *SpanNearQuery {
+	return &SpanNearQuery{clauses: clauses}
+}
+
+// Add adds span queries to the clauses.
+func (q *SpanNearQuery) Add(clauses ...Query) *SpanNearQuery {
+	q.clauses = append(q.clauses, clauses...)
+	return q
+}
+
+// Slop sets the maximum number of intervening unmatched positions.
+func (q *SpanNearQuery) Slop(slop int) *SpanNearQuery {
+	q.slop = &slop
+	return q
+}
+
+// InOrder specifies whether the matches are required to be in order.
+func (q *SpanNearQuery) InOrder(inOrder bool) *SpanNearQuery {
+	q.inOrder = &inOrder
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *SpanNearQuery) Boost(boost float64) *SpanNearQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *SpanNearQuery) QueryName(queryName string) *SpanNearQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *SpanNearQuery) Source() (interface{}, error) {
+	// {
+	//   "span_near":{
+	//     "clauses":[
+	//       {"span_term":{"field":"value1"}},
+	//       {"span_term":{"field":"value2"}}
+	//     ],
+	//     "slop":12,
+	//     "in_order":false
+	//   }
+	// }
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["span_near"] = params
+
+	clauses := make([]interface{}, 0, len(q.clauses))
+	for _, clause := range q.clauses {
+		src, err := clause.Source()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, src)
+	}
+	params["clauses"] = clauses
+
+	if q.slop != nil {
+		params["slop"] = *q.slop
+	}
+	if q.inOrder != nil {
+		params["in_order"] = *q.inOrder
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
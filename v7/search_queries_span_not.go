@@ -0,0 +1,111 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// SpanNotQuery removes matches which overlap with another span query,
+// or which are within a number of tokens before (pre) or after (post)
+// another span query.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-span-not-query.html
+type SpanNotQuery struct {
+	include   Query
+	exclude   Query
+	pre       *int
+	post      *int
+	dist      *int
+	boost     *float64
+	queryName string
+}
+
+// NewSpanNotQuery creates and initializes a new SpanNotQuery. It matches
+// the spans of include that do not overlap with the spans of exclude.
+// Both queries must be span queries.
+func NewSpanNotQuery(include, exclude Query) *SpanNotQuery {
+	return &SpanNotQuery{include: include, exclude: exclude}
+}
+
+// Pre sets the number of tokens before the include span that can't
+// have overlap with the exclude span.
+func (q *SpanNotQuery) Pre(pre int) *SpanNotQuery {
+	q.pre = &pre
+	return q
+}
+
+// Post sets the number of tokens after the include span that can't
+// have overlap with the exclude span.
+func (q *SpanNotQuery) Post(post int) *SpanNotQuery {
+	q.post = &post
+	return q
+}
+
+// Dist is equivalent to setting both Pre and Post.
+func (q *SpanNotQuery) Dist(dist int) *SpanNotQuery {
+	q.dist = &dist
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *SpanNotQuery) Boost(boost float64) *SpanNotQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *SpanNotQuery) QueryName(queryName string) *SpanNotQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *SpanNotQuery) Source() (interface{}, error) {
+	// {
+	//   "span_not":{
+	//     "include":{"span_term":{"field1":"hoya"}},
+	//     "exclude":{"span_near":{...}}
+	//   }
+	// }
+	if q.include == nil {
+		return nil, errors.New("SpanNotQuery expected an include query")
+	}
+	if q.exclude == nil {
+		return nil, errors.New("SpanNotQuery expected an exclude query")
+	}
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["span_not"] = params
+
+	src, err := q.include.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["include"] = src
+
+	src, err = q.exclude.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["exclude"] = src
+
+	if q.pre != nil {
+		params["pre"] = *q.pre
+	}
+	if q.post != nil {
+		params["post"] = *q.post
+	}
+	if q.dist != nil {
+		params["dist"] = *q.dist
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSpanNotQuery(t *testing.T) {
+	q := NewSpanNotQuery(NewSpanTermQuery("field1", "hoya"), NewSpanNearQuery(NewSpanTermQuery("field1", "la"), NewSpanTermQuery("field1", "hoya")).Slop(0).InOrder(true)).Pre(1).Post(2)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"span_not":{"exclude":{"span_near":{"clauses":[{"span_term":{"field1":"la"}},{"span_term":{"field1":"hoya"}}],"in_order":true,"slop":0}},"include":{"span_term":{"field1":"hoya"}},"post":2,"pre":1}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSpanNotQueryWithDist(t *testing.T) {
+	q := NewSpanNotQuery(NewSpanTermQuery("field1", "hoya"), NewSpanTermQuery("field1", "la")).Dist(3)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"span_not":{"dist":3,"exclude":{"span_term":{"field1":"la"}},"include":{"span_term":{"field1":"hoya"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSpanNotQueryWithoutClause(t *testing.T) {
+	for _, q := range []*SpanNotQuery{
+		NewSpanNotQuery(nil, NewSpanTermQuery("field1", "hoya")),
+		NewSpanNotQuery(NewSpanTermQuery("field1", "hoya"), nil),
+	} {
+		if _, err := q.Source(); err == nil {
+			t.Fatal("expected error without include or exclude query")
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SpanOrQuery matches the union of its span clauses.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-span-or-query.html
+type SpanOrQuery struct {
+	clauses   []Query
+	boost     *float64
+	queryName string
+}
+
+// NewSpanOrQuery creates and initializes a new SpanOrQuery.
+// The clauses must be span queries.
+func NewSpanOrQuery(clauses ...Query) *SpanOrQuery {
+	return &SpanOrQuery{clauses: clauses}
+}
+
+// Add adds span queries to the clauses.
+func (q *SpanOrQuery) Add(clauses ...Query) *SpanOrQuery {
+	q.clauses = append(q.clauses, clauses...)
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *SpanOrQuery) Boost(boost float64) *SpanOrQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *SpanOrQuery) QueryName(queryName string) *SpanOrQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *SpanOrQuery) Source() (interface{}, error) {
+	// {"span_or":{"clauses":[{"span_term":{"field":"value1"}},{"span_term":{"field":"value2"}}]}}
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["span_or"] = params
+
+	clauses := make([]interface{}, 0, len(q.clauses))
+	for _, clause := range q.clauses {
+		src, err := clause.Source()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, src)
+	}
+	params["clauses"] = clauses
+
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
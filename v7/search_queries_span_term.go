@@ -0,0 +1,63 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SpanTermQuery matches spans containing a term. It is the basic building
+// block of the other span queries, e.g. SpanNearQuery.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-span-term-query.html
+type SpanTermQuery struct {
+	name      string
+	value     interface{}
+	boost     *float64
+	queryName string
+}
+
+// NewSpanTermQuery creates and initializes a new SpanTermQuery.
+func NewSpanTermQuery(name string, value interface{}) *SpanTermQuery {
+	return &SpanTermQuery{name: name, value: value}
+}
+
+// Boost sets the boost for this query.
+func (q *SpanTermQuery) Boost(boost float64) *SpanTermQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *SpanTermQuery) QueryName(queryName string) *SpanTermQuery {
+	q.queryName = queryName
+	return q
+}
+
+// FieldName returns the name of the field the query runs on.
+func (q *SpanTermQuery) FieldName() string {
+	return q.name
+}
+
+// Source returns JSON for the query.
+func (q *SpanTermQuery) Source() (interface{}, error) {
+	// {"span_term":{"user":"kimchy"}}
+	source := make(map[string]interface{})
+	tq := make(map[string]interface{})
+	source["span_term"] = tq
+
+	if q.boost == nil && q.queryName == "" {
+		tq[q.name] = q.value
+	} else {
+		subQ := make(map[string]interface{})
+		subQ["value"] = q.value
+		if q.boost != nil {
+			subQ["boost"] = *q.boost
+		}
+		if q.queryName != "" {
+			subQ["_name"] = q.queryName
+		}
+		tq[q.name] = subQ
+	}
+	return source, nil
+}
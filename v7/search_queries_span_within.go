@@ -0,0 +1,77 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// SpanWithinQuery returns the spans of the little query that are enclosed
+// by a span of the big query.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-span-within-query.html
+type SpanWithinQuery struct {
+	big       Query
+	little    Query
+	boost     *float64
+	queryName string
+}
+
+// NewSpanWithinQuery creates and initializes a new SpanWithinQuery.
+// Both queries must be span queries.
+func NewSpanWithinQuery(big, little Query) *SpanWithinQuery {
+	return &SpanWithinQuery{big: big, little: little}
+}
+
+// Boost sets the boost for this query.
+func (q *SpanWithinQuery) Boost(boost float64) *SpanWithinQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *SpanWithinQuery) QueryName(queryName string) *SpanWithinQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *SpanWithinQuery) Source() (interface{}, error) {
+	// {
+	//   "span_within":{
+	//     "little":{"span_term":{"field1":"foo"}},
+	//     "big":{"span_near":{...}}
+	//   }
+	// }
+	if q.big == nil {
+		return nil, errors.New("SpanWithinQuery expected a big query")
+	}
+	if q.little == nil {
+		return nil, errors.New("SpanWithinQuery expected a little query")
+	}
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["span_within"] = params
+
+	src, err := q.big.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["big"] = src
+
+	src, err = q.little.Source()
+	if err != nil {
+		return nil, err
+	}
+	params["little"] = src
+
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	return source, nil
+}
@@ -34,6 +34,11 @@ func (q *TermQuery) QueryName(queryName string) *TermQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *TermQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns JSON for the query.
 func (q *TermQuery) Source() (interface{}, error) {
 	// {"term":{"name":"value"}}
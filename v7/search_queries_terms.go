@@ -48,6 +48,11 @@ func (q *TermsQuery) QueryName(queryName string) *TermsQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *TermsQuery) FieldName() string {
+	return q.name
+}
+
 // Creates the query source for the term query.
 func (q *TermsQuery) Source() (interface{}, error) {
 	// {"terms":{"name":["value1","value2"]}}
@@ -59,6 +59,11 @@ func (q *TermsSetQuery) QueryName(queryName string) *TermsSetQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *TermsSetQuery) FieldName() string {
+	return q.name
+}
+
 // Source creates the query source for the term query.
 func (q *TermsSetQuery) Source() (interface{}, error) {
 	// {"terms_set":{"codes":{"terms":["abc","def"],"minimum_should_match_field":"required_matches"}}}
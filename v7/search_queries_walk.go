@@ -0,0 +1,394 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnparsedQuery is returned by RewriteQuery and QueryFields if the
+// query tree contains a RawStringQuery or WrapperQuery, as the queries
+// inside them cannot be inspected.
+var ErrUnparsedQuery = errors.New("elastic: query tree contains an unparsed query")
+
+// FieldQuery is implemented by queries that run on a single field,
+// e.g. TermQuery, MatchQuery, or RangeQuery.
+type FieldQuery interface {
+	Query
+	FieldName() string
+}
+
+// WalkQuery traverses the query tree of q in depth-first order. It calls
+// fn for q and then for each of its sub-queries, e.g. the clauses of a
+// BoolQuery. If fn returns false, the sub-queries of the query passed to
+// fn are skipped.
+//
+// Sub-queries are found in BoolQuery, BoostingQuery, ConstantScoreQuery,
+// DisMaxQuery, FunctionScoreQuery, ScriptScoreQuery, PinnedQuery,
+// NestedQuery, HasChildQuery, HasParentQuery, and the compound span
+// queries like SpanNearQuery.
+// All other queries are leaves of the tree. This includes RawStringQuery
+// and WrapperQuery, e.g. the parts of a query that ParseQuery cannot
+// represent: fn is called for them, but not for the queries inside.
+//
+// Example:
+//   elastic.WalkQuery(q, func(q elastic.Query) bool {
+//     if _, ok := q.(*elastic.ScriptQuery); ok {
+//       hasScript = true
+//     }
+//     return true
+//   })
+func WalkQuery(q Query, fn func(Query) bool) {
+	if q == nil || !fn(q) {
+		return
+	}
+	for _, child := range subQueries(q) {
+		WalkQuery(child, fn)
+	}
+}
+
+// RewriteQuery transforms the query tree of q bottom-up. It rewrites the
+// sub-queries of q first, then calls fn with q and uses the query
+// returned by fn in place of q. Return the query passed to fn to keep it.
+//
+// Compound queries like BoolQuery are copied before being passed to fn,
+// so fn may modify them, e.g. add a filter clause, without changing the
+// original tree. Other queries are passed as they are; return a new
+// query instead of modifying them.
+//
+// If fn returns nil, the query is removed from its parent, e.g. from the
+// clauses of a BoolQuery. Note that an empty BoolQuery matches all
+// documents; return a MatchNoneQuery instead to exclude all documents.
+// Compound queries that cannot exist without the removed query, e.g. a
+// NestedQuery without its inner query, a BoostingQuery without its
+// positive or negative query, or a DisMaxQuery without any queries, are
+// removed as well. A function of a FunctionScoreQuery is
+// removed together with its filter. RewriteQuery returns nil if q itself
+// is removed.
+//
+// RawStringQuery and WrapperQuery are passed to fn like other leaves,
+// but the queries inside them are not rewritten. fn must replace or
+// remove them; if fn returns one of them unchanged, RewriteQuery fails
+// with ErrUnparsedQuery instead of silently skipping its queries.
+//
+// RewriteQuery stops at the first error returned by fn.
+//
+// Example:
+//   // Restrict all boolean queries to a tenant
+//   q, err := elastic.RewriteQuery(q, func(q elastic.Query) (elastic.Query, error) {
+//     if b, ok := q.(*elastic.BoolQuery); ok {
+//       return b.Filter(elastic.NewTermQuery("tenant", tenant)), nil
+//     }
+//     return q, nil
+//   })
+func RewriteQuery(q Query, fn func(Query) (Query, error)) (Query, error) {
+	if q == nil {
+		return nil, nil
+	}
+	q, err := rewriteSubQueries(q, fn)
+	if err != nil || q == nil {
+		return nil, err
+	}
+	rewritten, err := fn(q)
+	if err == nil && isUnparsedQuery(q) && rewritten == q {
+		return nil, ErrUnparsedQuery
+	}
+	return rewritten, err
+}
+
+// QueryFields returns the names of the fields used in the query tree of
+// q, in the order of their first occurrence. It includes the fields of
+// all FieldQuery implementations as well as the fields of the
+// MultiMatchQuery, QueryStringQuery, and SimpleQueryStringQuery, with
+// per-field boosts like "title^2" removed.
+//
+// QueryFields returns ErrUnparsedQuery if the query tree contains a
+// RawStringQuery or WrapperQuery, as their fields are unknown.
+func QueryFields(q Query) ([]string, error) {
+	var (
+		fields   []string
+		unparsed bool
+	)
+	seen := make(map[string]bool)
+	add := func(field string) {
+		if i := strings.IndexByte(field, '^'); i >= 0 {
+			field = field[:i]
+		}
+		if field != "" && !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	WalkQuery(q, func(q Query) bool {
+		if isUnparsedQuery(q) {
+			unparsed = true
+			return false
+		}
+		switch q := q.(type) {
+		case FieldQuery:
+			add(q.FieldName())
+		case *MultiMatchQuery:
+			for _, field := range q.fields {
+				add(field)
+			}
+		case *QueryStringQuery:
+			add(q.defaultField)
+			for _, field := range q.fields {
+				add(field)
+			}
+		case *SimpleQueryStringQuery:
+			for _, field := range q.fields {
+				add(field)
+			}
+		}
+		return true
+	})
+	if unparsed {
+		return nil, ErrUnparsedQuery
+	}
+	return fields, nil
+}
+
+// isUnparsedQuery returns true for queries that hide their sub-queries
+// and fields in JSON.
+func isUnparsedQuery(q Query) bool {
+	switch q.(type) {
+	case RawStringQuery, *WrapperQuery:
+		return true
+	}
+	return false
+}
+
+// subQueries returns the direct sub-queries of q.
+func subQueries(q Query) []Query {
+	var children []Query
+	switch q := q.(type) {
+	case *BoolQuery:
+		children = append(children, q.mustClauses...)
+		children = append(children, q.mustNotClauses...)
+		children = append(children, q.filterClauses...)
+		children = append(children, q.shouldClauses...)
+	case *BoostingQuery:
+		children = append(children, q.positiveClause, q.negativeClause)
+	case *ConstantScoreQuery:
+		children = append(children, q.filter)
+	case *DisMaxQuery:
+		children = append(children, q.queries...)
+	case *FunctionScoreQuery:
+		children = append(children, q.query, q.filter)
+		children = append(children, q.filters...)
+	case *ScriptScoreQuery:
+		children = append(children, q.query)
+	case *PinnedQuery:
+		children = append(children, q.organic)
+	case *NestedQuery:
+		children = append(children, q.query)
+	case *HasChildQuery:
+		children = append(children, q.query)
+	case *HasParentQuery:
+		children = append(children, q.query)
+	case *SpanMultiTermQuery:
+		children = append(children, q.match)
+	case *SpanFirstQuery:
+		children = append(children, q.match)
+	case *SpanNearQuery:
+		children = append(children, q.clauses...)
+	case *SpanOrQuery:
+		children = append(children, q.clauses...)
+	case *SpanNotQuery:
+		children = append(children, q.include, q.exclude)
+	case *SpanContainingQuery:
+		children = append(children, q.big, q.little)
+	case *SpanWithinQuery:
+		children = append(children, q.big, q.little)
+	case *FieldMaskingSpanQuery:
+		children = append(children, q.query)
+	}
+	// Drop optional sub-queries that are not set
+	n := 0
+	for _, child := range children {
+		if child != nil {
+			children[n] = child
+			n++
+		}
+	}
+	return children[:n]
+}
+
+// rewriteSubQueries returns a copy of q with its sub-queries rewritten,
+// or nil if q cannot exist without a sub-query that has been removed.
+// Queries without sub-queries are returned unchanged.
+func rewriteSubQueries(q Query, fn func(Query) (Query, error)) (Query, error) {
+	var err error
+	switch q := q.(type) {
+	case *BoolQuery:
+		c := *q
+		if c.mustClauses, err = rewriteQueryList(q.mustClauses, fn); err != nil {
+			return nil, err
+		}
+		if c.mustNotClauses, err = rewriteQueryList(q.mustNotClauses, fn); err != nil {
+			return nil, err
+		}
+		if c.filterClauses, err = rewriteQueryList(q.filterClauses, fn); err != nil {
+			return nil, err
+		}
+		if c.shouldClauses, err = rewriteQueryList(q.shouldClauses, fn); err != nil {
+			return nil, err
+		}
+		return &c, nil
+	case *BoostingQuery:
+		c := *q
+		if c.positiveClause, err = RewriteQuery(q.positiveClause, fn); err != nil || c.positiveClause == nil {
+			return nil, err
+		}
+		if c.negativeClause, err = RewriteQuery(q.negativeClause, fn); err != nil || c.negativeClause == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *ConstantScoreQuery:
+		c := *q
+		if c.filter, err = RewriteQuery(q.filter, fn); err != nil || c.filter == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *DisMaxQuery:
+		c := *q
+		if c.queries, err = rewriteQueryList(q.queries, fn); err != nil || len(c.queries) == 0 {
+			return nil, err
+		}
+		return &c, nil
+	case *FunctionScoreQuery:
+		c := *q
+		if c.query, err = RewriteQuery(q.query, fn); err != nil {
+			return nil, err
+		}
+		if c.filter, err = RewriteQuery(q.filter, fn); err != nil {
+			return nil, err
+		}
+		c.filters = make([]Query, 0, len(q.filters))
+		c.scoreFuncs = make([]ScoreFunction, 0, len(q.scoreFuncs))
+		for i, filter := range q.filters {
+			if filter != nil {
+				if filter, err = RewriteQuery(filter, fn); err != nil {
+					return nil, err
+				}
+				if filter == nil {
+					continue // remove the function together with its filter
+				}
+			}
+			c.filters = append(c.filters, filter)
+			c.scoreFuncs = append(c.scoreFuncs, q.scoreFuncs[i])
+		}
+		return &c, nil
+	case *ScriptScoreQuery:
+		c := *q
+		if c.query, err = RewriteQuery(q.query, fn); err != nil || c.query == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *PinnedQuery:
+		c := *q
+		if c.organic, err = RewriteQuery(q.organic, fn); err != nil || c.organic == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *NestedQuery:
+		c := *q
+		if c.query, err = RewriteQuery(q.query, fn); err != nil || c.query == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *HasChildQuery:
+		c := *q
+		if c.query, err = RewriteQuery(q.query, fn); err != nil || c.query == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *HasParentQuery:
+		c := *q
+		if c.query, err = RewriteQuery(q.query, fn); err != nil || c.query == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *SpanMultiTermQuery:
+		c := *q
+		if c.match, err = RewriteQuery(q.match, fn); err != nil || c.match == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *SpanFirstQuery:
+		c := *q
+		if c.match, err = RewriteQuery(q.match, fn); err != nil || c.match == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *SpanNearQuery:
+		c := *q
+		if c.clauses, err = rewriteQueryList(q.clauses, fn); err != nil || len(c.clauses) == 0 {
+			return nil, err
+		}
+		return &c, nil
+	case *SpanOrQuery:
+		c := *q
+		if c.clauses, err = rewriteQueryList(q.clauses, fn); err != nil || len(c.clauses) == 0 {
+			return nil, err
+		}
+		return &c, nil
+	case *SpanNotQuery:
+		c := *q
+		if c.include, err = RewriteQuery(q.include, fn); err != nil || c.include == nil {
+			return nil, err
+		}
+		if c.exclude, err = RewriteQuery(q.exclude, fn); err != nil || c.exclude == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *SpanContainingQuery:
+		c := *q
+		if c.big, err = RewriteQuery(q.big, fn); err != nil || c.big == nil {
+			return nil, err
+		}
+		if c.little, err = RewriteQuery(q.little, fn); err != nil || c.little == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *SpanWithinQuery:
+		c := *q
+		if c.big, err = RewriteQuery(q.big, fn); err != nil || c.big == nil {
+			return nil, err
+		}
+		if c.little, err = RewriteQuery(q.little, fn); err != nil || c.little == nil {
+			return nil, err
+		}
+		return &c, nil
+	case *FieldMaskingSpanQuery:
+		c := *q
+		if c.query, err = RewriteQuery(q.query, fn); err != nil || c.query == nil {
+			return nil, err
+		}
+		return &c, nil
+	}
+	return q, nil
+}
+
+// rewriteQueryList rewrites a list of queries into a new list, leaving
+// out the queries that have been removed.
+func rewriteQueryList(queries []Query, fn func(Query) (Query, error)) ([]Query, error) {
+	if len(queries) == 0 {
+		return nil, nil
+	}
+	list := make([]Query, 0, len(queries))
+	for _, q := range queries {
+		q, err := RewriteQuery(q, fn)
+		if err != nil {
+			return nil, err
+		}
+		if q != nil {
+			list = append(list, q)
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,378 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func queryJSON(t *testing.T, q Query) string {
+	t.Helper()
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWalkQuery(t *testing.T) {
+	q := NewBoolQuery().
+		Must(NewTermQuery("user", "olivere")).
+		Filter(
+			NewNestedQuery("comments", NewMatchQuery("comments.text", "hello")),
+			NewConstantScoreQuery(NewExistsQuery("message")),
+		).
+		Should(NewDisMaxQuery().Query(NewTermQuery("tag", "a"), NewTermQuery("tag", "b")))
+
+	var visited []string
+	WalkQuery(q, func(q Query) bool {
+		visited = append(visited, fmt.Sprintf("%T", q))
+		_, isNested := q.(*NestedQuery)
+		return !isNested
+	})
+	want := []string{
+		"*elastic.BoolQuery",
+		"*elastic.TermQuery",
+		"*elastic.NestedQuery",
+		"*elastic.ConstantScoreQuery",
+		"*elastic.ExistsQuery",
+		"*elastic.DisMaxQuery",
+		"*elastic.TermQuery",
+		"*elastic.TermQuery",
+	}
+	if !reflect.DeepEqual(want, visited) {
+		t.Errorf("expected to visit\n%v\ngot\n%v", want, visited)
+	}
+
+	fields, err := QueryFields(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"user", "comments.text", "message", "tag"}, fields; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected fields %v; got: %v", want, have)
+	}
+}
+
+func TestQueryFields(t *testing.T) {
+	q := NewBoolQuery().Must(
+		NewMultiMatchQuery("hello", "title^2", "body").FieldWithBoost("summary", 1.5),
+		NewQueryStringQuery("hello").DefaultField("body").Field("tags"),
+		NewRangeQuery("created").Gte("now-1d"),
+		NewFunctionScoreQuery().
+			Query(NewPrefixQuery("user", "oli")).
+			Add(NewTermQuery("featured", true), NewWeightFactorFunction(2)),
+	)
+	want := []string{"title", "body", "summary", "tags", "created", "user", "featured"}
+	have, err := QueryFields(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("expected fields %v; got: %v", want, have)
+	}
+}
+
+func TestRewriteQueryAddFilter(t *testing.T) {
+	inner := NewBoolQuery().Must(NewTermQuery("user", "olivere"))
+	q := NewBoolQuery().Should(inner, NewMatchAllQuery())
+	before := queryJSON(t, q)
+
+	rewritten, err := RewriteQuery(q, func(q Query) (Query, error) {
+		if b, ok := q.(*BoolQuery); ok {
+			return b.Filter(NewTermQuery("tenant", "acme")), nil
+		}
+		return q, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"bool":{"filter":{"term":{"tenant":"acme"}},"should":[{"bool":{"filter":{"term":{"tenant":"acme"}},"must":{"term":{"user":"olivere"}}}},{"match_all":{}}]}}`
+	if have := queryJSON(t, rewritten); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+	// The original query must be unchanged
+	if after := queryJSON(t, q); before != after {
+		t.Errorf("expected original query to be unchanged\n%s\ngot\n%s", before, after)
+	}
+}
+
+func TestRewriteQueryReplace(t *testing.T) {
+	q := NewBoolQuery().
+		Must(NewMatchQuery("title", "hello")).
+		Filter(NewTermQuery("user", "olivere"))
+
+	rewritten, err := RewriteQuery(q, func(q Query) (Query, error) {
+		if m, ok := q.(*MatchQuery); ok && m.FieldName() == "title" {
+			return NewMultiMatchQuery(m.Text(), "title", "title.english"), nil
+		}
+		return q, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"bool":{"filter":{"term":{"user":"olivere"}},"must":{"multi_match":{"fields":["title","title.english"],"query":"hello"}}}}`
+	if have := queryJSON(t, rewritten); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+}
+
+func TestRewriteQueryRemove(t *testing.T) {
+	script := NewScriptQuery(NewScript("doc['n'].value > 1"))
+	q := NewBoolQuery().
+		Must(NewTermQuery("user", "olivere"), script).
+		Filter(
+			NewNestedQuery("comments", script),
+			NewConstantScoreQuery(script),
+			NewDisMaxQuery().Query(script),
+		).
+		Should(NewBoostingQuery().Positive(NewMatchAllQuery()).Negative(script)).
+		MustNot(NewFunctionScoreQuery().
+			Query(script).
+			Add(script, NewWeightFactorFunction(2)).
+			Add(NewTermQuery("featured", true), NewWeightFactorFunction(3)))
+
+	stripScripts := func(q Query) (Query, error) {
+		if _, ok := q.(*ScriptQuery); ok {
+			return nil, nil
+		}
+		return q, nil
+	}
+	rewritten, err := RewriteQuery(q, stripScripts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"bool":{"must":{"term":{"user":"olivere"}},"must_not":{"function_score":{"functions":[{"filter":{"term":{"featured":true}},"weight":3}]}}}}`
+	if have := queryJSON(t, rewritten); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+
+	// Removing the query itself
+	rewritten, err = RewriteQuery(NewNestedQuery("comments", script), stripScripts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rewritten != nil {
+		t.Errorf("expected nil; got: %s", queryJSON(t, rewritten))
+	}
+}
+
+func TestRewriteBoostingQuery(t *testing.T) {
+	q := NewBoostingQuery().
+		Positive(NewMatchQuery("title", "apple")).
+		Negative(NewTermQuery("category", "fruit")).
+		NegativeBoost(0.2)
+
+	// Rewriting a clause keeps the boosting query
+	rewritten, err := RewriteQuery(q, func(q Query) (Query, error) {
+		if tq, ok := q.(*TermQuery); ok && tq.FieldName() == "category" {
+			return NewTermsQuery("category", "fruit", "vegetable"), nil
+		}
+		return q, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"boosting":{"negative":{"terms":{"category":["fruit","vegetable"]}},"negative_boost":0.2,"positive":{"match":{"title":{"query":"apple"}}}}}`
+	if have := queryJSON(t, rewritten); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+
+	// Removing either clause removes the boosting query
+	for _, remove := range []string{"title", "category"} {
+		rewritten, err := RewriteQuery(q, func(q Query) (Query, error) {
+			if fq, ok := q.(FieldQuery); ok && fq.FieldName() == remove {
+				return nil, nil
+			}
+			return q, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rewritten != nil {
+			t.Errorf("expected nil after removing %q; got: %s", remove, queryJSON(t, rewritten))
+		}
+	}
+}
+
+func TestRewriteQueryError(t *testing.T) {
+	errScript := errors.New("scripts are not allowed")
+	q := NewBoolQuery().Must(NewTermQuery("user", "olivere"), NewScriptQuery(NewScript("1")))
+
+	var calls int
+	_, err := RewriteQuery(q, func(q Query) (Query, error) {
+		calls++
+		if _, ok := q.(*ScriptQuery); ok {
+			return nil, errScript
+		}
+		return q, nil
+	})
+	if err != errScript {
+		t.Fatalf("expected %v; got: %v", errScript, err)
+	}
+	if want, have := 2, calls; want != have {
+		t.Errorf("expected %d calls; got: %d", want, have)
+	}
+}
+
+func TestWalkParsedQuery(t *testing.T) {
+	q, err := ParseQuery([]byte(`{"bool":{"must":{"match":{"title":"hello"}},"filter":{"script":{"script":{"source":"doc['likes'].value > 10"}}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hasScript bool
+	WalkQuery(q, func(q Query) bool {
+		if _, ok := q.(*ScriptQuery); ok {
+			hasScript = true
+		}
+		return true
+	})
+	if !hasScript {
+		t.Error("expected to visit the script query")
+	}
+	fields, err := QueryFields(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"title"}, fields; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected fields %v; got: %v", want, have)
+	}
+}
+
+func TestWalkUnparsedQuery(t *testing.T) {
+	q, err := ParseQuery([]byte(`{"bool":{"must":[{"term":{"user":"olivere"}},{"combined_fields":{"query":"database systems","fields":["title","abstract"]}}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw []Query
+	WalkQuery(q, func(q Query) bool {
+		if isUnparsedQuery(q) {
+			raw = append(raw, q)
+		}
+		return true
+	})
+	if want, have := 1, len(raw); want != have {
+		t.Fatalf("expected %d unparsed queries; got: %d", want, have)
+	}
+	if _, ok := raw[0].(RawStringQuery); !ok {
+		t.Errorf("expected RawStringQuery; got: %T", raw[0])
+	}
+
+	if _, err := QueryFields(q); err != ErrUnparsedQuery {
+		t.Errorf("expected %v; got: %v", ErrUnparsedQuery, err)
+	}
+	if _, err := QueryFields(NewWrapperQuery("eyJ0ZXJtIjp7InVzZXIiOiJvbGl2ZXJlIn19")); err != ErrUnparsedQuery {
+		t.Errorf("expected %v; got: %v", ErrUnparsedQuery, err)
+	}
+
+	// Keeping the unparsed query fails
+	_, err = RewriteQuery(q, func(q Query) (Query, error) {
+		return q, nil
+	})
+	if err != ErrUnparsedQuery {
+		t.Fatalf("expected %v; got: %v", ErrUnparsedQuery, err)
+	}
+
+	// Replacing it succeeds
+	rewritten, err := RewriteQuery(q, func(q Query) (Query, error) {
+		if _, ok := q.(RawStringQuery); ok {
+			return NewMultiMatchQuery("database systems", "title", "abstract"), nil
+		}
+		return q, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"bool":{"must":[{"term":{"user":"olivere"}},{"multi_match":{"fields":["title","abstract"],"query":"database systems"}}]}}`
+	if have := queryJSON(t, rewritten); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+}
+
+func TestRewriteSpanQueries(t *testing.T) {
+	q := NewSpanNotQuery(
+		NewSpanNearQuery(
+			NewSpanTermQuery("body", "quick"),
+			NewSpanMultiTermQuery(NewPrefixQuery("body", "bro")),
+			NewFieldMaskingSpanQuery(NewSpanTermQuery("body.stems", "fox"), "body"),
+		).Slop(5),
+		NewSpanOrQuery(NewSpanTermQuery("body", "lazy"), NewSpanTermQuery("title", "dog")),
+	)
+	fields, err := QueryFields(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"body", "body.stems", "title"}, fields; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected fields %v; got: %v", want, have)
+	}
+
+	// Removing a clause of the span_or keeps the span_not
+	rewritten, err := RewriteQuery(q, func(q Query) (Query, error) {
+		if st, ok := q.(*SpanTermQuery); ok && st.FieldName() == "title" {
+			return nil, nil
+		}
+		return q, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"span_not":{"exclude":{"span_or":{"clauses":[{"span_term":{"body":"lazy"}}]}},"include":{"span_near":{"clauses":[{"span_term":{"body":"quick"}},{"span_multi":{"match":{"prefix":{"body":"bro"}}}},{"field_masking_span":{"field":"body","query":{"span_term":{"body.stems":"fox"}}}}],"slop":5}}}}`
+	if have := queryJSON(t, rewritten); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+
+	// Removing the exclude clause removes the span_not
+	rewritten, err = RewriteQuery(q, func(q Query) (Query, error) {
+		if _, ok := q.(*SpanOrQuery); ok {
+			return nil, nil
+		}
+		return q, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rewritten != nil {
+		t.Errorf("expected nil; got: %s", queryJSON(t, rewritten))
+	}
+}
+
+func TestRewriteRelevanceQueries(t *testing.T) {
+	q := NewPinnedQuery(
+		NewScriptScoreQuery(
+			NewBoolQuery().Should(NewMatchQuery("title", "phone"), NewRankFeatureQuery("pagerank")),
+			NewScript("_score * 2"),
+		),
+		"1",
+	)
+	fields, err := QueryFields(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"title", "pagerank"}, fields; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected fields %v; got: %v", want, have)
+	}
+
+	rewritten, err := RewriteQuery(q, func(q Query) (Query, error) {
+		if _, ok := q.(*RankFeatureQuery); ok {
+			return nil, nil
+		}
+		return q, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"pinned":{"ids":["1"],"organic":{"script_score":{"query":{"bool":{"should":{"match":{"title":{"query":"phone"}}}}},"script":{"source":"_score * 2"}}}}}`
+	if have := queryJSON(t, rewritten); want != have {
+		t.Errorf("expected\n%s\ngot\n%s", want, have)
+	}
+}
@@ -47,6 +47,11 @@ func (q *WildcardQuery) QueryName(queryName string) *WildcardQuery {
 	return q
 }
 
+// FieldName returns the name of the field the query runs on.
+func (q *WildcardQuery) FieldName() string {
+	return q.name
+}
+
 // Source returns the JSON serializable body of this query.
 func (q *WildcardQuery) Source() (interface{}, error) {
 	// {
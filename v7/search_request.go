@@ -302,6 +302,37 @@ func (r *SearchRequest) StoredFields(fields ...string) *SearchRequest {
 	return r
 }
 
+// FetchField adds a field to retrieve with the fields parameter.
+func (r *SearchRequest) FetchField(field string) *SearchRequest {
+	r.searchSource = r.searchSource.FetchField(field)
+	return r
+}
+
+// FetchFieldWithFormat adds a field to retrieve with the fields parameter.
+func (r *SearchRequest) FetchFieldWithFormat(field FieldAndFormat) *SearchRequest {
+	r.searchSource = r.searchSource.FetchFieldWithFormat(field)
+	return r
+}
+
+// FetchFields adds one or more fields to retrieve with the fields parameter.
+func (r *SearchRequest) FetchFields(fields ...string) *SearchRequest {
+	r.searchSource = r.searchSource.FetchFields(fields...)
+	return r
+}
+
+// FetchFieldsWithFormat adds one or more fields to retrieve with the
+// fields parameter.
+func (r *SearchRequest) FetchFieldsWithFormat(fields ...FieldAndFormat) *SearchRequest {
+	r.searchSource = r.searchSource.FetchFieldsWithFormat(fields...)
+	return r
+}
+
+// RuntimeMapping adds a runtime field that is only defined for this request.
+func (r *SearchRequest) RuntimeMapping(name string, mapping *RuntimeMapping) *SearchRequest {
+	r.searchSource = r.searchSource.RuntimeMapping(name, mapping)
+	return r
+}
+
 // ScriptField adds a script based field to load and return.
 // The field does not have to be stored, but it's recommended
 // to use non analyzed or numeric fields.
@@ -513,13 +544,18 @@ func (r *SearchRequest) header() interface{} {
 // of one SearchRequest.
 // See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-multi-search.html
 func (r *SearchRequest) Body() (string, error) {
+	return r.body(&DefaultEncoder{})
+}
+
+// body returns the search body of the request, encoded with the given encoder.
+func (r *SearchRequest) body(encoder Encoder) (string, error) {
 	if r.source == nil {
 		// Default: No custom source specified
 		src, err := r.searchSource.Source()
 		if err != nil {
 			return "", err
 		}
-		body, err := json.Marshal(src)
+		body, err := encoder.Encode(src)
 		if err != nil {
 			return "", err
 		}
@@ -527,7 +563,7 @@ func (r *SearchRequest) Body() (string, error) {
 	}
 	switch t := r.source.(type) {
 	default:
-		body, err := json.Marshal(r.source)
+		body, err := encoder.Encode(r.source)
 		if err != nil {
 			return "", err
 		}
@@ -537,7 +573,7 @@ func (r *SearchRequest) Body() (string, error) {
 		if err != nil {
 			return "", err
 		}
-		body, err := json.Marshal(src)
+		body, err := encoder.Encode(src)
 		if err != nil {
 			return "", err
 		}
@@ -27,7 +27,9 @@ type SearchSource struct {
 	terminateAfter           *int
 	storedFieldNames         []string
 	docvalueFields           DocvalueFields
+	fields                   FieldsAndFormats
 	scriptFields             []*ScriptField
+	runtimeMappings          RuntimeMappings
 	fetchSourceContext       *FetchSourceContext
 	aggregations             map[string]Aggregation
 	highlight                *Highlight
@@ -40,6 +42,8 @@ type SearchSource struct {
 	innerHits                map[string]*InnerHit
 	collapse                 *CollapseBuilder
 	profile                  bool
+	pointInTime              *PointInTime
+	unparsed                 map[string]interface{} // see ParseSearchSource
 	// TODO extBuilders []SearchExtBuilder
 }
 
@@ -176,6 +180,16 @@ func (s *SearchSource) TrackTotalHits(trackTotalHits interface{}) *SearchSource
 	return s
 }
 
+// PointInTime specifies an optional PointInTime to be used in the context
+// of this search.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/point-in-time-api.html
+// for details.
+func (s *SearchSource) PointInTime(pointInTime *PointInTime) *SearchSource {
+	s.pointInTime = pointInTime
+	return s
+}
+
 // SearchAfter allows a different form of pagination by using a live cursor,
 // using the results of the previous page to help the retrieval of the next.
 //
@@ -317,6 +331,48 @@ func (s *SearchSource) DocvalueFieldsWithFormat(docvalueFields ...DocvalueField)
 	return s
 }
 
+// FetchField adds a single field to retrieve with the fields parameter
+// and return as part of the search request. The field may be a wildcard
+// pattern. Unlike docvalue fields, the values are taken from the mapping
+// and include runtime fields.
+func (s *SearchSource) FetchField(field string) *SearchSource {
+	s.fields = append(s.fields, FieldAndFormat{Field: field})
+	return s
+}
+
+// FetchFieldWithFormat adds a single field to retrieve with the fields
+// parameter and return as part of the search request.
+func (s *SearchSource) FetchFieldWithFormat(field FieldAndFormat) *SearchSource {
+	s.fields = append(s.fields, field)
+	return s
+}
+
+// FetchFields adds one or more fields to retrieve with the fields
+// parameter and return as part of the search request.
+func (s *SearchSource) FetchFields(fields ...string) *SearchSource {
+	for _, f := range fields {
+		s.fields = append(s.fields, FieldAndFormat{Field: f})
+	}
+	return s
+}
+
+// FetchFieldsWithFormat adds one or more fields to retrieve with the
+// fields parameter and return as part of the search request.
+func (s *SearchSource) FetchFieldsWithFormat(fields ...FieldAndFormat) *SearchSource {
+	s.fields = append(s.fields, fields...)
+	return s
+}
+
+// RuntimeMapping adds a runtime field that is only defined for this
+// search request. It replaces a runtime field with the same name.
+func (s *SearchSource) RuntimeMapping(name string, mapping *RuntimeMapping) *SearchSource {
+	if s.runtimeMappings == nil {
+		s.runtimeMappings = make(RuntimeMappings)
+	}
+	s.runtimeMappings[name] = mapping
+	return s
+}
+
 // ScriptField adds a single script field with the provided script.
 func (s *SearchSource) ScriptField(scriptField *ScriptField) *SearchSource {
 	s.scriptFields = append(s.scriptFields, scriptField)
@@ -418,6 +474,13 @@ func (s *SearchSource) Source() (interface{}, error) {
 		}
 		source["docvalue_fields"] = src
 	}
+	if len(s.fields) > 0 {
+		src, err := s.fields.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["fields"] = src
+	}
 	if len(s.scriptFields) > 0 {
 		sfmap := make(map[string]interface{})
 		for _, scriptField := range s.scriptFields {
@@ -429,6 +492,13 @@ func (s *SearchSource) Source() (interface{}, error) {
 		}
 		source["script_fields"] = sfmap
 	}
+	if len(s.runtimeMappings) > 0 {
+		src, err := s.runtimeMappings.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["runtime_mappings"] = src
+	}
 	if len(s.sorters) > 0 {
 		var sortarr []interface{}
 		for _, sorter := range s.sorters {
@@ -449,6 +519,13 @@ func (s *SearchSource) Source() (interface{}, error) {
 	if len(s.searchAfterSortValues) > 0 {
 		source["search_after"] = s.searchAfterSortValues
 	}
+	if s.pointInTime != nil {
+		src, err := s.pointInTime.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["pit"] = src
+	}
 	if s.sliceQuery != nil {
 		src, err := s.sliceQuery.Source()
 		if err != nil {
@@ -576,5 +653,12 @@ func (s *SearchSource) Source() (interface{}, error) {
 		source["inner_hits"] = m
 	}
 
+	// Keys that ParseSearchSource cannot represent with the builders
+	for k, v := range s.unparsed {
+		if _, found := source[k]; !found {
+			source[k] = v
+		}
+	}
+
 	return source, nil
 }
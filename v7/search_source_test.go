@@ -123,6 +123,47 @@ func TestSearchSourceDocvalueFields(t *testing.T) {
 	}
 }
 
+func TestSearchSourceFetchFields(t *testing.T) {
+	matchAllQ := NewMatchAllQuery()
+	builder := NewSearchSource().Query(matchAllQ).
+		FetchFields("user", "tags.*").
+		FetchFieldWithFormat(FieldAndFormat{Field: "created", Format: "epoch_millis"})
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"fields":["user","tags.*",{"field":"created","format":"epoch_millis"}],"query":{"match_all":{}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchSourceRuntimeMappings(t *testing.T) {
+	builder := NewSearchSource().
+		Query(NewTermQuery("day_of_week", "MONDAY")).
+		RuntimeMapping("day_of_week", NewRuntimeMapping("keyword").
+			Script(NewScript("emit(doc['created'].value.dayOfWeekEnum.toString())"))).
+		FetchField("day_of_week")
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"fields":["day_of_week"],"query":{"term":{"day_of_week":"MONDAY"}},"runtime_mappings":{"day_of_week":{"script":{"source":"emit(doc['created'].value.dayOfWeekEnum.toString())"},"type":"keyword"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestSearchSourceScriptFields(t *testing.T) {
 	matchAllQ := NewMatchAllQuery()
 	sf1 := NewScriptField("test1", NewScript("doc['my_field_name'].value * 2"))
@@ -281,6 +322,26 @@ func TestSearchSourceSearchAfter(t *testing.T) {
 	}
 }
 
+func TestSearchSourcePointInTime(t *testing.T) {
+	matchAllQ := NewMatchAllQuery()
+	builder := NewSearchSource().Query(matchAllQ).
+		PointInTime(NewPointInTimeWithKeepAlive("pit_id", "1m")).
+		SortBy(SortByShardDoc{})
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"pit":{"id":"pit_id","keep_alive":"1m"},"query":{"match_all":{}},"sort":["_shard_doc"]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestSearchSourceProfiledQuery(t *testing.T) {
 	matchAllQ := NewMatchAllQuery()
 	builder := NewSearchSource().Query(matchAllQ).Profile(true)
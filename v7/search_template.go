@@ -0,0 +1,291 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/facert/elastic/v7/uritemplates"
+)
+
+// SearchTemplateService runs a search with a mustache template. The
+// template is either given inline via Source or refers to a stored
+// template via Id. Use PutScriptService.SearchTemplate to store a
+// template.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/search-template.html
+// for details.
+type SearchTemplateService struct {
+	client            *Client
+	pretty            bool
+	index             []string
+	template          searchTemplate
+	searchType        string
+	routing           string
+	preference        string
+	scroll            string
+	ignoreUnavailable *bool
+	allowNoIndices    *bool
+	expandWildcards   string
+	maxResponseSize   int64
+}
+
+// NewSearchTemplateService creates a new SearchTemplateService.
+func NewSearchTemplateService(client *Client) *SearchTemplateService {
+	return &SearchTemplateService{
+		client: client,
+	}
+}
+
+// Index sets the names of the indices to search.
+func (s *SearchTemplateService) Index(index ...string) *SearchTemplateService {
+	s.index = append(s.index, index...)
+	return s
+}
+
+// Id sets the id of a stored template.
+func (s *SearchTemplateService) Id(id string) *SearchTemplateService {
+	s.template.id = id
+	return s
+}
+
+// Source sets an inline template, either as a string or as a
+// serializable object.
+func (s *SearchTemplateService) Source(source interface{}) *SearchTemplateService {
+	s.template.source = source
+	return s
+}
+
+// Params sets the parameters of the template, e.g. a map or a struct
+// that serializes into a JSON object.
+func (s *SearchTemplateService) Params(params interface{}) *SearchTemplateService {
+	s.template.params = params
+	return s
+}
+
+// Param adds a parameter of the template. It replaces parameters set
+// via Params that are not a map[string]interface{}.
+func (s *SearchTemplateService) Param(name string, value interface{}) *SearchTemplateService {
+	s.template.param(name, value)
+	return s
+}
+
+// Explain indicates whether to return an explanation of the score
+// for each hit.
+func (s *SearchTemplateService) Explain(explain bool) *SearchTemplateService {
+	s.template.explain = &explain
+	return s
+}
+
+// Profile indicates whether to profile the query execution.
+func (s *SearchTemplateService) Profile(profile bool) *SearchTemplateService {
+	s.template.profile = &profile
+	return s
+}
+
+// SearchType sets the search operation type. Valid values are:
+// "dfs_query_then_fetch" and "query_then_fetch".
+func (s *SearchTemplateService) SearchType(searchType string) *SearchTemplateService {
+	s.searchType = searchType
+	return s
+}
+
+// Routing is a list of specific routing values to control the shards
+// the search will be executed on.
+func (s *SearchTemplateService) Routing(routings ...string) *SearchTemplateService {
+	s.routing = strings.Join(routings, ",")
+	return s
+}
+
+// Preference sets the preference to execute the search. Defaults to
+// randomize across shards ("random").
+func (s *SearchTemplateService) Preference(preference string) *SearchTemplateService {
+	s.preference = preference
+	return s
+}
+
+// Scroll specifies how long a consistent view of the index should be
+// maintained for scrolled search, e.g. "1m".
+func (s *SearchTemplateService) Scroll(scroll string) *SearchTemplateService {
+	s.scroll = scroll
+	return s
+}
+
+// IgnoreUnavailable indicates whether the specified concrete indices
+// should be ignored when unavailable (missing or closed).
+func (s *SearchTemplateService) IgnoreUnavailable(ignoreUnavailable bool) *SearchTemplateService {
+	s.ignoreUnavailable = &ignoreUnavailable
+	return s
+}
+
+// AllowNoIndices indicates whether to ignore if a wildcard indices
+// expression resolves into no concrete indices.
+func (s *SearchTemplateService) AllowNoIndices(allowNoIndices bool) *SearchTemplateService {
+	s.allowNoIndices = &allowNoIndices
+	return s
+}
+
+// ExpandWildcards indicates whether to expand wildcard expression to
+// concrete indices that are open, closed or both.
+func (s *SearchTemplateService) ExpandWildcards(expandWildcards string) *SearchTemplateService {
+	s.expandWildcards = expandWildcards
+	return s
+}
+
+// MaxResponseSize sets an upper limit on the response body size that we accept,
+// to guard against OOM situations.
+func (s *SearchTemplateService) MaxResponseSize(maxResponseSize int64) *SearchTemplateService {
+	s.maxResponseSize = maxResponseSize
+	return s
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *SearchTemplateService) Pretty(pretty bool) *SearchTemplateService {
+	s.pretty = pretty
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *SearchTemplateService) buildURL() (string, url.Values, error) {
+	var err error
+	var path string
+
+	if len(s.index) > 0 {
+		path, err = uritemplates.Expand("/{index}/_search/template", map[string]string{
+			"index": strings.Join(s.index, ","),
+		})
+	} else {
+		path = "/_search/template"
+	}
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.searchType != "" {
+		params.Set("search_type", s.searchType)
+	}
+	if s.routing != "" {
+		params.Set("routing", s.routing)
+	}
+	if s.preference != "" {
+		params.Set("preference", s.preference)
+	}
+	if s.scroll != "" {
+		params.Set("scroll", s.scroll)
+	}
+	if s.ignoreUnavailable != nil {
+		params.Set("ignore_unavailable", fmt.Sprintf("%v", *s.ignoreUnavailable))
+	}
+	if s.allowNoIndices != nil {
+		params.Set("allow_no_indices", fmt.Sprintf("%v", *s.allowNoIndices))
+	}
+	if s.expandWildcards != "" {
+		params.Set("expand_wildcards", s.expandWildcards)
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *SearchTemplateService) Validate() error {
+	return s.template.validate()
+}
+
+// Do executes the search and returns a SearchResult.
+func (s *SearchTemplateService) Do(ctx context.Context) (*SearchResult, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:          "POST",
+		Path:            path,
+		Params:          params,
+		Body:            s.template.body(),
+		MaxResponseSize: s.maxResponseSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return search results
+	ret := new(SearchResult)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// -- Template --
+
+// searchTemplate is the body of a search template request, shared by
+// SearchTemplateService, SearchTemplateRequest, and
+// RenderSearchTemplateService.
+type searchTemplate struct {
+	id      string
+	source  interface{}
+	params  interface{}
+	explain *bool
+	profile *bool
+}
+
+func (t *searchTemplate) param(name string, value interface{}) {
+	params, ok := t.params.(map[string]interface{})
+	if !ok {
+		params = make(map[string]interface{})
+		t.params = params
+	}
+	params[name] = value
+}
+
+func (t *searchTemplate) validate() error {
+	var invalid []string
+	if t.id == "" && t.source == nil {
+		invalid = append(invalid, "Id || Source")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	if t.id != "" && t.source != nil {
+		return errors.New("elastic: specify either Id or Source of a search template, not both")
+	}
+	return nil
+}
+
+func (t *searchTemplate) body() map[string]interface{} {
+	body := make(map[string]interface{})
+	if t.id != "" {
+		body["id"] = t.id
+	}
+	if t.source != nil {
+		body["source"] = t.source
+	}
+	if t.params != nil {
+		body["params"] = t.params
+	}
+	if t.explain != nil {
+		body["explain"] = *t.explain
+	}
+	if t.profile != nil {
+		body["profile"] = *t.profile
+	}
+	return body
+}
@@ -0,0 +1,114 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// templateTestServer records the last request and replies with a
+// fixed response.
+type templateTestServer struct {
+	method   string
+	path     string
+	query    string
+	body     string
+	response string
+}
+
+func (s *templateTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	s.method = r.Method
+	s.path = r.URL.Path
+	s.query = r.URL.RawQuery
+	s.body = string(body)
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, s.response)
+}
+
+func TestSearchTemplate(t *testing.T) {
+	rec := &templateTestServer{
+		response: `{"took":1,"hits":{"total":{"value":1,"relation":"eq"},"hits":[{"_index":"products","_id":"1","_source":{"name":"phone"}}]}}`,
+	}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type productSearch struct {
+		Text string `json:"text"`
+		Size int    `json:"size"`
+	}
+	res, err := client.SearchTemplate("products").
+		Id("product-search").
+		Params(productSearch{Text: "phone", Size: 5}).
+		Routing("a", "b").
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(1), res.TotalHits(); want != have {
+		t.Fatalf("expected %d hits; got: %d", want, have)
+	}
+	if want, have := "POST", rec.method; want != have {
+		t.Errorf("expected method %q; got: %q", want, have)
+	}
+	if want, have := "/products/_search/template", rec.path; want != have {
+		t.Errorf("expected path %q; got: %q", want, have)
+	}
+	if want, have := "routing=a%2Cb", rec.query; want != have {
+		t.Errorf("expected query %q; got: %q", want, have)
+	}
+	if want, have := `{"id":"product-search","params":{"text":"phone","size":5}}`, rec.body; want != have {
+		t.Errorf("expected body\n%s\ngot\n%s", want, have)
+	}
+}
+
+func TestSearchTemplateInline(t *testing.T) {
+	rec := &templateTestServer{response: `{"took":1,"hits":{"total":{"value":0,"relation":"eq"},"hits":[]}}`}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.SearchTemplate().
+		Source(`{"query":{"match":{"name":"{{text}}"}}}`).
+		Param("text", "phone").
+		Explain(true).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/_search/template", rec.path; want != have {
+		t.Errorf("expected path %q; got: %q", want, have)
+	}
+	if want, have := `{"explain":true,"params":{"text":"phone"},"source":"{\"query\":{\"match\":{\"name\":\"{{text}}\"}}}"}`, rec.body; want != have {
+		t.Errorf("expected body\n%s\ngot\n%s", want, have)
+	}
+}
+
+func TestSearchTemplateValidate(t *testing.T) {
+	client, err := NewSimpleClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SearchTemplate().Validate(); err == nil {
+		t.Fatal("expected error without Id or Source")
+	}
+	if err := client.SearchTemplate().Id("a").Source("{}").Validate(); err == nil {
+		t.Fatal("expected error with both Id and Source")
+	}
+}
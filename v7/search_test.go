@@ -241,6 +241,104 @@ func TestSearchResultEachNoSource(t *testing.T) {
 	}
 }
 
+func TestSearchHitDecodeFields(t *testing.T) {
+	var hit SearchHit
+	err := json.Unmarshal([]byte(`{"_id":"1","fields":{"user":["olivere"],"tags":["golang","elasticsearch"],"retweets":[108]}}`), &hit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields struct {
+		User     []string `json:"user"`
+		Tags     []string `json:"tags"`
+		Retweets []int    `json:"retweets"`
+	}
+	if err := hit.DecodeFields(&fields); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"olivere"}, fields.User; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected User=%v; got: %v", want, have)
+	}
+	if want, have := []int{108}, fields.Retweets; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected Retweets=%v; got: %v", want, have)
+	}
+
+	var tags []string
+	found, err := hit.DecodeField("tags", &tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("expected to find field tags")
+	}
+	if want, have := []string{"golang", "elasticsearch"}, tags; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected tags=%v; got: %v", want, have)
+	}
+
+	var retweets int64
+	found, err = hit.DecodeFieldValue("retweets", &retweets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("expected to find field retweets")
+	}
+	if want, have := int64(108), retweets; want != have {
+		t.Errorf("expected retweets=%d; got: %d", want, have)
+	}
+
+	var missing string
+	found, err = hit.DecodeFieldValue("missing", &missing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Errorf("expected field missing to not be found; got: %q", missing)
+	}
+
+	var user int
+	if _, err := hit.DecodeFieldValue("user", &user); err == nil {
+		t.Error("expected error decoding a string into an int")
+	}
+}
+
+func TestSearchHitDecodeFieldsPrecision(t *testing.T) {
+	data := []byte(`{"hits":{"hits":[{"_id":"1","fields":{"id":[9007199254740993]},"inner_hits":{"comments":{"hits":{"hits":[{"_id":"2","fields":{"id":[9007199254740995]}}]}}}}]}}`)
+	for _, decoder := range []Decoder{&DefaultDecoder{}, &NumberDecoder{}} {
+		var res SearchResult
+		if err := decoder.Decode(data, &res); err != nil {
+			t.Fatal(err)
+		}
+		hit := res.Hits.Hits[0]
+
+		var fields struct {
+			ID []int64 `json:"id"`
+		}
+		if err := hit.DecodeFields(&fields); err != nil {
+			t.Fatal(err)
+		}
+		if want, have := []int64{9007199254740993}, fields.ID; !reflect.DeepEqual(want, have) {
+			t.Errorf("%T: expected ID=%v; got: %v", decoder, want, have)
+		}
+
+		var ids []int64
+		if _, err := hit.DecodeField("id", &ids); err != nil {
+			t.Fatal(err)
+		}
+		if want, have := []int64{9007199254740993}, ids; !reflect.DeepEqual(want, have) {
+			t.Errorf("%T: expected ids=%v; got: %v", decoder, want, have)
+		}
+
+		var id int64
+		if _, err := hit.InnerHits["comments"].Hits.Hits[0].DecodeFieldValue("id", &id); err != nil {
+			t.Fatal(err)
+		}
+		if want, have := int64(9007199254740995), id; want != have {
+			t.Errorf("%T: expected id=%d; got: %d", decoder, want, have)
+		}
+	}
+}
+
 func TestSearchSorting(t *testing.T) {
 	client := setupTestClientAndCreateIndex(t)
 
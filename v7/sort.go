@@ -4,7 +4,10 @@
 
 package elastic
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+)
 
 // -- Sorter --
 
@@ -105,6 +108,41 @@ func (s SortByDoc) Source() (interface{}, error) {
 	return "_doc", nil
 }
 
+// -- SortByShardDoc --
+
+// SortByShardDoc sorts by the "_shard_doc" field. It is the tiebreaker
+// for searches with a point in time, as described in
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/paginate-search-results.html#search-after.
+// It requires Elasticsearch 7.12 or later.
+//
+// Example:
+//   ss := elastic.NewSearchSource()
+//   ss = ss.SortBy(elastic.SortByShardDoc{})
+type SortByShardDoc struct {
+	Sorter
+}
+
+// Source returns the JSON-serializable data.
+func (s SortByShardDoc) Source() (interface{}, error) {
+	return "_shard_doc", nil
+}
+
+// -- RawSorter --
+
+// RawSorter is a sort clause given as JSON that is passed to
+// Elasticsearch unchanged. ParseSearchSource returns a RawSorter
+// for sort clauses it cannot represent with one of the sorters.
+//
+// Example:
+//   ss := elastic.NewSearchSource()
+//   ss = ss.SortBy(elastic.RawSorter(`{"price":{"order":"asc","numeric_type":"double"}}`))
+type RawSorter json.RawMessage
+
+// Source returns the JSON of the sort clause.
+func (s RawSorter) Source() (interface{}, error) {
+	return json.RawMessage(s), nil
+}
+
 // -- ScoreSort --
 
 // ScoreSort sorts by relevancy score.
@@ -0,0 +1,154 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/facert/elastic/v7/uritemplates"
+)
+
+// UpdateByQueryRethrottleService changes the throttle of a running update by query task.
+// Rethrottling that speeds up the task takes effect immediately, while
+// rethrottling that slows it down takes effect after completing the
+// current batch.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/docs-update-by-query.html#docs-update-by-query-rethrottle
+// for details.
+type UpdateByQueryRethrottleService struct {
+	client            *Client
+	pretty            bool
+	taskId            string
+	requestsPerSecond *float64
+	groupBy           string
+	headers           http.Header
+}
+
+// NewUpdateByQueryRethrottleService creates a new UpdateByQueryRethrottleService.
+func NewUpdateByQueryRethrottleService(client *Client) *UpdateByQueryRethrottleService {
+	return &UpdateByQueryRethrottleService{
+		client: client,
+	}
+}
+
+// TaskId specifies the update by query task to rethrottle. Notice that the caller
+// is responsible for using the correct format, i.e. node_id:task_number,
+// as specified in the REST API.
+func (s *UpdateByQueryRethrottleService) TaskId(taskId string) *UpdateByQueryRethrottleService {
+	s.taskId = taskId
+	return s
+}
+
+// TaskIdFromNodeAndId specifies the update by query task on the given node with
+// the specified id.
+func (s *UpdateByQueryRethrottleService) TaskIdFromNodeAndId(nodeId string, id int64) *UpdateByQueryRethrottleService {
+	s.taskId = fmt.Sprintf("%s:%d", nodeId, id)
+	return s
+}
+
+// RequestsPerSecond is the new throttle for the task in sub-requests per
+// second. Use -1 to disable throttling.
+func (s *UpdateByQueryRethrottleService) RequestsPerSecond(requestsPerSecond float64) *UpdateByQueryRethrottleService {
+	s.requestsPerSecond = &requestsPerSecond
+	return s
+}
+
+// GroupBy specifies how tasks are grouped in the response, i.e.
+// "nodes" (default), "parents", or "none".
+func (s *UpdateByQueryRethrottleService) GroupBy(groupBy string) *UpdateByQueryRethrottleService {
+	s.groupBy = groupBy
+	return s
+}
+
+// Header sets headers on the request
+func (s *UpdateByQueryRethrottleService) Header(name string, value string) *UpdateByQueryRethrottleService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *UpdateByQueryRethrottleService) Pretty(pretty bool) *UpdateByQueryRethrottleService {
+	s.pretty = pretty
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *UpdateByQueryRethrottleService) buildURL() (string, url.Values, error) {
+	// Build URL
+	path, err := uritemplates.Expand("/_update_by_query/{task_id}/_rethrottle", map[string]string{
+		"task_id": s.taskId,
+	})
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.requestsPerSecond != nil {
+		params.Set("requests_per_second", strconv.FormatFloat(*s.requestsPerSecond, 'f', -1, 64))
+	}
+	if s.groupBy != "" {
+		params.Set("group_by", s.groupBy)
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *UpdateByQueryRethrottleService) Validate() error {
+	var invalid []string
+	if s.taskId == "" {
+		invalid = append(invalid, "TaskId")
+	}
+	if s.requestsPerSecond == nil {
+		invalid = append(invalid, "RequestsPerSecond")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *UpdateByQueryRethrottleService) Do(ctx context.Context) (*TasksListResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "POST",
+		Path:    path,
+		Params:  params,
+		Headers: s.headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(TasksListResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	ret.Header = res.Header
+	return ret, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/facert/elastic/v7/uritemplates"
+)
+
+// XPackAsyncSearchDeleteService deletes an async search and its
+// results, and cancels the search if it is still running.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/async-search.html#delete-async-search
+// for details.
+type XPackAsyncSearchDeleteService struct {
+	client  *Client
+	pretty  bool
+	headers http.Header
+	id      string
+}
+
+// NewXPackAsyncSearchDeleteService creates a new XPackAsyncSearchDeleteService.
+func NewXPackAsyncSearchDeleteService(client *Client) *XPackAsyncSearchDeleteService {
+	return &XPackAsyncSearchDeleteService{
+		client: client,
+	}
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *XPackAsyncSearchDeleteService) Pretty(pretty bool) *XPackAsyncSearchDeleteService {
+	s.pretty = pretty
+	return s
+}
+
+// Header adds a header to the request.
+func (s *XPackAsyncSearchDeleteService) Header(name string, value string) *XPackAsyncSearchDeleteService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Id is the id of the async search.
+func (s *XPackAsyncSearchDeleteService) Id(id string) *XPackAsyncSearchDeleteService {
+	s.id = id
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *XPackAsyncSearchDeleteService) buildURL() (string, url.Values, error) {
+	// Build URL
+	path, err := uritemplates.Expand("/_async_search/{id}", map[string]string{
+		"id": s.id,
+	})
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *XPackAsyncSearchDeleteService) Validate() error {
+	var invalid []string
+	if s.id == "" {
+		invalid = append(invalid, "Id")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *XPackAsyncSearchDeleteService) Do(ctx context.Context) (*XPackAsyncSearchDeleteResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "DELETE",
+		Path:    path,
+		Params:  params,
+		Headers: s.headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(XPackAsyncSearchDeleteResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// XPackAsyncSearchDeleteResponse is the response of XPackAsyncSearchDeleteService.
+type XPackAsyncSearchDeleteResponse struct {
+	AcknowledgedResponse
+}
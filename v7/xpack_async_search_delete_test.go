@@ -0,0 +1,36 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAsyncSearchDelete(t *testing.T) {
+	rec := &templateTestServer{response: `{"acknowledged":true}`}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.XPackAsyncSearchDelete("search-1").Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "DELETE", rec.method; want != have {
+		t.Errorf("expected method %q; got: %q", want, have)
+	}
+	if want, have := "/_async_search/search-1", rec.path; want != have {
+		t.Errorf("expected path %q; got: %q", want, have)
+	}
+	if !res.Acknowledged {
+		t.Error("expected acknowledged response")
+	}
+}
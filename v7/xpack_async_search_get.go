@@ -0,0 +1,148 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/facert/elastic/v7/uritemplates"
+)
+
+// XPackAsyncSearchGetService retrieves the results of an async search,
+// which are partial while the search is still running.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/async-search.html#get-async-search
+// for details.
+type XPackAsyncSearchGetService struct {
+	client                   *Client
+	pretty                   bool
+	headers                  http.Header
+	id                       string
+	keepAlive                string
+	waitForCompletionTimeout string
+	maxResponseSize          int64
+}
+
+// NewXPackAsyncSearchGetService creates a new XPackAsyncSearchGetService.
+func NewXPackAsyncSearchGetService(client *Client) *XPackAsyncSearchGetService {
+	return &XPackAsyncSearchGetService{
+		client: client,
+	}
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *XPackAsyncSearchGetService) Pretty(pretty bool) *XPackAsyncSearchGetService {
+	s.pretty = pretty
+	return s
+}
+
+// Header adds a header to the request.
+func (s *XPackAsyncSearchGetService) Header(name string, value string) *XPackAsyncSearchGetService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Id is the id of the async search.
+func (s *XPackAsyncSearchGetService) Id(id string) *XPackAsyncSearchGetService {
+	s.id = id
+	return s
+}
+
+// KeepAlive extends the time the async search and its results are kept
+// available, e.g. "5d".
+func (s *XPackAsyncSearchGetService) KeepAlive(keepAlive string) *XPackAsyncSearchGetService {
+	s.keepAlive = keepAlive
+	return s
+}
+
+// WaitForCompletionTimeout specifies how long to wait for the search to
+// complete before returning partial results, e.g. "1s". By default, the
+// results are returned immediately.
+func (s *XPackAsyncSearchGetService) WaitForCompletionTimeout(timeout string) *XPackAsyncSearchGetService {
+	s.waitForCompletionTimeout = timeout
+	return s
+}
+
+// MaxResponseSize sets an upper limit on the response body size that we accept,
+// to guard against OOM situations.
+func (s *XPackAsyncSearchGetService) MaxResponseSize(maxResponseSize int64) *XPackAsyncSearchGetService {
+	s.maxResponseSize = maxResponseSize
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *XPackAsyncSearchGetService) buildURL() (string, url.Values, error) {
+	// Build URL
+	path, err := uritemplates.Expand("/_async_search/{id}", map[string]string{
+		"id": s.id,
+	})
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.keepAlive != "" {
+		params.Set("keep_alive", s.keepAlive)
+	}
+	if s.waitForCompletionTimeout != "" {
+		params.Set("wait_for_completion_timeout", s.waitForCompletionTimeout)
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *XPackAsyncSearchGetService) Validate() error {
+	var invalid []string
+	if s.id == "" {
+		invalid = append(invalid, "Id")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *XPackAsyncSearchGetService) Do(ctx context.Context) (*XPackAsyncSearchResult, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:          "GET",
+		Path:            path,
+		Params:          params,
+		Headers:         s.headers,
+		MaxResponseSize: s.maxResponseSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(XPackAsyncSearchResult)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAsyncSearchGet(t *testing.T) {
+	server := &asyncSearchTestServer{t: t, polls: 1}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.XPackAsyncSearchGet("search-1").KeepAlive("1d").Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.IsRunning || res.IsPartial {
+		t.Errorf("expected completed search; got: %+v", res)
+	}
+	if want, have := int64(2), res.Response.TotalHits(); want != have {
+		t.Errorf("expected %d hits; got: %d", want, have)
+	}
+	if want, have := "keep_alive=1d", server.getQuery; want != have {
+		t.Errorf("expected query %q; got: %q", want, have)
+	}
+}
+
+func TestAsyncSearchGetValidate(t *testing.T) {
+	client, err := NewSimpleClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.XPackAsyncSearchGet("").Validate(); err == nil {
+		t.Fatal("expected error without Id")
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/facert/elastic/v7/uritemplates"
+)
+
+// XPackAsyncSearchStatusService returns the status of an async search
+// without its results. It requires Elasticsearch 7.11 or later.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/async-search.html#get-async-search-status
+// for details.
+type XPackAsyncSearchStatusService struct {
+	client  *Client
+	pretty  bool
+	headers http.Header
+	id      string
+}
+
+// NewXPackAsyncSearchStatusService creates a new XPackAsyncSearchStatusService.
+func NewXPackAsyncSearchStatusService(client *Client) *XPackAsyncSearchStatusService {
+	return &XPackAsyncSearchStatusService{
+		client: client,
+	}
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *XPackAsyncSearchStatusService) Pretty(pretty bool) *XPackAsyncSearchStatusService {
+	s.pretty = pretty
+	return s
+}
+
+// Header adds a header to the request.
+func (s *XPackAsyncSearchStatusService) Header(name string, value string) *XPackAsyncSearchStatusService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Id is the id of the async search.
+func (s *XPackAsyncSearchStatusService) Id(id string) *XPackAsyncSearchStatusService {
+	s.id = id
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *XPackAsyncSearchStatusService) buildURL() (string, url.Values, error) {
+	// Build URL
+	path, err := uritemplates.Expand("/_async_search/status/{id}", map[string]string{
+		"id": s.id,
+	})
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *XPackAsyncSearchStatusService) Validate() error {
+	var invalid []string
+	if s.id == "" {
+		invalid = append(invalid, "Id")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *XPackAsyncSearchStatusService) Do(ctx context.Context) (*XPackAsyncSearchStatusResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "GET",
+		Path:    path,
+		Params:  params,
+		Headers: s.headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(XPackAsyncSearchStatusResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// XPackAsyncSearchStatusResponse is the response of XPackAsyncSearchStatusService.
+type XPackAsyncSearchStatusResponse struct {
+	Id                     string      `json:"id"`
+	IsRunning              bool        `json:"is_running"`
+	IsPartial              bool        `json:"is_partial"`
+	StartTimeInMillis      int64       `json:"start_time_in_millis,omitempty"`
+	ExpirationTimeInMillis int64       `json:"expiration_time_in_millis,omitempty"`
+	Shards                 *ShardsInfo `json:"_shards,omitempty"`
+	// CompletionStatus is the HTTP status code of the completed search.
+	// It is nil while the search is running.
+	CompletionStatus *int `json:"completion_status,omitempty"`
+}
@@ -0,0 +1,41 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAsyncSearchStatus(t *testing.T) {
+	rec := &templateTestServer{
+		response: `{"id":"search-1","is_running":false,"is_partial":false,"start_time_in_millis":1583945890986,"expiration_time_in_millis":1584377890986,"_shards":{"total":188,"successful":188,"skipped":0,"failed":0},"completion_status":200}`,
+	}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.XPackAsyncSearchStatus("search-1").Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/_async_search/status/search-1", rec.path; want != have {
+		t.Errorf("expected path %q; got: %q", want, have)
+	}
+	if res.IsRunning {
+		t.Error("expected completed search")
+	}
+	if res.CompletionStatus == nil || *res.CompletionStatus != 200 {
+		t.Errorf("expected completion status 200; got: %v", res.CompletionStatus)
+	}
+	if res.Shards == nil || res.Shards.Total != 188 {
+		t.Errorf("expected 188 shards; got: %+v", res.Shards)
+	}
+}
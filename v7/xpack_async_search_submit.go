@@ -0,0 +1,443 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/facert/elastic/v7/uritemplates"
+)
+
+// XPackAsyncSearchSubmitService submits a search request that runs
+// asynchronously. If the search does not complete within the wait for
+// completion timeout, the result contains the id of the search and
+// possibly partial results. Use XPackAsyncSearchGetService with the id
+// to retrieve the results later, or Poll to wait for the search to
+// complete.
+//
+// Async search requires Elasticsearch 7.7 or later.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/async-search.html
+// for details.
+type XPackAsyncSearchSubmitService struct {
+	client                   *Client
+	pretty                   bool
+	headers                  http.Header
+	searchSource             *SearchSource
+	source                   interface{}
+	index                    []string
+	keepAlive                string
+	waitForCompletionTimeout string
+	keepOnCompletion         *bool
+	batchedReduceSize        *int
+	searchType               string
+	routing                  string
+	preference               string
+	requestCache             *bool
+	ignoreUnavailable        *bool
+	allowNoIndices           *bool
+	expandWildcards          string
+	maxResponseSize          int64
+}
+
+// NewXPackAsyncSearchSubmitService creates a new XPackAsyncSearchSubmitService.
+func NewXPackAsyncSearchSubmitService(client *Client) *XPackAsyncSearchSubmitService {
+	return &XPackAsyncSearchSubmitService{
+		client:       client,
+		searchSource: NewSearchSource(),
+	}
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *XPackAsyncSearchSubmitService) Pretty(pretty bool) *XPackAsyncSearchSubmitService {
+	s.pretty = pretty
+	return s
+}
+
+// Header adds a header to the request.
+func (s *XPackAsyncSearchSubmitService) Header(name string, value string) *XPackAsyncSearchSubmitService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Index sets the names of the indices to use for search.
+func (s *XPackAsyncSearchSubmitService) Index(index ...string) *XPackAsyncSearchSubmitService {
+	s.index = append(s.index, index...)
+	return s
+}
+
+// KeepAlive specifies how long the async search and its results are
+// kept available, e.g. "5d". It defaults to 5 days.
+func (s *XPackAsyncSearchSubmitService) KeepAlive(keepAlive string) *XPackAsyncSearchSubmitService {
+	s.keepAlive = keepAlive
+	return s
+}
+
+// WaitForCompletionTimeout specifies how long to wait for the search to
+// complete before returning, e.g. "1s". It defaults to 1 second.
+func (s *XPackAsyncSearchSubmitService) WaitForCompletionTimeout(timeout string) *XPackAsyncSearchSubmitService {
+	s.waitForCompletionTimeout = timeout
+	return s
+}
+
+// KeepOnCompletion specifies whether to store the results of a search
+// that completes within the wait for completion timeout. It defaults
+// to false.
+func (s *XPackAsyncSearchSubmitService) KeepOnCompletion(keepOnCompletion bool) *XPackAsyncSearchSubmitService {
+	s.keepOnCompletion = &keepOnCompletion
+	return s
+}
+
+// BatchedReduceSize specifies the number of shard results that are
+// reduced at once, i.e. how often partial results become available.
+// It defaults to 5.
+func (s *XPackAsyncSearchSubmitService) BatchedReduceSize(size int) *XPackAsyncSearchSubmitService {
+	s.batchedReduceSize = &size
+	return s
+}
+
+// SearchSource sets the search source builder to use with this service.
+func (s *XPackAsyncSearchSubmitService) SearchSource(searchSource *SearchSource) *XPackAsyncSearchSubmitService {
+	s.searchSource = searchSource
+	if s.searchSource == nil {
+		s.searchSource = NewSearchSource()
+	}
+	return s
+}
+
+// Source allows the user to set the request body manually without using
+// any of the structs and interfaces in Elastic.
+func (s *XPackAsyncSearchSubmitService) Source(source interface{}) *XPackAsyncSearchSubmitService {
+	s.source = source
+	return s
+}
+
+// Query sets the query to perform, e.g. MatchAllQuery.
+func (s *XPackAsyncSearchSubmitService) Query(query Query) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.Query(query)
+	return s
+}
+
+// PostFilter will be executed after the query has been executed and
+// only affects the search hits, not the aggregations.
+func (s *XPackAsyncSearchSubmitService) PostFilter(postFilter Query) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.PostFilter(postFilter)
+	return s
+}
+
+// Aggregation adds an aggregation to perform as part of the search.
+func (s *XPackAsyncSearchSubmitService) Aggregation(name string, aggregation Aggregation) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.Aggregation(name, aggregation)
+	return s
+}
+
+// FetchSource indicates whether the response should contain the stored
+// _source for every hit.
+func (s *XPackAsyncSearchSubmitService) FetchSource(fetchSource bool) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.FetchSource(fetchSource)
+	return s
+}
+
+// FetchSourceContext indicates how the _source should be fetched.
+func (s *XPackAsyncSearchSubmitService) FetchSourceContext(fetchSourceContext *FetchSourceContext) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.FetchSourceContext(fetchSourceContext)
+	return s
+}
+
+// Highlight adds highlighting to the search.
+func (s *XPackAsyncSearchSubmitService) Highlight(highlight *Highlight) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.Highlight(highlight)
+	return s
+}
+
+// From index to start the search from. Defaults to 0.
+func (s *XPackAsyncSearchSubmitService) From(from int) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.From(from)
+	return s
+}
+
+// Size is the number of search hits to return. Defaults to 10.
+func (s *XPackAsyncSearchSubmitService) Size(size int) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.Size(size)
+	return s
+}
+
+// Sort adds a sort order.
+func (s *XPackAsyncSearchSubmitService) Sort(field string, ascending bool) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.Sort(field, ascending)
+	return s
+}
+
+// SortWithInfo adds a sort order.
+func (s *XPackAsyncSearchSubmitService) SortWithInfo(info SortInfo) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.SortWithInfo(info)
+	return s
+}
+
+// SortBy adds a sort order.
+func (s *XPackAsyncSearchSubmitService) SortBy(sorter ...Sorter) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.SortBy(sorter...)
+	return s
+}
+
+// TrackTotalHits controls if the total hit count for the query should be tracked.
+func (s *XPackAsyncSearchSubmitService) TrackTotalHits(trackTotalHits interface{}) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.TrackTotalHits(trackTotalHits)
+	return s
+}
+
+// Timeout sets the timeout of the search on each shard, e.g. "1s".
+func (s *XPackAsyncSearchSubmitService) Timeout(timeout string) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.Timeout(timeout)
+	return s
+}
+
+// TerminateAfter specifies the maximum number of documents to collect for
+// each shard, upon reaching which the query execution will terminate early.
+func (s *XPackAsyncSearchSubmitService) TerminateAfter(terminateAfter int) *XPackAsyncSearchSubmitService {
+	s.searchSource = s.searchSource.TerminateAfter(terminateAfter)
+	return s
+}
+
+// SearchType sets the search operation type. Valid values are:
+// "dfs_query_then_fetch" and "query_then_fetch".
+func (s *XPackAsyncSearchSubmitService) SearchType(searchType string) *XPackAsyncSearchSubmitService {
+	s.searchType = searchType
+	return s
+}
+
+// Routing is a list of specific routing values to control the shards
+// the search will be executed on.
+func (s *XPackAsyncSearchSubmitService) Routing(routings ...string) *XPackAsyncSearchSubmitService {
+	s.routing = strings.Join(routings, ",")
+	return s
+}
+
+// Preference sets the preference to execute the search. Defaults to
+// randomize across shards ("random").
+func (s *XPackAsyncSearchSubmitService) Preference(preference string) *XPackAsyncSearchSubmitService {
+	s.preference = preference
+	return s
+}
+
+// RequestCache indicates whether the cache should be used for this
+// request or not, defaults to index level setting.
+func (s *XPackAsyncSearchSubmitService) RequestCache(requestCache bool) *XPackAsyncSearchSubmitService {
+	s.requestCache = &requestCache
+	return s
+}
+
+// IgnoreUnavailable indicates whether the specified concrete indices
+// should be ignored when unavailable (missing or closed).
+func (s *XPackAsyncSearchSubmitService) IgnoreUnavailable(ignoreUnavailable bool) *XPackAsyncSearchSubmitService {
+	s.ignoreUnavailable = &ignoreUnavailable
+	return s
+}
+
+// AllowNoIndices indicates whether to ignore if a wildcard indices
+// expression resolves into no concrete indices.
+func (s *XPackAsyncSearchSubmitService) AllowNoIndices(allowNoIndices bool) *XPackAsyncSearchSubmitService {
+	s.allowNoIndices = &allowNoIndices
+	return s
+}
+
+// ExpandWildcards indicates whether to expand wildcard expression to
+// concrete indices that are open, closed or both.
+func (s *XPackAsyncSearchSubmitService) ExpandWildcards(expandWildcards string) *XPackAsyncSearchSubmitService {
+	s.expandWildcards = expandWildcards
+	return s
+}
+
+// MaxResponseSize sets an upper limit on the response body size that we accept,
+// to guard against OOM situations.
+func (s *XPackAsyncSearchSubmitService) MaxResponseSize(maxResponseSize int64) *XPackAsyncSearchSubmitService {
+	s.maxResponseSize = maxResponseSize
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *XPackAsyncSearchSubmitService) buildURL() (string, url.Values, error) {
+	var err error
+	var path string
+
+	if len(s.index) > 0 {
+		path, err = uritemplates.Expand("/{index}/_async_search", map[string]string{
+			"index": strings.Join(s.index, ","),
+		})
+	} else {
+		path = "/_async_search"
+	}
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.keepAlive != "" {
+		params.Set("keep_alive", s.keepAlive)
+	}
+	if s.waitForCompletionTimeout != "" {
+		params.Set("wait_for_completion_timeout", s.waitForCompletionTimeout)
+	}
+	if s.keepOnCompletion != nil {
+		params.Set("keep_on_completion", fmt.Sprintf("%v", *s.keepOnCompletion))
+	}
+	if s.batchedReduceSize != nil {
+		params.Set("batched_reduce_size", fmt.Sprintf("%v", *s.batchedReduceSize))
+	}
+	if s.searchType != "" {
+		params.Set("search_type", s.searchType)
+	}
+	if s.routing != "" {
+		params.Set("routing", s.routing)
+	}
+	if s.preference != "" {
+		params.Set("preference", s.preference)
+	}
+	if s.requestCache != nil {
+		params.Set("request_cache", fmt.Sprintf("%v", *s.requestCache))
+	}
+	if s.ignoreUnavailable != nil {
+		params.Set("ignore_unavailable", fmt.Sprintf("%v", *s.ignoreUnavailable))
+	}
+	if s.allowNoIndices != nil {
+		params.Set("allow_no_indices", fmt.Sprintf("%v", *s.allowNoIndices))
+	}
+	if s.expandWildcards != "" {
+		params.Set("expand_wildcards", s.expandWildcards)
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *XPackAsyncSearchSubmitService) Validate() error {
+	return nil
+}
+
+// Do submits the search and returns its result, which is partial if the
+// search is still running.
+func (s *XPackAsyncSearchSubmitService) Do(ctx context.Context) (*XPackAsyncSearchResult, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup HTTP request body
+	var body interface{}
+	if s.source != nil {
+		body = s.source
+	} else {
+		src, err := s.searchSource.Source()
+		if err != nil {
+			return nil, err
+		}
+		body = src
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:          "POST",
+		Path:            path,
+		Params:          params,
+		Body:            body,
+		Headers:         s.headers,
+		MaxResponseSize: s.maxResponseSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(XPackAsyncSearchResult)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Poll submits the search and waits for it to complete. It calls fn with
+// every result, i.e. the partial results of the running search that are
+// returned by the submit and every subsequent retrieval, and finally the
+// completed result, which Poll returns as well. fn may be nil.
+//
+// Poll retrieves the results in intervals of the wait for completion
+// timeout, which defaults to 1 second. If retrieving the results fails,
+// or fn returns an error, Poll cancels the running search and returns the
+// error. The results of a completed search are kept until the keep alive
+// expires; use XPackAsyncSearchDeleteService to delete them earlier.
+func (s *XPackAsyncSearchSubmitService) Poll(ctx context.Context, fn func(*XPackAsyncSearchResult) error) (*XPackAsyncSearchResult, error) {
+	timeout := s.waitForCompletionTimeout
+	if timeout == "" {
+		timeout = "1s"
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if fn != nil {
+			if err := fn(res); err != nil {
+				s.cancel(res)
+				return nil, err
+			}
+		}
+		if !res.IsRunning {
+			return res, nil
+		}
+		next, err := NewXPackAsyncSearchGetService(s.client).
+			Id(res.Id).
+			WaitForCompletionTimeout(timeout).
+			MaxResponseSize(s.maxResponseSize).
+			Do(ctx)
+		if err != nil {
+			s.cancel(res)
+			return nil, err
+		}
+		res = next
+	}
+}
+
+// cancel deletes the async search of res if it is still running.
+func (s *XPackAsyncSearchSubmitService) cancel(res *XPackAsyncSearchResult) {
+	if !res.IsRunning || res.Id == "" {
+		return
+	}
+	// Use a new context as ctx might have been cancelled
+	_, _ = NewXPackAsyncSearchDeleteService(s.client).Id(res.Id).Do(context.Background())
+}
+
+// XPackAsyncSearchResult is the result of an async search, as returned
+// by XPackAsyncSearchSubmitService and XPackAsyncSearchGetService.
+type XPackAsyncSearchResult struct {
+	// Id of the async search. It is empty if the search completed within
+	// the wait for completion timeout and was not kept on completion.
+	Id string `json:"id,omitempty"`
+	// IsRunning is true while the search is still running.
+	IsRunning bool `json:"is_running"`
+	// IsPartial is true if Response contains partial results, either
+	// because the search is still running or because it failed on some
+	// shards.
+	IsPartial              bool          `json:"is_partial"`
+	StartTimeInMillis      int64         `json:"start_time_in_millis,omitempty"`
+	ExpirationTimeInMillis int64         `json:"expiration_time_in_millis,omitempty"`
+	Response               *SearchResult `json:"response,omitempty"`
+	Error                  *ErrorDetails `json:"error,omitempty"`
+}
@@ -0,0 +1,166 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// asyncSearchTestServer simulates an async search that completes after
+// a given number of retrievals, with one more hit on every retrieval.
+type asyncSearchTestServer struct {
+	t     *testing.T
+	polls int
+
+	mu       sync.Mutex
+	gets     int
+	deleted  []string
+	body     map[string]interface{}
+	query    string
+	getQuery string
+}
+
+func (s *asyncSearchTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/logs/_async_search":
+		s.query = r.URL.RawQuery
+		if err := json.NewDecoder(r.Body).Decode(&s.body); err != nil {
+			s.t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.writeResult(w, 0)
+	case r.Method == "GET" && r.URL.Path == "/_async_search/search-1":
+		s.gets++
+		s.getQuery = r.URL.RawQuery
+		s.writeResult(w, s.gets)
+	case r.Method == "DELETE" && r.URL.Path == "/_async_search/search-1":
+		s.deleted = append(s.deleted, "search-1")
+		fmt.Fprint(w, `{"acknowledged":true}`)
+	default:
+		s.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *asyncSearchTestServer) writeResult(w http.ResponseWriter, n int) {
+	running := n < s.polls
+	fmt.Fprintf(w, `{"id":"search-1","is_running":%v,"is_partial":%v,"start_time_in_millis":1583945890986,"expiration_time_in_millis":1584377890986,"response":{"took":%d,"timed_out":false,"hits":{"total":{"value":%d,"relation":"eq"},"hits":[]}}}`, running, running, n, n+1)
+}
+
+func TestAsyncSearchSubmit(t *testing.T) {
+	server := &asyncSearchTestServer{t: t, polls: 1}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.XPackAsyncSearchSubmit("logs").
+		Query(NewRangeQuery("@timestamp").Gte("now-5y")).
+		Aggregation("per_year", NewDateHistogramAggregation().Field("@timestamp").Interval("year")).
+		Size(0).
+		KeepAlive("2d").
+		WaitForCompletionTimeout("2s").
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "search-1", res.Id; want != have {
+		t.Errorf("expected id %q; got: %q", want, have)
+	}
+	if !res.IsRunning || !res.IsPartial {
+		t.Errorf("expected running search with partial results; got: %+v", res)
+	}
+	if want, have := int64(1), res.Response.TotalHits(); want != have {
+		t.Errorf("expected %d hits; got: %d", want, have)
+	}
+	if want, have := "keep_alive=2d&wait_for_completion_timeout=2s", server.query; want != have {
+		t.Errorf("expected query %q; got: %q", want, have)
+	}
+	if _, ok := server.body["aggregations"]; !ok {
+		t.Errorf("expected aggregations in body; got: %v", server.body)
+	}
+}
+
+func TestAsyncSearchSubmitPoll(t *testing.T) {
+	server := &asyncSearchTestServer{t: t, polls: 3}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var totals []int64
+	res, err := client.XPackAsyncSearchSubmit("logs").
+		Query(NewMatchAllQuery()).
+		WaitForCompletionTimeout("500ms").
+		Poll(context.Background(), func(res *XPackAsyncSearchResult) error {
+			totals = append(totals, res.Response.TotalHits())
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.IsRunning {
+		t.Error("expected completed search")
+	}
+	if want, have := int64(4), res.Response.TotalHits(); want != have {
+		t.Errorf("expected %d hits; got: %d", want, have)
+	}
+	if want, have := fmt.Sprint([]int64{1, 2, 3, 4}), fmt.Sprint(totals); want != have {
+		t.Errorf("expected intermediate totals %s; got: %s", want, have)
+	}
+	if want, have := "wait_for_completion_timeout=500ms", server.getQuery; want != have {
+		t.Errorf("expected query %q; got: %q", want, have)
+	}
+	if len(server.deleted) > 0 {
+		t.Errorf("expected completed search not to be deleted; got: %v", server.deleted)
+	}
+}
+
+func TestAsyncSearchSubmitPollCancel(t *testing.T) {
+	server := &asyncSearchTestServer{t: t, polls: 10}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errEnough := errors.New("enough")
+	_, err = client.XPackAsyncSearchSubmit("logs").
+		Poll(context.Background(), func(res *XPackAsyncSearchResult) error {
+			if res.Response.TotalHits() >= 2 {
+				return errEnough
+			}
+			return nil
+		})
+	if err != errEnough {
+		t.Fatalf("expected %v; got: %v", errEnough, err)
+	}
+	if want, have := 1, server.gets; want != have {
+		t.Errorf("expected %d retrievals; got: %d", want, have)
+	}
+	if want, have := fmt.Sprint([]string{"search-1"}), fmt.Sprint(server.deleted); want != have {
+		t.Errorf("expected running search to be deleted %s; got: %s", want, have)
+	}
+}